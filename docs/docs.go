@@ -1,6 +1,6 @@
 // GENERATED BY THE COMMAND ABOVE; DO NOT EDIT
 // This file was generated by swaggo/swag at
-// 2019-12-14 22:18:39.782789 +0800 CST m=+0.089314565
+// 2026-10-17 01:39:41.550585734 +0000 UTC m=+0.241548680
 
 package docs
 
@@ -29,14 +29,14 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/composite_index": {
+        "/audit_logs": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get composite index",
+                "description": "get audit log list",
                 "consumes": [
                     "application/json"
                 ],
@@ -44,74 +44,79 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "上证指数"
+                    "审计日志"
                 ],
-                "summary": "获取上证指数",
+                "summary": "查询审计日志",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "操作人ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "日期",
-                        "name": "composite_date",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "{\"status\":true}",
-                        "schema": {
-                            "$ref": "#/definitions/server.CompositeIndexDetailResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
+                        "description": "操作",
+                        "name": "action",
+                        "in": "query"
+                    },
                     {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "post composite index",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "上证指数"
-                ],
-                "summary": "添加或者更新上证指数",
-                "parameters": [
+                        "type": "string",
+                        "description": "操作对象类型",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
                     {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.CompositeIndexAddRequest"
-                        }
+                        "type": "integer",
+                        "description": "操作对象ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "begin_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CompositeIndexAddResponse"
+                            "$ref": "#/definitions/server.AuditLogListResponse"
                         }
                     }
                 }
             }
         },
-        "/customers": {
+        "/campaigns": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get customer list",
+                "description": "get check-in campaign list",
                 "consumes": [
                     "application/json"
                 ],
@@ -119,25 +124,19 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "客户"
+                    "签到活动"
                 ],
-                "summary": "获取客户列表",
+                "summary": "获取签到活动列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "用户名",
+                        "description": "活动名称",
                         "name": "name",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "联系电话",
-                        "name": "mobile",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "客户状态",
+                        "description": "活动状态",
                         "name": "status",
                         "in": "query"
                     },
@@ -160,18 +159,18 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CustomerListResponse"
+                            "$ref": "#/definitions/server.CampaignListResponse"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete customer",
+                "description": "edit check-in campaign",
                 "consumes": [
                     "application/json"
                 ],
@@ -179,9 +178,9 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "客户"
+                    "签到活动"
                 ],
-                "summary": "删除客户",
+                "summary": "编辑签到活动",
                 "parameters": [
                     {
                         "description": "参数",
@@ -190,7 +189,7 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/server.CustomerDelRequest"
+                            "$ref": "#/definitions/server.CampaignEditRequest"
                         }
                     }
                 ],
@@ -198,20 +197,18 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CustomerDelResponse"
+                            "$ref": "#/definitions/server.CampaignEditResponse"
                         }
                     }
                 }
-            }
-        },
-        "/customers/can_part_lucky_number_activity": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "can participate in the lucky number activity",
+                "description": "create check-in campaign",
                 "consumes": [
                     "application/json"
                 ],
@@ -219,27 +216,39 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "客户"
+                    "签到活动"
+                ],
+                "summary": "新增签到活动",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.CampaignAddRequest"
+                        }
+                    }
                 ],
-                "summary": "用户是否可以参与猜数字活动",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CanPartLuckyNumberActivityResponse"
+                            "$ref": "#/definitions/server.CampaignAddResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/checkin_record": {
+        "/campaigns/detail": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get customer checkin record",
+                "description": "get check-in campaign detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -247,33 +256,36 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "客户"
+                    "签到活动"
                 ],
-                "summary": "获取签到记录",
+                "summary": "获取签到活动详情",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "当该参数有值时以该参数为准，否则以token用户为准",
-                        "name": "customer_id",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "签到活动ID",
+                        "name": "campaign_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CheckinRecordResponse"
+                            "$ref": "#/definitions/server.CampaignDetailResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/campaigns/disable": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "customer exec checkin record",
+                "description": "disable check-in campaign",
                 "consumes": [
                     "application/json"
                 ],
@@ -281,27 +293,39 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "客户"
+                    "签到活动"
+                ],
+                "summary": "停用签到活动",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.CampaignDisableRequest"
+                        }
+                    }
                 ],
-                "summary": "用户执行签到",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.ExecCheckinRecordResponse"
+                            "$ref": "#/definitions/server.CampaignDisableResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/checkin_record/help": {
-            "post": {
+        "/composite_index": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "customer help other checkin",
+                "description": "get composite index",
                 "consumes": [
                     "application/json"
                 ],
@@ -309,39 +333,34 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "客户"
+                    "上证指数"
                 ],
-                "summary": "为他人补签",
+                "summary": "获取上证指数",
                 "parameters": [
                     {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.HelpCheckinRequest"
-                        }
+                        "type": "string",
+                        "description": "日期",
+                        "name": "composite_date",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.HelpCheckinResponse"
+                            "$ref": "#/definitions/server.CompositeIndexDetailResponse"
                         }
                     }
                 }
-            }
-        },
-        "/customers/checkin_record/refresh": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "customer refresh checkin record",
+                "description": "post composite index",
                 "consumes": [
                     "application/json"
                 ],
@@ -349,22 +368,39 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "客户"
+                    "上证指数"
+                ],
+                "summary": "添加或者更新上证指数",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.CompositeIndexAddRequest"
+                        }
+                    }
                 ],
-                "summary": "用户重新签到",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.RefreshCheckinRecordResponse"
+                            "$ref": "#/definitions/server.CompositeIndexAddResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/detail": {
+        "/customers": {
             "get": {
-                "description": "get customer detail",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get customer list",
                 "consumes": [
                     "application/json"
                 ],
@@ -374,12 +410,38 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "获取客户详情",
+                "summary": "获取客户列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "客户ID,客户访问时可不传",
-                        "name": "customer_id",
+                        "description": "用户名",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "联系电话",
+                        "name": "mobile",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
@@ -387,20 +449,18 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CustomerDetailResponse"
+                            "$ref": "#/definitions/server.CustomerListResponse"
                         }
                     }
                 }
-            }
-        },
-        "/customers/disable": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "disable customer",
+                "description": "delete customer",
                 "consumes": [
                     "application/json"
                 ],
@@ -410,7 +470,7 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "禁用客户",
+                "summary": "删除客户",
                 "parameters": [
                     {
                         "description": "参数",
@@ -419,7 +479,7 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/server.CustomerDisableRequest"
+                            "$ref": "#/definitions/server.CustomerDelRequest"
                         }
                     }
                 ],
@@ -427,20 +487,20 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CustomerDisableResponse"
+                            "$ref": "#/definitions/server.CustomerDelResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/issue_records": {
+        "/customers/badges": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "customer get issue record",
+                "description": "get customer earned and locked badges",
                 "consumes": [
                     "application/json"
                 ],
@@ -450,23 +510,25 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "客户查看我的福利",
+                "summary": "获取客户徽章",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.IssueRecordResponse"
+                            "$ref": "#/definitions/server.CustomerBadgesResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/customers/can_part_lucky_number_activity": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "customer exec issue record",
+                "description": "can participate in the lucky number activity",
                 "consumes": [
                     "application/json"
                 ],
@@ -476,37 +538,25 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "用户领取福利",
-                "parameters": [
-                    {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.ExecIssueRecordRequest"
-                        }
-                    }
-                ],
+                "summary": "用户是否可以参与猜数字活动",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.ExecIssueRecordResponse"
+                            "$ref": "#/definitions/server.CanPartLuckyNumberActivityResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/issue_records/is_supplement": {
+        "/customers/checkin_history": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "is supplement checkin",
+                "description": "get customer checkin calendar, streaks and claimed gifts",
                 "consumes": [
                     "application/json"
                 ],
@@ -516,20 +566,45 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "是否是补签",
+                "summary": "获取签到历史",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "日历月份，格式为2019-10，不传时为当前月",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "领取福利记录页码，默认为1",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "领取福利记录每页数量，默认为10",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.IsSupplementCheckinResponse"
+                            "$ref": "#/definitions/server.CheckinHistoryResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/login": {
-            "post": {
-                "description": "customer login",
+        "/customers/checkin_record": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get customer checkin record",
                 "consumes": [
                     "application/json"
                 ],
@@ -539,34 +614,31 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "客户登录",
+                "summary": "获取签到记录",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "微信回调code",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
+                        "description": "当该参数有值时以该参数为准，否则以token用户为准",
+                        "name": "customer_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CustomerLoginResponse"
+                            "$ref": "#/definitions/server.CheckinRecordResponse"
                         }
                     }
                 }
-            }
-        },
-        "/customers/lucky/before": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get lucky customer before",
+                "description": "customer exec checkin record",
                 "consumes": [
                     "application/json"
                 ],
@@ -576,25 +648,42 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "上期幸运儿",
+                "summary": "用户执行签到",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.ExecCheckinRecordRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.LuckyPeopleBeforeResponse"
+                            "$ref": "#/definitions/server.ExecCheckinRecordResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/lucky_number": {
-            "get": {
+        "/customers/checkin_record/credit": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get customer lucky number",
+                "description": "use a referral reward credit to make up the earliest missed day",
                 "consumes": [
                     "application/json"
                 ],
@@ -604,23 +693,33 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "获取用户猜的数字",
+                "summary": "使用免费补签次数补签",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.LuckyNumberDetailResponse"
+                            "$ref": "#/definitions/server.CreditCheckinResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/customers/checkin_record/help": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "post customer lucky number",
+                "description": "customer help other checkin",
                 "consumes": [
                     "application/json"
                 ],
@@ -630,7 +729,7 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "写入用户猜的数字",
+                "summary": "为他人补签",
                 "parameters": [
                     {
                         "description": "参数",
@@ -639,28 +738,34 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/server.LuckyNumberAddRequest"
+                            "$ref": "#/definitions/server.HelpCheckinRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.LuckyNumberAddResponse"
+                            "$ref": "#/definitions/server.HelpCheckinResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/lucky_number/before": {
-            "get": {
+        "/customers/checkin_record/refresh": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get customer lucky number before",
+                "description": "customer refresh checkin record",
                 "consumes": [
                     "application/json"
                 ],
@@ -670,20 +775,20 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "用户上期猜的数字",
+                "summary": "用户重新签到",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.LuckyNumberBeforeResponse"
+                            "$ref": "#/definitions/server.RefreshCheckinRecordResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/near_merchant": {
+        "/customers/detail": {
             "get": {
-                "description": "get customer near merchant",
+                "description": "get customer detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -693,32 +798,12 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "获取用户附近最近的几家店铺",
+                "summary": "获取客户详情",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "经度",
-                        "name": "lon",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "维度",
-                        "name": "lat",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "距离多少公里内，默认10",
-                        "name": "distince",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "返回数量，默认4个",
-                        "name": "num",
+                        "type": "string",
+                        "description": "客户ID,客户访问时可不传",
+                        "name": "customer_id",
                         "in": "query"
                     }
                 ],
@@ -726,20 +811,20 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.NearMerchantResponse"
+                            "$ref": "#/definitions/server.CustomerDetailResponse"
                         }
                     }
                 }
             }
         },
-        "/customers/qrcode": {
-            "get": {
+        "/customers/disable": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get customer qrcode",
+                "description": "disable customer",
                 "consumes": [
                     "application/json"
                 ],
@@ -749,20 +834,37 @@ var doc = `{
                 "tags": [
                     "客户"
                 ],
-                "summary": "获取客户所属二维码",
+                "summary": "禁用客户",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.CustomerDisableRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.CustomerDisableResponse"
                         }
                     }
                 }
             }
         },
-        "/files/download": {
-            "get": {
-                "description": "download file",
+        "/customers/help_invitations": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create help checkin invitation, valid until the end of current checkin cycle",
                 "consumes": [
                     "application/json"
                 ],
@@ -770,62 +872,42 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件"
-                ],
-                "summary": "下载文件",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "filename",
-                        "name": "filename",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "\"avatar\", \"poster\"",
-                        "description": "file type",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
-                    }
+                    "客户"
                 ],
+                "summary": "生成补签邀请",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.BaseResponse"
+                            "$ref": "#/definitions/server.HelpInvitationAddResponse"
                         }
                     }
                 }
             }
         },
-        "/files/upload": {
-            "post": {
-                "description": "upload file",
+        "/customers/help_invitations/open": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "open help checkin invitation, return inviter info",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "文件"
+                    "客户"
                 ],
-                "summary": "上传文件",
+                "summary": "打开补签邀请",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "upload file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "default": "\"avatar\", \"poster\"",
-                        "description": "file type",
-                        "name": "type",
+                        "description": "补签邀请token",
+                        "name": "token",
                         "in": "query",
                         "required": true
                     }
@@ -834,20 +916,20 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.BaseResponse"
+                            "$ref": "#/definitions/server.HelpInvitationOpenResponse"
                         }
                     }
                 }
             }
         },
-        "/merchants": {
+        "/customers/helpers": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get merchant list",
+                "description": "get friends who helped customer checkin",
                 "consumes": [
                     "application/json"
                 ],
@@ -855,39 +937,15 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
+                    "客户"
                 ],
-                "summary": "获取商户列表",
+                "summary": "获取帮我补签的好友",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "商户名",
-                        "name": "store_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "联系人",
-                        "name": "contact_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "联系电话",
-                        "name": "contact_phone",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "商户状态",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "页码",
-                        "name": "page_no",
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
                         "in": "query"
                     },
                     {
@@ -902,18 +960,20 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.MerchantListResponse"
+                            "$ref": "#/definitions/server.HelperListResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/customers/issue_records": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "edit merchant",
+                "description": "customer get issue record",
                 "consumes": [
                     "application/json"
                 ],
@@ -921,26 +981,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
-                ],
-                "summary": "编辑商户",
-                "parameters": [
-                    {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.MerchantEditRequest"
-                        }
-                    }
+                    "客户"
                 ],
+                "summary": "客户查看我的福利",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.MerchantEditResponse"
+                            "$ref": "#/definitions/server.IssueRecordResponse"
                         }
                     }
                 }
@@ -951,7 +999,7 @@ var doc = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "create new merchant",
+                "description": "customer exec issue record",
                 "consumes": [
                     "application/json"
                 ],
@@ -959,9 +1007,9 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
+                    "客户"
                 ],
-                "summary": "新增商户",
+                "summary": "用户领取福利",
                 "parameters": [
                     {
                         "description": "参数",
@@ -970,26 +1018,34 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/server.MerchantAddRequest"
+                            "$ref": "#/definitions/server.ExecIssueRecordRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.MerchantAddResponse"
+                            "$ref": "#/definitions/server.ExecIssueRecordResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/customers/issue_records/is_supplement": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete merchant",
+                "description": "is supplement checkin",
                 "consumes": [
                     "application/json"
                 ],
@@ -997,39 +1053,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
-                ],
-                "summary": "删除商户",
-                "parameters": [
-                    {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.MerchantDelRequest"
-                        }
-                    }
+                    "客户"
                 ],
+                "summary": "是否是补签",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.MerchantDelResponse"
+                            "$ref": "#/definitions/server.IsSupplementCheckinResponse"
                         }
                     }
                 }
             }
         },
-        "/merchants/detail": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get merchant detail",
+        "/customers/login": {
+            "post": {
+                "description": "customer login",
                 "consumes": [
                     "application/json"
                 ],
@@ -1037,14 +1076,21 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
+                    "客户"
                 ],
-                "summary": "获取商户详情信息",
+                "summary": "客户登录",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "商户ID,商户访问时可不传",
-                        "name": "merchant_id",
+                        "description": "微信回调code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "微信回调state，新用户通过邀请码注册时传入邀请码",
+                        "name": "state",
                         "in": "query"
                     }
                 ],
@@ -1052,20 +1098,20 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.MerchantDetailResponse"
+                            "$ref": "#/definitions/server.CustomerLoginResponse"
                         }
                     }
                 }
             }
         },
-        "/merchants/disable": {
-            "post": {
+        "/customers/lucky/before": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "disable merchant",
+                "description": "get lucky customer before",
                 "consumes": [
                     "application/json"
                 ],
@@ -1073,34 +1119,53 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
+                    "客户"
                 ],
-                "summary": "禁用商户",
-                "parameters": [
-                    {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
+                "summary": "上期幸运儿",
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
                         "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.MerchantDisableRequest"
+                            "$ref": "#/definitions/server.LuckyPeopleBeforeResponse"
                         }
                     }
+                }
+            }
+        },
+        "/customers/lucky_number": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get customer lucky number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
                 ],
+                "summary": "获取用户猜的数字",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.MerchantDisableResponse"
+                            "$ref": "#/definitions/server.LuckyNumberDetailResponse"
                         }
                     }
                 }
-            }
-        },
-        "/merchants/login": {
+            },
             "post": {
-                "description": "merchant login",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "post customer lucky number",
                 "consumes": [
                     "application/json"
                 ],
@@ -1108,9 +1173,9 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
+                    "客户"
                 ],
-                "summary": "商户登录",
+                "summary": "写入用户猜的数字",
                 "parameters": [
                     {
                         "description": "参数",
@@ -1119,7 +1184,7 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/model.MerchantLoginVO"
+                            "$ref": "#/definitions/server.LuckyNumberAddRequest"
                         }
                     }
                 ],
@@ -1127,20 +1192,20 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.MerchantLoginResponse"
+                            "$ref": "#/definitions/server.LuckyNumberAddResponse"
                         }
                     }
                 }
             }
         },
-        "/merchants/poster": {
+        "/customers/lucky_number/before": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get merchant poster",
+                "description": "get customer lucky number before",
                 "consumes": [
                     "application/json"
                 ],
@@ -1148,27 +1213,27 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
+                    "客户"
                 ],
-                "summary": "获取商户随机一张海报",
+                "summary": "用户上期猜的数字",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.LuckyNumberBeforeResponse"
                         }
                     }
                 }
             }
         },
-        "/merchants/writeoff": {
+        "/customers/messages": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "merchant get customer write off",
+                "description": "get customer inbox messages and unread count",
                 "consumes": [
                     "application/json"
                 ],
@@ -1176,34 +1241,43 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
+                    "客户"
                 ],
-                "summary": "商户获取核销信息",
+                "summary": "获取站内信",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "客户ID",
-                        "name": "customer_id",
-                        "in": "query",
-                        "required": true
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.WriteOffResponse"
+                            "$ref": "#/definitions/server.InboxMessageListResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/customers/messages/read": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "merchant exec write off",
+                "description": "mark all customer inbox messages as read",
                 "consumes": [
                     "application/json"
                 ],
@@ -1211,39 +1285,22 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商户"
-                ],
-                "summary": "商户核销",
-                "parameters": [
-                    {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.ExecWriteOffRequest"
-                        }
-                    }
+                    "客户"
                 ],
+                "summary": "站内信全部标记为已读",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.ExecWriteOffResponse"
+                            "$ref": "#/definitions/server.InboxMessageReadResponse"
                         }
                     }
                 }
             }
         },
-        "/stat/checkin": {
+        "/customers/near_merchant": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "stat customer exec checkin num",
+                "description": "get customer near merchant",
                 "consumes": [
                     "application/json"
                 ],
@@ -1251,43 +1308,55 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "统计"
+                    "客户"
                 ],
-                "summary": "统计用户执行签到数目",
+                "summary": "获取用户附近最近的几家店铺",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "开始日期",
-                        "name": "begin_date",
+                        "type": "integer",
+                        "description": "经度",
+                        "name": "lon",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "结束日期",
-                        "name": "end_date",
+                        "type": "integer",
+                        "description": "维度",
+                        "name": "lat",
                         "in": "query",
                         "required": true
-                    }
-                ],
+                    },
+                    {
+                        "type": "integer",
+                        "description": "距离多少公里内，默认10",
+                        "name": "distince",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "返回数量，默认4个",
+                        "name": "num",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CheckinStatResponse"
+                            "$ref": "#/definitions/server.NearMerchantResponse"
                         }
                     }
                 }
             }
         },
-        "/stat/register": {
+        "/customers/points": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "stat customer register num",
+                "description": "get customer points balance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1295,43 +1364,27 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "统计"
-                ],
-                "summary": "统计用户注册数",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "开始日期",
-                        "name": "begin_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束日期",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
-                    }
+                    "积分"
                 ],
+                "summary": "获取积分余额",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.RegisterStatResponse"
+                            "$ref": "#/definitions/server.PointsBalanceResponse"
                         }
                     }
                 }
             }
         },
-        "/users/checkin_record_list": {
+        "/customers/points/entries": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get checkin record list",
+                "description": "get customer points history",
                 "consumes": [
                     "application/json"
                 ],
@@ -1339,27 +1392,43 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "后台用户"
+                    "积分"
+                ],
+                "summary": "获取积分流水",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "获取签到记录列表(临时)",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CheckinRecordListResponse"
+                            "$ref": "#/definitions/server.PointsEntryListResponse"
                         }
                     }
                 }
             }
         },
-        "/users/checkin_record_list/modify": {
+        "/customers/points/redeem": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "users modify customer checkin record",
+                "description": "redeem points for free make-up days or extra gifts",
                 "consumes": [
                     "application/json"
                 ],
@@ -1367,9 +1436,9 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "后台用户"
+                    "积分"
                 ],
-                "summary": "后台用户更改用户签到记录(临时)",
+                "summary": "积分兑换",
                 "parameters": [
                     {
                         "description": "参数",
@@ -1378,23 +1447,90 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/server.ModifyCheckinRecordRequest"
+                            "$ref": "#/definitions/server.PointsRedeemRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.PointsRedeemResponse"
                         }
                     }
+                }
+            }
+        },
+        "/customers/qrcode": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get customer qrcode",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
                 ],
+                "summary": "获取客户所属二维码",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.ModifyCheckinRecordResponse"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/users/login": {
+        "/customers/referral_code": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get referral code, pass it as the state of wechat oauth url to invite new customers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "获取邀请码",
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.ReferralCodeResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/customers/reviews": {
             "post": {
-                "description": "user login",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "customer rate and review a write off, one review per write off",
                 "consumes": [
                     "application/json"
                 ],
@@ -1402,9 +1538,9 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "后台用户"
+                    "客户"
                 ],
-                "summary": "后台用户登录",
+                "summary": "客户评价商户",
                 "parameters": [
                     {
                         "description": "参数",
@@ -1413,7 +1549,7 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/server.UserLoginRequest"
+                            "$ref": "#/definitions/server.MerchantReviewAddRequest"
                         }
                     }
                 ],
@@ -1421,15 +1557,15 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.UserLoginResponse"
+                            "$ref": "#/definitions/server.MerchantReviewAddResponse"
                         }
                     }
                 }
             }
         },
-        "/verify_code": {
+        "/files/download": {
             "get": {
-                "description": "send sms code",
+                "description": "download file",
                 "consumes": [
                     "application/json"
                 ],
@@ -1437,45 +1573,62 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "验证码"
+                    "文件"
                 ],
-                "summary": "发送验证码",
+                "summary": "下载文件",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "手机号",
-                        "name": "mobile",
-                        "in": "query"
+                        "description": "filename",
+                        "name": "filename",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"avatar\", \"poster\", \"review\"",
+                        "description": "file type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.CodeResponse"
+                            "$ref": "#/definitions/server.BaseResponse"
                         }
                     }
                 }
             }
         },
-        "/wx/config": {
-            "get": {
-                "description": "get wx config",
+        "/files/upload": {
+            "post": {
+                "description": "upload file",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "微信"
+                    "文件"
                 ],
-                "summary": "获取微信接口配置",
+                "summary": "上传文件",
                 "parameters": [
+                    {
+                        "type": "file",
+                        "description": "upload file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "当前页URL，不包含#及其后面部分",
-                        "name": "url",
+                        "default": "\"avatar\", \"poster\", \"review\"",
+                        "description": "file type",
+                        "name": "type",
                         "in": "query",
                         "required": true
                     }
@@ -1484,20 +1637,20 @@ var doc = `{
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.WXConfigResponse"
+                            "$ref": "#/definitions/server.BaseResponse"
                         }
                     }
                 }
             }
         },
-        "/wx/pay": {
+        "/logout": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "customer pay",
+                "description": "logout, revoke current access token and refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -1505,22 +1658,39 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "微信"
+                    "登录"
+                ],
+                "summary": "退出登录",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.LogoutRequest"
+                        }
+                    }
                 ],
-                "summary": "用户支付",
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "$ref": "#/definitions/server.WXPayResponse"
+                            "$ref": "#/definitions/server.LogoutResponse"
                         }
                     }
                 }
             }
         },
-        "/wx/pay/notify": {
-            "post": {
-                "description": "wx pay callback",
+        "/merchants": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get merchant list",
                 "consumes": [
                     "application/json"
                 ],
@@ -1528,535 +1698,4547 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "微信"
+                    "商户"
                 ],
-                "summary": "用户支付",
+                "summary": "获取商户列表",
                 "parameters": [
                     {
-                        "description": "参数",
-                        "name": "args",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/server.WxpayCallbackRequest"
-                        }
+                        "type": "string",
+                        "description": "商户名",
+                        "name": "store_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "联系人",
+                        "name": "contact_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "联系电话",
+                        "name": "contact_phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "商户状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "{\"status\":true}",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/server.MerchantListResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "edit merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "编辑商户",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantEditResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create new merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "新增商户",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantAddResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "删除商户",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantDelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantDelResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/detail": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get merchant detail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "获取商户详情信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "商户ID,商户访问时可不传",
+                        "name": "merchant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "disable merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "禁用商户",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantDisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantDisableResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/gift_items": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get merchant gift items available to claim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "获取商户礼品列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商户ID，商户登录时默认为当前商户",
+                        "name": "merchant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.GiftItemListResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "edit merchant gift item, stock can not be less than received, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "编辑礼品",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.GiftItemEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.GiftItemEditResponse"
                         }
                     }
                 }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add merchant gift item, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "新增礼品",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.GiftItemAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.GiftItemAddResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/gift_items/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "disable merchant gift item, claimed welfare can still be written off, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "停用礼品",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.GiftItemDisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.GiftItemDisableResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/login": {
+            "post": {
+                "description": "merchant login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "商户登录",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/model.MerchantLoginVO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantLoginResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/poster": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get merchant poster",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "获取商户随机一张海报",
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get visible merchant reviews with merchant replies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "获取商户评价",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商户ID，商户登录时默认为当前商户",
+                        "name": "merchant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantReviewListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/reviews/reply": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "merchant reply a review, replying again overwrites the previous reply, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "商户回复评价",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantReviewReplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantReviewReplyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/staff": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get merchant staff list, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "获取商户员工列表",
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantStaffListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add merchant staff, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "新增商户员工",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantStaffAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantStaffAddResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete merchant staff, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "删除商户员工",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantStaffDelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantStaffDelResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/staff/enable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "enable or disable merchant staff, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "启用或停用商户员工",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.MerchantStaffEnableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.MerchantStaffEnableResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/writeoff": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "merchant get customer write off",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "商户获取核销信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "核销码",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "客户ID，仅在允许使用客户ID核销时有效",
+                        "name": "customer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "礼品ID，客户在该商户有多份福利时指定",
+                        "name": "gift_item_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.WriteOffResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "merchant exec write off",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "商户核销",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.ExecWriteOffRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.ExecWriteOffResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/writeoff/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "export merchant write off history as csv, merchant owner only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "导出商户核销记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "begin_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "csv",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/writeoff/logs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get merchant write off history, default today",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "获取商户核销记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "begin_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.WriteOffLogListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/writeoff/reverse": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "merchant reverse a write off within the reverse window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "撤销核销",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.WriteOffReverseRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.WriteOffReverseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/merchants/writeoff/summary": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get merchant daily write off summary, default today",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商户"
+                ],
+                "summary": "获取商户每日核销汇总",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "begin_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.WriteOffSummaryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/points/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "adjust customer points with a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "积分"
+                ],
+                "summary": "调整客户积分",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.PointsAdjustRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.PointsAdjustResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get review list for moderation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "评价"
+                ],
+                "summary": "后台查询评价",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商户ID",
+                        "name": "merchant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态：A(展示中)，U(待审核或已隐藏)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "页数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.ReviewListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/moderate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "approve or hide a review, merchant rating is updated accordingly",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "评价"
+                ],
+                "summary": "后台审核评价",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.ReviewModerateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.ReviewModerateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat/checkin": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "stat customer exec checkin num",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "统计"
+                ],
+                "summary": "统计用户执行签到数目",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "begin_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.CheckinStatResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat/invitation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "stat help checkin invitation opens, accepts and conversion rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "统计"
+                ],
+                "summary": "统计补签邀请转化率",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "begin_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期，与开始日期最多相隔92天",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.InvitationStatResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stat/register": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "stat customer register num",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "统计"
+                ],
+                "summary": "统计用户注册数",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "begin_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.RegisterStatResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "refresh access token, the old refresh token becomes invalid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录"
+                ],
+                "summary": "刷新token",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.TokenRefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.TokenRefreshResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "后台用户"
+                ],
+                "summary": "新增后台用户",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.UserAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.UserAddResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/checkin_record_list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get checkin record list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "后台用户"
+                ],
+                "summary": "获取签到记录列表(临时)",
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.CheckinRecordListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/checkin_record_list/modify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "users modify customer checkin record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "后台用户"
+                ],
+                "summary": "后台用户更改用户签到记录(临时)",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.ModifyCheckinRecordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.ModifyCheckinRecordResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "user login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "后台用户"
+                ],
+                "summary": "后台用户登录",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.UserLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.UserLoginResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "user change password, all sessions of the user will be revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "后台用户"
+                ],
+                "summary": "后台用户修改密码",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.UserPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.UserPasswordResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "后台用户"
+                ],
+                "summary": "修改后台用户角色",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.UserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.UserRoleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get role list with permissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "后台用户"
+                ],
+                "summary": "获取后台角色列表",
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.RoleListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify_code": {
+            "get": {
+                "description": "send sms code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "验证码"
+                ],
+                "summary": "发送验证码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "手机号",
+                        "name": "mobile",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.CodeResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/config": {
+            "get": {
+                "description": "get wx config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "微信"
+                ],
+                "summary": "获取微信接口配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "当前页URL，不包含#及其后面部分",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.WXConfigResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/pay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "customer pay",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "微信"
+                ],
+                "summary": "用户支付",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "幂等键，重复提交时返回第一次成功的结果",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "$ref": "#/definitions/server.WXPayResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wx/pay/notify": {
+            "post": {
+                "description": "wx pay callback",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "微信"
+                ],
+                "summary": "用户支付",
+                "parameters": [
+                    {
+                        "description": "参数",
+                        "name": "args",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/server.WxpayCallbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"status\":true}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "jwt.TokenParames": {
+            "type": "object",
+            "properties": {
+                "mobile": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "姓名",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "角色",
+                    "type": "string"
+                },
+                "staffID": {
+                    "description": "商户员工ID，仅商户token",
+                    "type": "integer"
+                },
+                "staffRole": {
+                    "description": "商户员工角色，仅商户token",
+                    "type": "string"
+                },
+                "uid": {
+                    "description": "uid",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "操作",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "description": "操作人ID",
+                    "type": "integer"
+                },
+                "actor_name": {
+                    "description": "操作人",
+                    "type": "string"
+                },
+                "actor_role": {
+                    "description": "操作人角色",
+                    "type": "string"
+                },
+                "after": {
+                    "description": "修改后的数据JSON",
+                    "type": "string"
+                },
+                "before": {
+                    "description": "修改前的数据JSON",
+                    "type": "string"
+                },
+                "client_ip": {
+                    "description": "客户端IP",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "diff": {
+                    "description": "变化的字段JSON，{\"字段\":{\"before\":旧值,\"after\":新值}}",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "description": "操作对象ID",
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "description": "操作对象类型",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "description": "请求ID",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BadgeVO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "徽章编码",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "获得条件说明",
+                    "type": "string"
+                },
+                "earned": {
+                    "description": "是否已获得",
+                    "type": "boolean"
+                },
+                "earned_at": {
+                    "description": "获得时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "徽章名称",
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "当前指标值，超过阈值时为阈值",
+                    "type": "integer"
+                },
+                "threshold": {
+                    "description": "获得徽章需要达到的指标值",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Campaign": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "cycle_days": {
+                    "description": "签到周期天数",
+                    "type": "integer"
+                },
+                "end_date": {
+                    "description": "结束日期，当天仍可参与",
+                    "type": "string"
+                },
+                "geofence_radius": {
+                    "description": "到店签到半径，单位公里，为0时不限制签到位置",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchants": {
+                    "description": "参与活动的商户ID，为空时所有商户均可参与",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "description": "活动名称",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "reward_num": {
+                    "description": "签满后可领取的礼品数量，为0时使用商户设置的数量",
+                    "type": "integer"
+                },
+                "start_date": {
+                    "description": "开始日期",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CampaignVO": {
+            "type": "object",
+            "required": [
+                "cycle_days",
+                "end_date",
+                "name",
+                "start_date"
+            ],
+            "properties": {
+                "cycle_days": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2019-10-31"
+                },
+                "geofence_radius": {
+                    "description": "到店签到半径，单位公里，为0时不限制签到位置",
+                    "type": "number",
+                    "example": 0.5
+                },
+                "merchants": {
+                    "description": "参与活动的商户ID，为空时所有商户均可参与",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "活动名称"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "reward_num": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2019-10-01"
+                }
+            }
+        },
+        "model.CheckinHistory": {
+            "type": "object",
+            "properties": {
+                "checkin_dates": {
+                    "description": "该月已签到的日期",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "claimed_gifts": {
+                    "description": "累计领取的礼品数量",
+                    "type": "integer"
+                },
+                "completed_cycles": {
+                    "description": "已完成并领取福利的签到周期数，即领取福利记录总数",
+                    "type": "integer"
+                },
+                "current_streak": {
+                    "description": "当前连续签到天数，今天未签到时截止到昨天",
+                    "type": "integer"
+                },
+                "gifts": {
+                    "description": "领取福利记录当前页，按领取时间倒序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ClaimedGift"
+                    }
+                },
+                "longest_streak": {
+                    "description": "最近一年内最长连续签到天数",
+                    "type": "integer"
+                },
+                "month": {
+                    "description": "日历月份，格式为2006-01",
+                    "type": "string"
+                },
+                "total_checkins": {
+                    "description": "累计签到天数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CheckinRecord": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "description": "签到活动ID，为0时使用默认签到周期",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "签到人ID",
+                    "type": "integer"
+                },
+                "day": {
+                    "description": "签到第几天",
+                    "type": "integer"
+                },
+                "help_checkin_customer_id": {
+                    "description": "帮签人ID",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "description": "到店签到时所在的店铺ID",
+                    "type": "integer"
+                },
+                "need_checkin_time": {
+                    "description": "需要签到的日期",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplement_type": {
+                    "description": "补签方式：help(好友帮签)，pay(付费补签)，为空时非补签",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CheckinRecordListResp": {
+            "type": "object",
+            "properties": {
+                "checkin_record": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.CheckinRecord"
+                },
+                "customer": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Customer"
+                }
+            }
+        },
+        "model.CheckinStat": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "日期",
+                    "type": "string"
+                },
+                "num": {
+                    "description": "签到次数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ClaimedGift": {
+            "type": "object",
+            "properties": {
+                "claimed_at": {
+                    "description": "领取时间",
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "description": "店铺ID",
+                    "type": "integer"
+                },
+                "num": {
+                    "description": "领取的礼品数量",
+                    "type": "integer"
+                },
+                "store_name": {
+                    "description": "店名",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CompositeIndex": {
+            "type": "object",
+            "properties": {
+                "composite_date": {
+                    "description": "上证指数日期",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "指数",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Customer": {
+            "type": "object",
+            "properties": {
+                "bonus_gift_num": {
+                    "description": "邀请奖励的额外福利数量，下次领取福利时发放",
+                    "type": "integer"
+                },
+                "city": {
+                    "description": "微信用户所在区",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "微信用户所在国家",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "headimgurl": {
+                    "description": "微信用户头像",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_checkin_time": {
+                    "description": "最后一次签到时间",
+                    "type": "string"
+                },
+                "mobile": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "称呼",
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "微信用户昵称",
+                    "type": "string"
+                },
+                "open_id": {
+                    "description": "微信用户openid",
+                    "type": "string"
+                },
+                "points": {
+                    "description": "积分余额，与积分流水同步更新",
+                    "type": "integer"
+                },
+                "province": {
+                    "description": "微信用户所在市",
+                    "type": "string"
+                },
+                "referrer_id": {
+                    "description": "邀请人ID，不是被邀请注册时为0",
+                    "type": "integer"
+                },
+                "sex": {
+                    "description": "微信用户性别",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplement_credits": {
+                    "description": "邀请奖励的免费补签次数",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GiftItem": {
+            "type": "object",
+            "properties": {
+                "checkin_days": {
+                    "description": "签满多少天可领取",
+                    "type": "integer"
+                },
+                "claim_num": {
+                    "description": "每次领取的数量",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "礼品说明",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "description": "礼品图片",
+                    "type": "string"
+                },
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "礼品名称",
+                    "type": "string"
+                },
+                "received": {
+                    "description": "已领取数量",
+                    "type": "integer"
+                },
+                "sort": {
+                    "description": "排序，越小越靠前",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_stock": {
+                    "description": "礼品库存总数",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GiftItemVO": {
+            "type": "object",
+            "required": [
+                "checkin_days",
+                "claim_num",
+                "name",
+                "total_stock"
+            ],
+            "properties": {
+                "checkin_days": {
+                    "description": "签满多少天可领取",
+                    "type": "integer"
+                },
+                "claim_num": {
+                    "description": "每次领取的数量",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "礼品说明",
+                    "type": "string"
+                },
+                "image": {
+                    "description": "礼品图片",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "礼品名称",
+                    "type": "string"
+                },
+                "sort": {
+                    "description": "排序，越小越靠前",
+                    "type": "integer"
+                },
+                "total_stock": {
+                    "description": "礼品库存总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HelpInvitationDetail": {
+            "type": "object",
+            "properties": {
+                "customer": {
+                    "description": "邀请人",
+                    "type": "object",
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "expires_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.HelpInvitationResp": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "邀请token，好友帮签时传入",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Helper": {
+            "type": "object",
+            "properties": {
+                "checkin_record_id": {
+                    "description": "补签的签到记录ID",
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "帮签人ID",
+                    "type": "integer"
+                },
+                "headimgurl": {
+                    "description": "微信头像",
+                    "type": "string"
+                },
+                "helped_at": {
+                    "description": "帮签时间",
+                    "type": "string"
+                },
+                "invitation_id": {
+                    "description": "通过的补签邀请ID，为0时非邀请帮签",
+                    "type": "integer"
+                },
+                "nickname": {
+                    "description": "微信昵称",
+                    "type": "string"
+                }
+            }
+        },
+        "model.InboxMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "内容",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_read": {
+                    "description": "是否已读",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "template": {
+                    "description": "消息模板名",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "标题",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.InvitationStat": {
+            "type": "object",
+            "properties": {
+                "accepts": {
+                    "description": "接受邀请帮签的人数",
+                    "type": "integer"
+                },
+                "conversion_rate": {
+                    "description": "转化率，接受人数/打开人数",
+                    "type": "number"
+                },
+                "date": {
+                    "description": "日期",
+                    "type": "string"
+                },
+                "invitations": {
+                    "description": "生成的邀请数",
+                    "type": "integer"
+                },
+                "opens": {
+                    "description": "打开邀请的人数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.IssueRecord": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "customer_id": {
+                    "description": "顾客ID",
+                    "type": "integer"
+                },
+                "gift_item": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.GiftItem"
+                },
+                "gift_item_id": {
+                    "description": "礼品ID，为0时为商户未区分礼品时发放的福利",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Merchant"
+                },
+                "merchant_id": {
+                    "description": "店铺ID",
+                    "type": "integer"
+                },
+                "received": {
+                    "description": "在对应店铺已兑换的礼品数",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_receive": {
+                    "description": "在对应店铺可领取的总礼品数",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LuckyNumberRecord": {
+            "type": "object",
+            "properties": {
+                "composite_index": {
+                    "description": "本轮内上证指数",
+                    "type": "object",
+                    "$ref": "#/definitions/model.CompositeIndex"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "签到人ID",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lucky_number": {
+                    "description": "用户填写的幸运数字",
+                    "type": "integer"
+                },
+                "ranking": {
+                    "description": "本轮幸运数字排名",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Merchant": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "地址",
+                    "type": "string"
+                },
+                "catering_type": {
+                    "description": "餐饮类型",
+                    "type": "string"
+                },
+                "checkin_days": {
+                    "description": "签到天数多少天可领取礼品",
+                    "type": "integer"
+                },
+                "checkin_num": {
+                    "description": "达到指定签到天数后，可领取的礼品数量",
+                    "type": "integer"
+                },
+                "contact_name": {
+                    "description": "联系人",
+                    "type": "string"
+                },
+                "contact_phone": {
+                    "description": "联系人电话",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "has_failure": {
+                    "description": "已失效",
+                    "type": "integer"
+                },
+                "has_write_off_num": {
+                    "description": "已核销总数",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lat": {
+                    "description": "纬度",
+                    "type": "number"
+                },
+                "lon": {
+                    "description": "经度",
+                    "type": "number"
+                },
+                "poster": {
+                    "description": "商户海报",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "平均评分，保留一位小数",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "description": "展示中的评价数",
+                    "type": "integer"
+                },
+                "rating_total": {
+                    "description": "展示中的评价评分总和",
+                    "type": "integer"
+                },
+                "received": {
+                    "description": "已领礼品数量，不含按礼品目录领取的数量",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "store_avatar": {
+                    "description": "店铺头像",
+                    "type": "string"
+                },
+                "store_name": {
+                    "description": "店名",
+                    "type": "string"
+                },
+                "total_receive": {
+                    "description": "该店礼品一共可领取总数",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MerchantLoginVO": {
+            "type": "object",
+            "required": [
+                "code",
+                "contact_phone"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "验证码"
+                },
+                "contact_phone": {
+                    "type": "string",
+                    "example": "手机号"
+                }
+            }
+        },
+        "model.MerchantReview": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "评价内容",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                },
+                "gift_item_id": {
+                    "description": "礼品ID",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                },
+                "photos": {
+                    "description": "评价图片文件名",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "description": "评分，1-5",
+                    "type": "integer"
+                },
+                "replied_at": {
+                    "description": "商户回复时间，未回复时为空",
+                    "type": "string"
+                },
+                "replied_by": {
+                    "description": "回复的员工ID",
+                    "type": "integer"
+                },
+                "reply": {
+                    "description": "商户回复",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "write_off_log_id": {
+                    "description": "核销记录ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MerchantReviewItem": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "评价内容",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                },
+                "gift_item_id": {
+                    "description": "礼品ID",
+                    "type": "integer"
+                },
+                "gift_item_name": {
+                    "description": "礼品名称",
+                    "type": "string"
+                },
+                "headimgurl": {
+                    "description": "客户微信头像",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                },
+                "nickname": {
+                    "description": "客户微信昵称",
+                    "type": "string"
+                },
+                "photos": {
+                    "description": "评价图片文件名",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "description": "评分，1-5",
+                    "type": "integer"
+                },
+                "replied_at": {
+                    "description": "商户回复时间，未回复时为空",
+                    "type": "string"
+                },
+                "replied_by": {
+                    "description": "回复的员工ID",
+                    "type": "integer"
+                },
+                "reply": {
+                    "description": "商户回复",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "write_off_log_id": {
+                    "description": "核销记录ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MerchantStaff": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                },
+                "mobile": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "姓名",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "角色：owner(店主)，clerk(店员)",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MerchantVO": {
+            "type": "object",
+            "required": [
+                "address",
+                "checkin_days",
+                "checkin_num",
+                "contact_phone",
+                "lat",
+                "lon",
+                "store_avatar",
+                "store_name",
+                "total_receive"
+            ],
+            "properties": {
+                "address": {
+                    "description": "地址",
+                    "type": "string"
+                },
+                "catering_type": {
+                    "description": "餐饮类型",
+                    "type": "string"
+                },
+                "checkin_days": {
+                    "description": "签到天数多少天可领取礼品",
+                    "type": "integer"
+                },
+                "checkin_num": {
+                    "description": "达到指定签到天数后，可领取的礼品数量",
+                    "type": "integer"
+                },
+                "contact_name": {
+                    "description": "联系人",
+                    "type": "string"
+                },
+                "contact_phone": {
+                    "description": "联系人电话",
+                    "type": "string"
+                },
+                "lat": {
+                    "description": "纬度",
+                    "type": "number"
+                },
+                "lon": {
+                    "description": "经度",
+                    "type": "number"
+                },
+                "poster": {
+                    "description": "商户海报",
+                    "type": "string"
+                },
+                "store_avatar": {
+                    "description": "店铺头像",
+                    "type": "string"
+                },
+                "store_name": {
+                    "description": "店名",
+                    "type": "string"
+                },
+                "total_receive": {
+                    "description": "该店礼品一共可领取总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MerchantWriteOffRespVO": {
+            "type": "object",
+            "properties": {
+                "customer": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "issue_record": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.IssueRecord"
+                },
+                "issue_records": {
+                    "description": "客户在该商户所有未失效的福利，按礼品区分",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.IssueRecord"
+                    }
+                },
+                "merchant": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Merchant"
+                },
+                "num": {
+                    "type": "integer"
+                },
+                "write_off_id": {
+                    "description": "核销记录ID，撤销核销时使用",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PointsBalance": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "积分余额",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PointsEntry": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "description": "记账账户：customer:\u003c客户ID\u003e或system:\u003c原因\u003e",
+                    "type": "string"
+                },
+                "amount": {
+                    "description": "积分变动，正数为入账，负数为出账",
+                    "type": "integer"
+                },
+                "balance": {
+                    "description": "记账后客户的积分余额，系统账户流水为0",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "原因：checkin(签到)，help_checkin(帮签)，cycle(完成一轮签到)，redeem(兑换)，adjust(后台调整)",
+                    "type": "string"
+                },
+                "ref_id": {
+                    "description": "关联的业务记录ID",
+                    "type": "integer"
+                },
+                "remark": {
+                    "description": "备注，后台调整时为调整原因",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tx_id": {
+                    "description": "交易号，同一笔交易的两条流水相同",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReferralCodeResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "邀请码",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.RegisterStat": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "日期",
+                    "type": "string"
+                },
+                "num": {
+                    "description": "注册用户数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Role": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "角色名",
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "角色拥有的权限",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TokenResp": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "访问接口使用的token",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "access_token有效期，单位秒",
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "description": "用于刷新access_token",
+                    "type": "string"
+                }
+            }
+        },
+        "model.WXConfigResp": {
+            "type": "object",
+            "properties": {
+                "appid": {
+                    "description": "公众号的唯一标识",
+                    "type": "string"
+                },
+                "noncestr": {
+                    "description": "生成签名的随机串",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "签名",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "生成签名的时间戳",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WriteOffLog": {
+            "type": "object",
+            "properties": {
+                "client_ip": {
+                    "description": "核销时的客户端IP",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                },
+                "gift_item_id": {
+                    "description": "礼品ID",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issue_record_id": {
+                    "description": "福利记录ID",
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                },
+                "num": {
+                    "description": "核销数目",
+                    "type": "integer"
+                },
+                "reverse_reason": {
+                    "description": "撤销原因",
+                    "type": "string"
+                },
+                "reversed_at": {
+                    "description": "撤销时间，未撤销时为空",
+                    "type": "string"
+                },
+                "reversed_by": {
+                    "description": "执行撤销的员工ID",
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "description": "执行核销的员工ID",
+                    "type": "integer"
+                },
+                "staff_name": {
+                    "description": "执行核销的员工姓名",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WriteOffLogItem": {
+            "type": "object",
+            "properties": {
+                "client_ip": {
+                    "description": "核销时的客户端IP",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                },
+                "gift_item_id": {
+                    "description": "礼品ID",
+                    "type": "integer"
+                },
+                "gift_item_name": {
+                    "description": "礼品名称",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issue_record_id": {
+                    "description": "福利记录ID",
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                },
+                "nickname": {
+                    "description": "客户微信昵称",
+                    "type": "string"
+                },
+                "num": {
+                    "description": "核销数目",
+                    "type": "integer"
+                },
+                "reverse_reason": {
+                    "description": "撤销原因",
+                    "type": "string"
+                },
+                "reversed_at": {
+                    "description": "撤销时间，未撤销时为空",
+                    "type": "string"
+                },
+                "reversed_by": {
+                    "description": "执行撤销的员工ID",
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "description": "执行核销的员工ID",
+                    "type": "integer"
+                },
+                "staff_name": {
+                    "description": "执行核销的员工姓名",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WriteOffSummary": {
+            "type": "object",
+            "properties": {
+                "customers": {
+                    "description": "核销的客户数",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "日期",
+                    "type": "string"
+                },
+                "num": {
+                    "description": "核销数量",
+                    "type": "integer"
+                },
+                "times": {
+                    "description": "核销次数",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.AuditLogListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuditLog"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                },
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.BaseResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CampaignAddRequest": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "description": "签到活动",
+                    "type": "object",
+                    "$ref": "#/definitions/model.CampaignVO"
+                }
+            }
+        },
+        "server.CampaignAddResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CampaignDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Campaign"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CampaignDisableRequest": {
+            "type": "object",
+            "required": [
+                "campaign_id"
+            ],
+            "properties": {
+                "campaign_id": {
+                    "description": "签到活动ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.CampaignDisableResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CampaignEditRequest": {
+            "type": "object",
+            "required": [
+                "campaign_id"
+            ],
+            "properties": {
+                "campaign": {
+                    "description": "签到活动",
+                    "type": "object",
+                    "$ref": "#/definitions/model.CampaignVO"
+                },
+                "campaign_id": {
+                    "description": "签到活动ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.CampaignEditResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CampaignListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Campaign"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                },
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.CanPartLuckyNumberActivityResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CheckinHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.CheckinHistory"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CheckinRecordListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CheckinRecordListResp"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CheckinRecordResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CheckinRecord"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CheckinStatResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CheckinStat"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CodeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CompositeIndexAddRequest": {
+            "type": "object",
+            "required": [
+                "composite_date",
+                "points"
+            ],
+            "properties": {
+                "composite_date": {
+                    "description": "上证指数日期",
+                    "type": "string"
+                },
+                "points": {
+                    "description": "指数",
+                    "type": "number"
+                }
+            }
+        },
+        "server.CompositeIndexAddResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CompositeIndexDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.CompositeIndex"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CreditCheckinResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CustomerBadgesResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BadgeVO"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CustomerDelRequest": {
+            "type": "object",
+            "properties": {
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.CustomerDelResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CustomerDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CustomerDisableRequest": {
+            "type": "object",
+            "properties": {
+                "customer_id": {
+                    "description": "客户ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.CustomerDisableResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.CustomerListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Customer"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                },
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
+                }
             }
-        }
-    },
-    "definitions": {
-        "jwt.TokenParames": {
+        },
+        "server.CustomerLoginResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.TokenResp"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.ExecCheckinRecordRequest": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "description": "纬度，到店签到时必传",
+                    "type": "number",
+                    "example": 39.916527
+                },
+                "lon": {
+                    "description": "经度，到店签到时必传",
+                    "type": "number",
+                    "example": 116.397128
+                }
+            }
+        },
+        "server.ExecCheckinRecordResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.ExecIssueRecordRequest": {
             "type": "object",
+            "required": [
+                "merchant_id"
+            ],
             "properties": {
+                "code": {
+                    "description": "验证码",
+                    "type": "string"
+                },
+                "gift_item_id": {
+                    "description": "礼品ID，商户提供礼品时必填",
+                    "type": "integer"
+                },
+                "merchant_id": {
+                    "description": "店铺ID",
+                    "type": "integer"
+                },
                 "mobile": {
                     "description": "手机号",
                     "type": "string"
+                }
+            }
+        },
+        "server.ExecIssueRecordResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "姓名",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "uid": {
-                    "description": "uid",
-                    "type": "integer"
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.CheckinRecord": {
+        "server.ExecWriteOffRequest": {
             "type": "object",
+            "required": [
+                "num"
+            ],
             "properties": {
-                "created_at": {
+                "code": {
+                    "description": "客户二维码中的核销码",
                     "type": "string"
                 },
-                "created_by": {
+                "customer_id": {
+                    "description": "客户ID，仅在允许使用客户ID核销时有效",
                     "type": "integer"
                 },
-                "customer_id": {
-                    "description": "签到人ID",
+                "gift_item_id": {
+                    "description": "礼品ID，客户在该商户有多份福利时必填",
                     "type": "integer"
                 },
-                "day": {
-                    "description": "签到第几天",
+                "num": {
+                    "description": "核销数目",
                     "type": "integer"
+                }
+            }
+        },
+        "server.ExecWriteOffResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
                 },
-                "help_checkin_customer_id": {
-                    "description": "帮签人ID",
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.MerchantWriteOffRespVO"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.GiftItemAddRequest": {
+            "type": "object",
+            "properties": {
+                "gift_item": {
+                    "description": "礼品",
+                    "type": "object",
+                    "$ref": "#/definitions/model.GiftItemVO"
+                }
+            }
+        },
+        "server.GiftItemAddResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.GiftItem"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.GiftItemDisableRequest": {
+            "type": "object",
+            "required": [
+                "gift_item_id"
+            ],
+            "properties": {
+                "gift_item_id": {
+                    "description": "礼品ID",
                     "type": "integer"
+                }
+            }
+        },
+        "server.GiftItemDisableResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
                 },
-                "id": {
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.GiftItemEditRequest": {
+            "type": "object",
+            "required": [
+                "gift_item_id"
+            ],
+            "properties": {
+                "gift_item": {
+                    "description": "礼品",
+                    "type": "object",
+                    "$ref": "#/definitions/model.GiftItemVO"
+                },
+                "gift_item_id": {
+                    "description": "礼品ID",
                     "type": "integer"
+                }
+            }
+        },
+        "server.GiftItemEditResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
                 },
-                "need_checkin_time": {
-                    "description": "需要签到的日期",
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.GiftItemListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "status": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GiftItem"
+                    }
+                },
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "updated_at": {
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "updated_by": {
-                    "type": "integer"
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.CheckinRecordListResp": {
+        "server.HelpCheckinRequest": {
             "type": "object",
             "properties": {
-                "checkin_record": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.CheckinRecord"
+                "customer_id": {
+                    "description": "补签客户ID，传入token时以邀请人为准",
+                    "type": "integer"
                 },
-                "customer": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Customer"
+                "token": {
+                    "description": "补签邀请token",
+                    "type": "string"
                 }
             }
         },
-        "model.CheckinStat": {
+        "server.HelpCheckinResponse": {
             "type": "object",
             "properties": {
-                "date": {
-                    "description": "日期",
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "num": {
-                    "description": "签到次数",
-                    "type": "integer"
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.CompositeIndex": {
+        "server.HelpInvitationAddResponse": {
             "type": "object",
             "properties": {
-                "composite_date": {
-                    "description": "上证指数日期",
-                    "type": "string"
-                },
-                "created_at": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "points": {
-                    "description": "指数",
-                    "type": "number"
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.HelpInvitationResp"
                 },
-                "status": {
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "updated_at": {
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "updated_by": {
-                    "type": "integer"
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.Customer": {
+        "server.HelpInvitationOpenResponse": {
             "type": "object",
             "properties": {
-                "city": {
-                    "description": "微信用户所在区",
-                    "type": "string"
-                },
-                "country": {
-                    "description": "微信用户所在国家",
-                    "type": "string"
-                },
-                "created_at": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "integer"
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.HelpInvitationDetail"
                 },
-                "headimgurl": {
-                    "description": "微信用户头像",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "last_checkin_time": {
-                    "description": "最后一次签到时间",
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "mobile": {
-                    "description": "手机号",
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.HelperListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "name": {
-                    "description": "称呼",
-                    "type": "string"
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
                 },
-                "nickname": {
-                    "description": "微信用户昵称",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Helper"
+                    }
                 },
-                "open_id": {
-                    "description": "微信用户openid",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "province": {
-                    "description": "微信用户所在市",
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "sex": {
-                    "description": "微信用户性别",
-                    "type": "integer"
-                },
                 "status": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+                    "description": "状态",
+                    "type": "boolean"
                 },
-                "updated_by": {
+                "total": {
+                    "description": "总数量",
                     "type": "integer"
                 }
             }
         },
-        "model.IssueRecord": {
+        "server.InboxMessageListResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "created_by": {
+                "current": {
+                    "description": "当前页页码",
                     "type": "integer"
                 },
-                "customer": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Customer"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InboxMessage"
+                    }
                 },
-                "customer_id": {
-                    "description": "顾客ID",
-                    "type": "integer"
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
                 },
-                "merchant": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Merchant"
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 },
-                "merchant_id": {
-                    "description": "店铺ID",
+                "total": {
+                    "description": "总数量",
                     "type": "integer"
                 },
-                "received": {
-                    "description": "在对应店铺已兑换的礼品数",
+                "unread": {
+                    "description": "未读消息数",
                     "type": "integer"
-                },
-                "status": {
+                }
+            }
+        },
+        "server.InboxMessageReadResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "total_receive": {
-                    "description": "在对应店铺可领取的总礼品数",
-                    "type": "integer"
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
                 },
-                "updated_at": {
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "updated_by": {
-                    "type": "integer"
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.LuckyNumberRecord": {
+        "server.InvitationStatResponse": {
             "type": "object",
             "properties": {
-                "composite_index": {
-                    "description": "本轮内上证指数",
-                    "type": "object",
-                    "$ref": "#/definitions/model.CompositeIndex"
-                },
-                "created_at": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "integer"
-                },
-                "customer_id": {
-                    "description": "签到人ID",
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "lucky_number": {
-                    "description": "用户填写的幸运数字",
-                    "type": "integer"
-                },
-                "ranking": {
-                    "description": "本轮幸运数字排名",
-                    "type": "integer"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InvitationStat"
+                    }
                 },
-                "status": {
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "updated_at": {
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "updated_by": {
-                    "type": "integer"
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.Merchant": {
+        "server.IsSupplementCheckinResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "地址",
-                    "type": "string"
-                },
-                "catering_type": {
-                    "description": "餐饮类型",
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "checkin_days": {
-                    "description": "签到天数多少天可领取礼品",
-                    "type": "integer"
-                },
-                "checkin_num": {
-                    "description": "达到指定签到天数后，可领取的礼品数量",
-                    "type": "integer"
+                "data": {
+                    "type": "boolean"
                 },
-                "contact_name": {
-                    "description": "联系人",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "contact_phone": {
-                    "description": "联系人电话",
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "created_at": {
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.IssueRecordResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "created_by": {
-                    "type": "integer"
-                },
-                "has_failure": {
-                    "description": "已失效",
-                    "type": "integer"
-                },
-                "has_write_off_num": {
-                    "description": "已核销总数",
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "lat": {
-                    "description": "纬度",
-                    "type": "number"
-                },
-                "lon": {
-                    "description": "经度",
-                    "type": "number"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.IssueRecord"
+                    }
                 },
-                "poster": {
-                    "description": "商户海报",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "received": {
-                    "description": "已领礼品数量",
-                    "type": "integer"
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
                 },
                 "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "同时注销的refresh_token",
                     "type": "string"
-                },
-                "store_avatar": {
-                    "description": "店铺头像",
+                }
+            }
+        },
+        "server.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "store_name": {
-                    "description": "店名",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "total_receive": {
-                    "description": "该店礼品一共可领取总数",
-                    "type": "integer"
-                },
-                "updated_at": {
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "updated_by": {
-                    "type": "integer"
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.MerchantLoginVO": {
+        "server.LuckyNumberAddRequest": {
             "type": "object",
             "required": [
-                "code",
-                "contact_phone"
+                "num"
             ],
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "验证码"
-                },
-                "contact_phone": {
-                    "type": "string",
-                    "example": "手机号"
+                "num": {
+                    "description": "猜的数字",
+                    "type": "integer"
                 }
             }
         },
-        "model.MerchantVO": {
+        "server.LuckyNumberAddResponse": {
             "type": "object",
-            "required": [
-                "address",
-                "checkin_days",
-                "checkin_num",
-                "contact_phone",
-                "lat",
-                "lon",
-                "store_avatar",
-                "store_name",
-                "total_receive"
-            ],
             "properties": {
-                "address": {
-                    "description": "地址",
-                    "type": "string"
-                },
-                "catering_type": {
-                    "description": "餐饮类型",
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "checkin_days": {
-                    "description": "签到天数多少天可领取礼品",
-                    "type": "integer"
-                },
-                "checkin_num": {
-                    "description": "达到指定签到天数后，可领取的礼品数量",
-                    "type": "integer"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "contact_name": {
-                    "description": "联系人",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "contact_phone": {
-                    "description": "联系人电话",
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "lat": {
-                    "description": "纬度",
-                    "type": "number"
-                },
-                "lon": {
-                    "description": "经度",
-                    "type": "number"
-                },
-                "poster": {
-                    "description": "商户海报",
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.LuckyNumberBeforeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "store_avatar": {
-                    "description": "店铺头像",
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.LuckyNumberRecord"
+                },
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "store_name": {
-                    "description": "店名",
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "total_receive": {
-                    "description": "该店礼品一共可领取总数",
-                    "type": "integer"
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.MerchantWriteOffRespVO": {
+        "server.LuckyNumberDetailResponse": {
             "type": "object",
             "properties": {
-                "customer": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Customer"
+                "code": {
+                    "description": "业务状态码",
+                    "type": "string"
                 },
-                "issue_record": {
+                "data": {
                     "type": "object",
-                    "$ref": "#/definitions/model.IssueRecord"
+                    "$ref": "#/definitions/model.LuckyNumberRecord"
                 },
-                "merchant": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Merchant"
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
                 },
-                "num": {
-                    "type": "integer"
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "model.RegisterStat": {
+        "server.LuckyPeopleBeforeResponse": {
             "type": "object",
             "properties": {
-                "date": {
-                    "description": "日期",
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "num": {
-                    "description": "注册用户数",
-                    "type": "integer"
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "error": {
+                    "description": "Error信息",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.MerchantAddRequest": {
+            "type": "object",
+            "properties": {
+                "merchant": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.MerchantVO"
                 }
             }
         },
-        "model.WXConfigResp": {
+        "server.MerchantAddResponse": {
             "type": "object",
             "properties": {
-                "appid": {
-                    "description": "公众号的唯一标识",
+                "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "noncestr": {
-                    "description": "生成签名的随机串",
+                "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
-                "signature": {
-                    "description": "签名",
+                "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
-                "timestamp": {
-                    "description": "生成签名的时间戳",
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
+                }
+            }
+        },
+        "server.MerchantDelRequest": {
+            "type": "object",
+            "properties": {
+                "merchant_id": {
+                    "description": "商户ID",
                     "type": "integer"
                 }
             }
         },
-        "server.BaseResponse": {
+        "server.MerchantDelResponse": {
             "type": "object",
             "properties": {
                 "code": {
+                    "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "type": "object"
-                },
                 "error": {
+                    "description": "Error信息",
                     "type": "string"
                 },
                 "message": {
+                    "description": "提示消息",
                     "type": "string"
                 },
                 "status": {
+                    "description": "状态",
                     "type": "boolean"
                 }
             }
         },
-        "server.CanPartLuckyNumberActivityResponse": {
+        "server.MerchantDetailResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2064,7 +6246,8 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "boolean"
+                    "type": "object",
+                    "$ref": "#/definitions/model.Merchant"
                 },
                 "error": {
                     "description": "Error信息",
@@ -2080,19 +6263,22 @@ var doc = `{
                 }
             }
         },
-        "server.CheckinRecordListResponse": {
+        "server.MerchantDisableRequest": {
+            "type": "object",
+            "properties": {
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.MerchantDisableResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.CheckinRecordListResp"
-                    }
-                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2107,19 +6293,27 @@ var doc = `{
                 }
             }
         },
-        "server.CheckinRecordResponse": {
+        "server.MerchantEditRequest": {
+            "type": "object",
+            "properties": {
+                "merchant": {
+                    "description": "商户信息",
+                    "type": "object",
+                    "$ref": "#/definitions/model.MerchantVO"
+                },
+                "merchant_id": {
+                    "description": "商户ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "server.MerchantEditResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.CheckinRecord"
-                    }
-                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2134,17 +6328,21 @@ var doc = `{
                 }
             }
         },
-        "server.CheckinStatResponse": {
+        "server.MerchantListResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.CheckinStat"
+                        "$ref": "#/definitions/model.Merchant"
                     }
                 },
                 "error": {
@@ -2158,16 +6356,24 @@ var doc = `{
                 "status": {
                     "description": "状态",
                     "type": "boolean"
+                },
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
                 }
             }
         },
-        "server.CodeResponse": {
+        "server.MerchantLoginResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.TokenResp"
+                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2182,30 +6388,48 @@ var doc = `{
                 }
             }
         },
-        "server.CompositeIndexAddRequest": {
+        "server.MerchantReviewAddRequest": {
             "type": "object",
             "required": [
-                "composite_date",
-                "points"
+                "rating"
             ],
             "properties": {
-                "composite_date": {
-                    "description": "上证指数日期",
+                "content": {
+                    "description": "评价内容",
                     "type": "string"
                 },
-                "points": {
-                    "description": "指数",
-                    "type": "number"
+                "merchant_id": {
+                    "description": "商户ID，未指定核销记录时评价在该商户最近一次未评价的核销",
+                    "type": "integer"
+                },
+                "photos": {
+                    "description": "评价图片，通过上传文件接口以 review 类型上传后的文件名",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rating": {
+                    "description": "评分，1-5",
+                    "type": "integer"
+                },
+                "write_off_log_id": {
+                    "description": "核销记录ID",
+                    "type": "integer"
                 }
             }
         },
-        "server.CompositeIndexAddResponse": {
+        "server.MerchantReviewAddResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.MerchantReview"
+                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2220,16 +6444,22 @@ var doc = `{
                 }
             }
         },
-        "server.CompositeIndexDetailResponse": {
+        "server.MerchantReviewListResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
                 "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.CompositeIndex"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MerchantReviewItem"
+                    }
                 },
                 "error": {
                     "description": "Error信息",
@@ -2242,19 +6472,31 @@ var doc = `{
                 "status": {
                     "description": "状态",
                     "type": "boolean"
+                },
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
                 }
             }
         },
-        "server.CustomerDelRequest": {
+        "server.MerchantReviewReplyRequest": {
             "type": "object",
+            "required": [
+                "id",
+                "reply"
+            ],
             "properties": {
-                "customer_id": {
-                    "description": "客户ID",
+                "id": {
+                    "description": "评价ID",
                     "type": "integer"
+                },
+                "reply": {
+                    "description": "回复内容",
+                    "type": "string"
                 }
             }
         },
-        "server.CustomerDelResponse": {
+        "server.MerchantReviewReplyResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2275,17 +6517,35 @@ var doc = `{
                 }
             }
         },
-        "server.CustomerDetailResponse": {
+        "server.MerchantStaffAddRequest": {
+            "type": "object",
+            "required": [
+                "mobile",
+                "name",
+                "role"
+            ],
+            "properties": {
+                "mobile": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "姓名",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "角色：owner(店主)，clerk(店员)",
+                    "type": "string"
+                }
+            }
+        },
+        "server.MerchantStaffAddResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Customer"
-                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2300,16 +6560,19 @@ var doc = `{
                 }
             }
         },
-        "server.CustomerDisableRequest": {
+        "server.MerchantStaffDelRequest": {
             "type": "object",
+            "required": [
+                "staff_id"
+            ],
             "properties": {
-                "customer_id": {
-                    "description": "客户ID",
+                "staff_id": {
+                    "description": "员工ID",
                     "type": "integer"
                 }
             }
         },
-        "server.CustomerDisableResponse": {
+        "server.MerchantStaffDelResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2330,51 +6593,29 @@ var doc = `{
                 }
             }
         },
-        "server.CustomerListResponse": {
+        "server.MerchantStaffEnableRequest": {
             "type": "object",
+            "required": [
+                "staff_id"
+            ],
             "properties": {
-                "code": {
-                    "description": "业务状态码",
-                    "type": "string"
-                },
-                "current": {
-                    "description": "当前页页码",
-                    "type": "integer"
-                },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Customer"
-                    }
-                },
-                "error": {
-                    "description": "Error信息",
-                    "type": "string"
-                },
-                "message": {
-                    "description": "提示消息",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "状态",
+                "enabled": {
+                    "description": "是否启用",
                     "type": "boolean"
                 },
-                "total": {
-                    "description": "总数量",
+                "staff_id": {
+                    "description": "员工ID",
                     "type": "integer"
                 }
             }
         },
-        "server.CustomerLoginResponse": {
+        "server.MerchantStaffEnableResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "type": "string"
-                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2389,13 +6630,19 @@ var doc = `{
                 }
             }
         },
-        "server.ExecCheckinRecordResponse": {
+        "server.MerchantStaffListResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MerchantStaff"
+                    }
+                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2410,27 +6657,20 @@ var doc = `{
                 }
             }
         },
-        "server.ExecIssueRecordRequest": {
+        "server.ModifyCheckinRecordRequest": {
             "type": "object",
-            "required": [
-                "merchant_id"
-            ],
             "properties": {
-                "code": {
-                    "description": "验证码",
-                    "type": "string"
-                },
-                "merchant_id": {
-                    "description": "店铺ID",
+                "checkin_record_id": {
+                    "description": "签到记录ID",
                     "type": "integer"
                 },
-                "mobile": {
-                    "description": "手机号",
+                "status": {
+                    "description": "用户状态：U，A",
                     "type": "string"
                 }
             }
         },
-        "server.ExecIssueRecordResponse": {
+        "server.ModifyCheckinRecordResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2443,32 +6683,15 @@ var doc = `{
                 },
                 "message": {
                     "description": "提示消息",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "状态",
-                    "type": "boolean"
-                }
-            }
-        },
-        "server.ExecWriteOffRequest": {
-            "type": "object",
-            "required": [
-                "customer_id",
-                "num"
-            ],
-            "properties": {
-                "customer_id": {
-                    "description": "客户ID",
-                    "type": "integer"
-                },
-                "num": {
-                    "description": "核销数目",
-                    "type": "integer"
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "boolean"
                 }
             }
         },
-        "server.ExecWriteOffResponse": {
+        "server.NearMerchantResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2476,8 +6699,10 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.MerchantWriteOffRespVO"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Merchant"
+                    }
                 },
                 "error": {
                     "description": "Error信息",
@@ -2493,19 +6718,29 @@ var doc = `{
                 }
             }
         },
-        "server.HelpCheckinRequest": {
+        "server.PointsAdjustRequest": {
             "type": "object",
             "required": [
-                "customer_id"
+                "amount",
+                "customer_id",
+                "reason"
             ],
             "properties": {
+                "amount": {
+                    "description": "调整的积分，正数为增加，负数为扣减",
+                    "type": "integer"
+                },
                 "customer_id": {
-                    "description": "补签客户ID",
+                    "description": "客户ID",
                     "type": "integer"
+                },
+                "reason": {
+                    "description": "调整原因",
+                    "type": "string"
                 }
             }
         },
-        "server.HelpCheckinResponse": {
+        "server.PointsAdjustResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2526,7 +6761,7 @@ var doc = `{
                 }
             }
         },
-        "server.IsSupplementCheckinResponse": {
+        "server.PointsBalanceResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2534,7 +6769,8 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "boolean"
+                    "type": "object",
+                    "$ref": "#/definitions/model.PointsBalance"
                 },
                 "error": {
                     "description": "Error信息",
@@ -2550,17 +6786,21 @@ var doc = `{
                 }
             }
         },
-        "server.IssueRecordResponse": {
+        "server.PointsEntryListResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.IssueRecord"
+                        "$ref": "#/definitions/model.PointsEntry"
                     }
                 },
                 "error": {
@@ -2574,34 +6814,37 @@ var doc = `{
                 "status": {
                     "description": "状态",
                     "type": "boolean"
+                },
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
                 }
             }
         },
-        "server.LuckyNumberAddRequest": {
+        "server.PointsRedeemRequest": {
             "type": "object",
             "required": [
+                "item",
                 "num"
             ],
             "properties": {
+                "item": {
+                    "description": "兑换项目：supplement(免费补签次数)，gift(下次领取福利时的额外福利)",
+                    "type": "string"
+                },
                 "num": {
-                    "description": "猜的数字",
+                    "description": "兑换数量，单次最多100",
                     "type": "integer"
                 }
             }
         },
-        "server.LuckyNumberAddResponse": {
+        "server.PointsRedeemResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2616,7 +6859,7 @@ var doc = `{
                 }
             }
         },
-        "server.LuckyNumberBeforeResponse": {
+        "server.ReferralCodeResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2625,7 +6868,7 @@ var doc = `{
                 },
                 "data": {
                     "type": "object",
-                    "$ref": "#/definitions/model.LuckyNumberRecord"
+                    "$ref": "#/definitions/model.ReferralCodeResp"
                 },
                 "error": {
                     "description": "Error信息",
@@ -2641,17 +6884,13 @@ var doc = `{
                 }
             }
         },
-        "server.LuckyNumberDetailResponse": {
+        "server.RefreshCheckinRecordResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.LuckyNumberRecord"
-                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2666,7 +6905,7 @@ var doc = `{
                 }
             }
         },
-        "server.LuckyPeopleBeforeResponse": {
+        "server.RegisterStatResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2674,8 +6913,10 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Customer"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RegisterStat"
+                    }
                 },
                 "error": {
                     "description": "Error信息",
@@ -2691,22 +6932,23 @@ var doc = `{
                 }
             }
         },
-        "server.MerchantAddRequest": {
-            "type": "object",
-            "properties": {
-                "merchant": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.MerchantVO"
-                }
-            }
-        },
-        "server.MerchantAddResponse": {
+        "server.ReviewListResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MerchantReviewItem"
+                    }
+                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2718,19 +6960,31 @@ var doc = `{
                 "status": {
                     "description": "状态",
                     "type": "boolean"
+                },
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
                 }
             }
         },
-        "server.MerchantDelRequest": {
+        "server.ReviewModerateRequest": {
             "type": "object",
+            "required": [
+                "action",
+                "id"
+            ],
             "properties": {
-                "merchant_id": {
-                    "description": "商户ID",
+                "action": {
+                    "description": "操作：approve(审核通过)，hide(隐藏)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "评价ID",
                     "type": "integer"
                 }
             }
         },
-        "server.MerchantDelResponse": {
+        "server.ReviewModerateResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2751,7 +7005,7 @@ var doc = `{
                 }
             }
         },
-        "server.MerchantDetailResponse": {
+        "server.RoleListResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2759,8 +7013,10 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.Merchant"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Role"
+                    }
                 },
                 "error": {
                     "description": "Error信息",
@@ -2776,22 +7032,29 @@ var doc = `{
                 }
             }
         },
-        "server.MerchantDisableRequest": {
+        "server.TokenRefreshRequest": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
-                "merchant_id": {
-                    "description": "商户ID",
-                    "type": "integer"
+                "refresh_token": {
+                    "description": "登录时返回的refresh_token",
+                    "type": "string"
                 }
             }
         },
-        "server.MerchantDisableResponse": {
+        "server.TokenRefreshResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "data": {
+                    "type": "object",
+                    "$ref": "#/definitions/model.TokenResp"
+                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2806,21 +7069,29 @@ var doc = `{
                 }
             }
         },
-        "server.MerchantEditRequest": {
+        "server.UserAddRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "password",
+                "role_id"
+            ],
             "properties": {
-                "merchant": {
-                    "description": "商户信息",
-                    "type": "object",
-                    "$ref": "#/definitions/model.MerchantVO"
+                "name": {
+                    "description": "用户名",
+                    "type": "string"
                 },
-                "merchant_id": {
-                    "description": "商户ID",
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "role_id": {
+                    "description": "角色ID",
                     "type": "integer"
                 }
             }
         },
-        "server.MerchantEditResponse": {
+        "server.UserAddResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2841,22 +7112,33 @@ var doc = `{
                 }
             }
         },
-        "server.MerchantListResponse": {
+        "server.UserLoginRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "password"
+            ],
+            "properties": {
+                "name": {
+                    "description": "用户名",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                }
+            }
+        },
+        "server.UserLoginResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "current": {
-                    "description": "当前页页码",
-                    "type": "integer"
-                },
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Merchant"
-                    }
+                    "type": "object",
+                    "$ref": "#/definitions/model.TokenResp"
                 },
                 "error": {
                     "description": "Error信息",
@@ -2869,24 +7151,33 @@ var doc = `{
                 "status": {
                     "description": "状态",
                     "type": "boolean"
+                }
+            }
+        },
+        "server.UserPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "新密码",
+                    "type": "string"
                 },
-                "total": {
-                    "description": "总数量",
-                    "type": "integer"
+                "old_password": {
+                    "description": "原密码",
+                    "type": "string"
                 }
             }
         },
-        "server.MerchantLoginResponse": {
+        "server.UserPasswordResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
-                "data": {
-                    "description": "access token",
-                    "type": "string"
-                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2901,20 +7192,24 @@ var doc = `{
                 }
             }
         },
-        "server.ModifyCheckinRecordRequest": {
+        "server.UserRoleRequest": {
             "type": "object",
+            "required": [
+                "role_id",
+                "user_id"
+            ],
             "properties": {
-                "checkin_record_id": {
-                    "description": "签到记录ID",
+                "role_id": {
+                    "description": "角色ID",
                     "type": "integer"
                 },
-                "status": {
-                    "description": "用户状态：U，A",
-                    "type": "string"
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "integer"
                 }
             }
         },
-        "server.ModifyCheckinRecordResponse": {
+        "server.UserRoleResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2935,7 +7230,7 @@ var doc = `{
                 }
             }
         },
-        "server.NearMerchantResponse": {
+        "server.WXConfigResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2943,10 +7238,8 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Merchant"
-                    }
+                    "type": "object",
+                    "$ref": "#/definitions/model.WXConfigResp"
                 },
                 "error": {
                     "description": "Error信息",
@@ -2962,13 +7255,16 @@ var doc = `{
                 }
             }
         },
-        "server.RefreshCheckinRecordResponse": {
+        "server.WXPayResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "data": {
+                    "type": "string"
+                },
                 "error": {
                     "description": "Error信息",
                     "type": "string"
@@ -2983,17 +7279,21 @@ var doc = `{
                 }
             }
         },
-        "server.RegisterStatResponse": {
+        "server.WriteOffLogListResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "description": "业务状态码",
                     "type": "string"
                 },
+                "current": {
+                    "description": "当前页页码",
+                    "type": "integer"
+                },
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.RegisterStat"
+                        "$ref": "#/definitions/model.WriteOffLogItem"
                     }
                 },
                 "error": {
@@ -3007,27 +7307,14 @@ var doc = `{
                 "status": {
                     "description": "状态",
                     "type": "boolean"
-                }
-            }
-        },
-        "server.UserLoginRequest": {
-            "type": "object",
-            "required": [
-                "name",
-                "password"
-            ],
-            "properties": {
-                "name": {
-                    "description": "用户名",
-                    "type": "string"
                 },
-                "password": {
-                    "description": "密码",
-                    "type": "string"
+                "total": {
+                    "description": "总数量",
+                    "type": "integer"
                 }
             }
         },
-        "server.UserLoginResponse": {
+        "server.WriteOffResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3035,8 +7322,8 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "description": "access token",
-                    "type": "string"
+                    "type": "object",
+                    "$ref": "#/definitions/model.MerchantWriteOffRespVO"
                 },
                 "error": {
                     "description": "Error信息",
@@ -3052,32 +7339,24 @@ var doc = `{
                 }
             }
         },
-        "server.WXConfigResponse": {
+        "server.WriteOffReverseRequest": {
             "type": "object",
+            "required": [
+                "id",
+                "reason"
+            ],
             "properties": {
-                "code": {
-                    "description": "业务状态码",
-                    "type": "string"
-                },
-                "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.WXConfigResp"
-                },
-                "error": {
-                    "description": "Error信息",
-                    "type": "string"
+                "id": {
+                    "description": "核销记录ID",
+                    "type": "integer"
                 },
-                "message": {
-                    "description": "提示消息",
+                "reason": {
+                    "description": "撤销原因",
                     "type": "string"
-                },
-                "status": {
-                    "description": "状态",
-                    "type": "boolean"
                 }
             }
         },
-        "server.WXPayResponse": {
+        "server.WriteOffReverseResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3085,7 +7364,8 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "string"
+                    "type": "object",
+                    "$ref": "#/definitions/model.WriteOffLog"
                 },
                 "error": {
                     "description": "Error信息",
@@ -3101,7 +7381,7 @@ var doc = `{
                 }
             }
         },
-        "server.WriteOffResponse": {
+        "server.WriteOffSummaryResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3109,8 +7389,10 @@ var doc = `{
                     "type": "string"
                 },
                 "data": {
-                    "type": "object",
-                    "$ref": "#/definitions/model.MerchantWriteOffRespVO"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WriteOffSummary"
+                    }
                 },
                 "error": {
                     "description": "Error信息",
@@ -38,6 +38,56 @@ const (
 	ErrNoParticipation        wsgin.APICode = "ERR_NO_PARTICIPATION"
 	ErrSave                   wsgin.APICode = "ERR_SAVE"
 	ErrLuckyPeople            wsgin.APICode = "ERR_LUCKY_PEOPLE"
+	ErrRefreshToken           wsgin.APICode = "ERR_REFRESH_TOKEN"
+	ErrLogout                 wsgin.APICode = "ERR_LOGOUT"
+	ErrLoginLocked            wsgin.APICode = "ERR_LOGIN_LOCKED"
+	ErrChangePassword         wsgin.APICode = "ERR_CHANGE_PASSWORD"
+	ErrUserExists             wsgin.APICode = "ERR_USER_EXISTS"
+	ErrRoleNotExists          wsgin.APICode = "ERR_ROLE_NOT_EXISTS"
+	ErrUpdateUserRole         wsgin.APICode = "ERR_UPDATE_USER_ROLE"
+	ErrSMSTooFrequent         wsgin.APICode = "ERR_SMS_TOO_FREQUENT"
+	ErrSMSTooManyAttempts     wsgin.APICode = "ERR_SMS_TOO_MANY_ATTEMPTS"
+	ErrVerifyCode             wsgin.APICode = "ERR_VERIFY_CODE"
+	ErrStaffExists            wsgin.APICode = "ERR_STAFF_EXISTS"
+	ErrStaffNotExists         wsgin.APICode = "ERR_STAFF_NOT_EXISTS"
+	ErrUpdateStaff            wsgin.APICode = "ERR_UPDATE_STAFF"
+	ErrWriteOffCode           wsgin.APICode = "ERR_WRITE_OFF_CODE"
+	ErrCampaignDate           wsgin.APICode = "ERR_CAMPAIGN_DATE"
+	ErrCampaignNotExists      wsgin.APICode = "ERR_CAMPAIGN_NOT_EXISTS"
+	ErrEditCampaign           wsgin.APICode = "ERR_EDIT_CAMPAIGN"
+	ErrMerchantNotInCampaign  wsgin.APICode = "ERR_MERCHANT_NOT_IN_CAMPAIGN"
+	ErrGetCheckinHistory      wsgin.APICode = "ERR_GET_CHECKIN_HISTORY"
+	ErrCheckinLocation        wsgin.APICode = "ERR_CHECKIN_LOCATION"
+	ErrNotNearMerchant        wsgin.APICode = "ERR_NOT_NEAR_MERCHANT"
+	ErrHelpCheckinDailyLimit  wsgin.APICode = "ERR_HELP_CHECKIN_DAILY_LIMIT"
+	ErrSupplementLimit        wsgin.APICode = "ERR_SUPPLEMENT_LIMIT"
+	ErrNoSupplement           wsgin.APICode = "ERR_NO_SUPPLEMENT"
+	ErrSupplementPayDisabled  wsgin.APICode = "ERR_SUPPLEMENT_PAY_DISABLED"
+	ErrHelpInvitation         wsgin.APICode = "ERR_HELP_INVITATION"
+	ErrReferralCode           wsgin.APICode = "ERR_REFERRAL_CODE"
+	ErrNoSupplementCredit     wsgin.APICode = "ERR_NO_SUPPLEMENT_CREDIT"
+	ErrSupplementCheckin      wsgin.APICode = "ERR_SUPPLEMENT_CHECKIN"
+	ErrPointsNotEnough        wsgin.APICode = "ERR_POINTS_NOT_ENOUGH"
+	ErrRedeemPoints           wsgin.APICode = "ERR_REDEEM_POINTS"
+	ErrAdjustPoints           wsgin.APICode = "ERR_ADJUST_POINTS"
+	ErrWriteOffDate           wsgin.APICode = "ERR_WRITE_OFF_DATE"
+	ErrExportWriteOffLog      wsgin.APICode = "ERR_EXPORT_WRITE_OFF_LOG"
+	ErrReverseWriteOff        wsgin.APICode = "ERR_REVERSE_WRITE_OFF"
+	ErrWriteOffLogNotExists   wsgin.APICode = "ERR_WRITE_OFF_LOG_NOT_EXISTS"
+	ErrWriteOffReversed       wsgin.APICode = "ERR_WRITE_OFF_REVERSED"
+	ErrReverseWindowPassed    wsgin.APICode = "ERR_REVERSE_WINDOW_PASSED"
+	ErrWelfareExpired         wsgin.APICode = "ERR_WELFARE_EXPIRED"
+	ErrTaskRemindWelfare      wsgin.APICode = "ERR_TASK_REMIND_WELFARE"
+	ErrReadInboxMessage       wsgin.APICode = "ERR_READ_INBOX_MESSAGE"
+	ErrGiftItemNotExists      wsgin.APICode = "ERR_GIFT_ITEM_NOT_EXISTS"
+	ErrEditGiftItem           wsgin.APICode = "ERR_EDIT_GIFT_ITEM"
+	ErrGiftItemRequired       wsgin.APICode = "ERR_GIFT_ITEM_REQUIRED"
+	ErrReview                 wsgin.APICode = "ERR_REVIEW"
+	ErrReviewExists           wsgin.APICode = "ERR_REVIEW_EXISTS"
+	ErrReviewWindowPassed     wsgin.APICode = "ERR_REVIEW_WINDOW_PASSED"
+	ErrReviewNotExists        wsgin.APICode = "ERR_REVIEW_NOT_EXISTS"
+	ErrReplyReview            wsgin.APICode = "ERR_REPLY_REVIEW"
+	ErrModerateReview         wsgin.APICode = "ERR_MODERATE_REVIEW"
 )
 
 func init() {
@@ -75,4 +125,54 @@ func init() {
 	wsgin.APICodeMapZH[ErrNoParticipation] = "禁止参与活动"
 	wsgin.APICodeMapZH[ErrSave] = "保存失败"
 	wsgin.APICodeMapZH[ErrLuckyPeople] = "获取幸运观众失败"
+	wsgin.APICodeMapZH[ErrRefreshToken] = "登录已过期，请重新登录"
+	wsgin.APICodeMapZH[ErrLogout] = "退出登录失败"
+	wsgin.APICodeMapZH[ErrLoginLocked] = "登录失败次数过多，请稍后再试"
+	wsgin.APICodeMapZH[ErrChangePassword] = "修改密码失败"
+	wsgin.APICodeMapZH[ErrUserExists] = "用户名已存在"
+	wsgin.APICodeMapZH[ErrRoleNotExists] = "角色不存在"
+	wsgin.APICodeMapZH[ErrUpdateUserRole] = "修改用户角色失败"
+	wsgin.APICodeMapZH[ErrSMSTooFrequent] = "验证码发送过于频繁，请稍后再试"
+	wsgin.APICodeMapZH[ErrSMSTooManyAttempts] = "验证码错误次数过多，请重新获取"
+	wsgin.APICodeMapZH[ErrVerifyCode] = "验证码错误或已过期"
+	wsgin.APICodeMapZH[ErrStaffExists] = "员工手机号已存在"
+	wsgin.APICodeMapZH[ErrStaffNotExists] = "员工不存在"
+	wsgin.APICodeMapZH[ErrUpdateStaff] = "修改员工失败"
+	wsgin.APICodeMapZH[ErrWriteOffCode] = "核销码无效或已过期，请让客户刷新二维码"
+	wsgin.APICodeMapZH[ErrCampaignDate] = "活动日期不正确"
+	wsgin.APICodeMapZH[ErrCampaignNotExists] = "签到活动不存在"
+	wsgin.APICodeMapZH[ErrEditCampaign] = "编辑签到活动失败"
+	wsgin.APICodeMapZH[ErrMerchantNotInCampaign] = "该商户未参与您的签到活动"
+	wsgin.APICodeMapZH[ErrGetCheckinHistory] = "获取签到历史失败"
+	wsgin.APICodeMapZH[ErrCheckinLocation] = "本次活动需要到店签到，请允许获取您的位置"
+	wsgin.APICodeMapZH[ErrNotNearMerchant] = "您不在活动商户附近，请到店后再签到"
+	wsgin.APICodeMapZH[ErrHelpCheckinDailyLimit] = "今天的帮签次数已用完，请明天再来"
+	wsgin.APICodeMapZH[ErrSupplementLimit] = "本轮补签天数已达上限"
+	wsgin.APICodeMapZH[ErrNoSupplement] = "没有可以补签的记录"
+	wsgin.APICodeMapZH[ErrSupplementPayDisabled] = "暂不支持付费补签"
+	wsgin.APICodeMapZH[ErrHelpInvitation] = "补签邀请无效或已过期"
+	wsgin.APICodeMapZH[ErrReferralCode] = "获取邀请码失败"
+	wsgin.APICodeMapZH[ErrNoSupplementCredit] = "没有可用的免费补签次数"
+	wsgin.APICodeMapZH[ErrSupplementCheckin] = "补签失败"
+	wsgin.APICodeMapZH[ErrPointsNotEnough] = "积分不足"
+	wsgin.APICodeMapZH[ErrRedeemPoints] = "积分兑换失败"
+	wsgin.APICodeMapZH[ErrAdjustPoints] = "调整积分失败"
+	wsgin.APICodeMapZH[ErrWriteOffDate] = "查询日期不正确，最多可查询92天"
+	wsgin.APICodeMapZH[ErrExportWriteOffLog] = "导出核销记录失败"
+	wsgin.APICodeMapZH[ErrReverseWriteOff] = "撤销核销失败"
+	wsgin.APICodeMapZH[ErrWriteOffLogNotExists] = "核销记录不存在"
+	wsgin.APICodeMapZH[ErrWriteOffReversed] = "该核销记录已撤销"
+	wsgin.APICodeMapZH[ErrReverseWindowPassed] = "已超过可撤销时间"
+	wsgin.APICodeMapZH[ErrWelfareExpired] = "福利已过期，无法撤销"
+	wsgin.APICodeMapZH[ErrTaskRemindWelfare] = "执行福利过期提醒任务失败"
+	wsgin.APICodeMapZH[ErrReadInboxMessage] = "标记消息已读失败"
+	wsgin.APICodeMapZH[ErrGiftItemNotExists] = "礼品不存在或已停用"
+	wsgin.APICodeMapZH[ErrEditGiftItem] = "编辑礼品失败"
+	wsgin.APICodeMapZH[ErrGiftItemRequired] = "请选择要领取的礼品"
+	wsgin.APICodeMapZH[ErrReview] = "评价失败"
+	wsgin.APICodeMapZH[ErrReviewExists] = "该次核销已评价过了"
+	wsgin.APICodeMapZH[ErrReviewWindowPassed] = "已超过可评价时间"
+	wsgin.APICodeMapZH[ErrReviewNotExists] = "评价不存在"
+	wsgin.APICodeMapZH[ErrReplyReview] = "回复评价失败"
+	wsgin.APICodeMapZH[ErrModerateReview] = "审核评价失败"
 }
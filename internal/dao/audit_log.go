@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"context"
+
+	"welfare-sign/internal/dao/mysql"
+	"welfare-sign/internal/model"
+)
+
+// CreateAuditLog 记录审计日志
+func (d *dao) CreateAuditLog(ctx context.Context, data *model.AuditLog) error {
+	data.SetDefaultAttr()
+	data.CreatedBy = data.ActorID
+	data.UpdatedBy = data.ActorID
+	return d.db.Create(data).Error
+}
+
+// ListAuditLog 分页查询审计日志
+// pageNo >= 1
+func (d *dao) ListAuditLog(ctx context.Context, vo *model.AuditLogListVO) ([]*model.AuditLog, int, error) {
+	var logs []*model.AuditLog
+	total := 0
+
+	db := d.db.Model(&model.AuditLog{})
+	if vo.ActorID != 0 {
+		db = db.Where("actor_id = ?", vo.ActorID)
+	}
+	if vo.Action != "" {
+		db = db.Where("action = ?", vo.Action)
+	}
+	if vo.EntityType != "" {
+		db = db.Where("entity_type = ?", vo.EntityType)
+	}
+	if vo.EntityID != 0 {
+		db = db.Where("entity_id = ?", vo.EntityID)
+	}
+	if vo.BeginDate != "" {
+		db = db.Where("created_at >= ?", vo.BeginDate)
+	}
+	if vo.EndDate != "" {
+		db = db.Where("created_at < DATE_ADD(?, INTERVAL 1 DAY)", vo.EndDate)
+	}
+
+	if err := db.Count(&total).Error; mysql.IsError(err) {
+		return logs, total, err
+	}
+	err := db.Limit(vo.PageSize).Offset((vo.PageNo - 1) * vo.PageSize).Order("id desc").Find(&logs).Error
+	if mysql.IsError(err) {
+		return logs, total, err
+	}
+	return logs, total, nil
+}
@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"context"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+const getBadgeStatSQL = `
+	SELECT
+	(SELECT COUNT(DISTINCT customer_id) FROM checkin_record WHERE help_checkin_customer_id = ?) AS help_friends,
+	(SELECT COUNT(DISTINCT merchant_id) FROM issue_record_log WHERE customer_id = ?) AS merchants,
+	(SELECT COUNT(id) FROM lucky_number_record WHERE customer_id = ? AND ranking = 1 AND status = ?) AS lucky_wins
+	`
+
+// GetBadgeStat 获取计算徽章所需的客户统计数据
+func (d *dao) GetBadgeStat(ctx context.Context, customerID uint64) (*model.BadgeStat, error) {
+	var stat model.BadgeStat
+	err := d.db.Raw(getBadgeStatSQL, customerID, customerID, customerID, global.ActiveStatus).Scan(&stat).Error
+	return &stat, err
+}
+
+// ListCustomerBadge 获取客户已获得的徽章
+func (d *dao) ListCustomerBadge(ctx context.Context, customerID uint64) ([]*model.CustomerBadge, error) {
+	var badges []*model.CustomerBadge
+	err := checkErr(d.db.Where("customer_id = ?", customerID).Order("id").Find(&badges).Error)
+	return badges, err
+}
+
+// CreateCustomerBadge 发放徽章，并发发放时徽章已存在则忽略
+func (d *dao) CreateCustomerBadge(ctx context.Context, data *model.CustomerBadge) error {
+	data.SetDefaultAttr()
+	return d.db.Set("gorm:insert_modifier", "IGNORE").Create(data).Error
+}
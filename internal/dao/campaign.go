@@ -0,0 +1,158 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"welfare-sign/internal/dao/mysql"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// FindCampaign 获取签到活动，包含参与活动的商户
+func (d *dao) FindCampaign(ctx context.Context, query interface{}) (*model.Campaign, error) {
+	var campaign model.Campaign
+	if err := checkErr(d.db.Where(query).First(&campaign).Error); err != nil || campaign.ID == 0 {
+		return &campaign, err
+	}
+	merchants, err := d.ListCampaignMerchant(ctx, campaign.ID)
+	campaign.Merchants = merchants
+	return &campaign, err
+}
+
+// GetCurrentCampaign 获取 date 当天进行中的签到活动，有多个时取最晚开始的一个，没有时返回的活动ID为0
+func (d *dao) GetCurrentCampaign(ctx context.Context, date string) (*model.Campaign, error) {
+	var campaign model.Campaign
+	err := checkErr(d.db.Where("status = ? AND start_date <= ? AND end_date >= ?", global.ActiveStatus, date, date).
+		Order("start_date desc, id desc").First(&campaign).Error)
+	if err != nil || campaign.ID == 0 {
+		return &campaign, err
+	}
+	merchants, err := d.ListCampaignMerchant(ctx, campaign.ID)
+	campaign.Merchants = merchants
+	return &campaign, err
+}
+
+// ListCampaign 获取签到活动列表
+// pageNo >= 1
+func (d *dao) ListCampaign(ctx context.Context, query interface{}, pageNo, pageSize int) ([]*model.Campaign, int, error) {
+	var campaigns []*model.Campaign
+	total := 0
+	err := d.db.Where(query).Limit(pageSize).Offset((pageNo - 1) * pageSize).Order("start_date desc, id desc").Find(&campaigns).Error
+	if mysql.IsError(err) {
+		return campaigns, total, err
+	}
+	if err := d.db.Model(&model.Campaign{}).Where(query).Count(&total).Error; mysql.IsError(err) {
+		return campaigns, total, err
+	}
+	if len(campaigns) == 0 {
+		return campaigns, total, nil
+	}
+	campaignIDs := make([]uint64, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		campaignIDs = append(campaignIDs, campaign.ID)
+	}
+	var rows []*model.CampaignMerchant
+	if err := d.db.Where("campaign_id IN (?) AND status = ?", campaignIDs, global.ActiveStatus).Find(&rows).Error; mysql.IsError(err) {
+		return campaigns, total, err
+	}
+	merchants := make(map[uint64][]uint64)
+	for _, row := range rows {
+		merchants[row.CampaignID] = append(merchants[row.CampaignID], row.MerchantID)
+	}
+	for _, campaign := range campaigns {
+		campaign.Merchants = merchants[campaign.ID]
+	}
+	return campaigns, total, nil
+}
+
+// ListCampaignMerchant 获取参与签到活动的商户ID
+func (d *dao) ListCampaignMerchant(ctx context.Context, campaignID uint64) ([]uint64, error) {
+	var merchants []uint64
+	err := checkErr(d.db.Model(&model.CampaignMerchant{}).Where(map[string]interface{}{
+		"campaign_id": campaignID,
+		"status":      global.ActiveStatus,
+	}).Pluck("merchant_id", &merchants).Error)
+	return merchants, err
+}
+
+// CreateCampaign 新增签到活动及参与的商户
+func (d *dao) CreateCampaign(ctx context.Context, campaign *model.Campaign) error {
+	tx := d.db.Begin()
+	campaign.SetDefaultAttr()
+	if err := tx.Create(campaign).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, merchantID := range campaign.Merchants {
+		data := model.CampaignMerchant{
+			CampaignID: campaign.ID,
+			MerchantID: merchantID,
+		}
+		data.SetDefaultAttr()
+		if err := tx.Create(&data).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// UpdateCampaign 更新签到活动，参与的商户按差异调整：新增的商户插入或重新启用，移除的商户标记为删除
+func (d *dao) UpdateCampaign(ctx context.Context, campaign *model.Campaign) error {
+	tx := d.db.Begin()
+	now := time.Now()
+	campaign.UpdatedAt = now
+	if err := tx.Save(campaign).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var rows []*model.CampaignMerchant
+	if err := tx.Where("campaign_id = ?", campaign.ID).Find(&rows).Error; mysql.IsError(err) {
+		tx.Rollback()
+		return err
+	}
+	wanted := make(map[uint64]bool, len(campaign.Merchants))
+	for _, merchantID := range campaign.Merchants {
+		wanted[merchantID] = true
+	}
+	var enableIDs, disableIDs []uint64
+	for _, row := range rows {
+		switch {
+		case wanted[row.MerchantID] && row.Status != global.ActiveStatus:
+			enableIDs = append(enableIDs, row.ID)
+		case !wanted[row.MerchantID] && row.Status != global.DeleteStatus:
+			disableIDs = append(disableIDs, row.ID)
+		}
+		delete(wanted, row.MerchantID)
+	}
+	for status, ids := range map[string][]uint64{global.ActiveStatus: enableIDs, global.DeleteStatus: disableIDs} {
+		if len(ids) == 0 {
+			continue
+		}
+		if err := tx.Model(&model.CampaignMerchant{}).Where("id IN (?)", ids).Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": now,
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	for _, merchantID := range campaign.Merchants {
+		if !wanted[merchantID] {
+			continue
+		}
+		delete(wanted, merchantID)
+		data := model.CampaignMerchant{
+			CampaignID: campaign.ID,
+			MerchantID: merchantID,
+		}
+		data.SetDefaultAttr()
+		if err := tx.Create(&data).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
@@ -12,25 +13,34 @@ import (
 	"welfare-sign/internal/model"
 	"welfare-sign/internal/pkg/config"
 	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/supplement"
 )
 
 const (
 	hasCheckedSQL = `
-SELECT * from checkin_record WHERE CURRENT_DATE = DATE(need_checkin_time) AND customer_id = ? 
+SELECT * from checkin_record WHERE need_checkin_time >= ? AND need_checkin_time < ? AND customer_id = ? 
 AND status = ?
 `
-	getUncheckedSQL = `
-SELECT * from checkin_record WHERE DATE(need_checkin_time) < CURRENT_DATE AND customer_id = ? 
-AND status = ?
-	`
 	execCheckinSQL = `
-	UPDATE checkin_record SET status = ?, updated_at = ? WHERE DATE(need_checkin_time) = CURRENT_DATE AND status = ? AND customer_id = ?
+	UPDATE checkin_record SET status = ?, updated_at = ?, merchant_id = ? WHERE need_checkin_time >= ? AND need_checkin_time < ? AND status = ? AND customer_id = ?
 	`
 	helpCheckinSQL = `
-	UPDATE checkin_record SET status = ?, updated_at = ?, help_checkin_customer_id = ? WHERE id = ?
+	UPDATE checkin_record SET status = ?, updated_at = ?, help_checkin_customer_id = ?, supplement_type = ? WHERE id = ? AND status = ?
+	`
+	creditCheckinSQL = `
+	UPDATE checkin_record SET status = ?, updated_at = ?, supplement_type = ? WHERE id = ? AND status = ?
 	`
 	payCheckinSQL = `
-	UPDATE checkin_record SET status = ?, updated_at = ? WHERE id = ?
+	UPDATE checkin_record SET status = ?, updated_at = ?, supplement_type = ? WHERE id = ?
+	`
+	listClaimedGiftSQL = `
+	SELECT l.merchant_id, m.store_name, l.total_receive AS num, l.created_at AS claimed_at FROM issue_record_log AS l
+LEFT JOIN merchant AS m ON m.id = l.merchant_id
+WHERE l.customer_id = ? AND l.status = ? ORDER BY l.id DESC
+LIMIT ? OFFSET ?
+	`
+	summaryClaimedGiftSQL = `
+	SELECT COUNT(*), IFNULL(SUM(total_receive), 0) FROM issue_record_log WHERE customer_id = ? AND status = ?
 	`
 	getNeedClearIssueRecordsSQL = `
 	SELECT *from issue_record WHERE status = ? AND total_receive > received
@@ -38,6 +48,9 @@ AND created_at <= DATE_ADD(NOW(), INTERVAL - ? MINUTE)
 	`
 )
 
+// ErrCheckinRecordSupplemented 签到记录已被补签，并发补签同一天时只有一个成功
+var ErrCheckinRecordSupplemented = errors.New("该签到记录无需补签")
+
 // ListCheckinRecord 获取签到列表
 func (d *dao) ListCheckinRecord(ctx context.Context, query interface{}, args ...interface{}) ([]*model.CheckinRecord, error) {
 	var res []*model.CheckinRecord
@@ -45,20 +58,22 @@ func (d *dao) ListCheckinRecord(ctx context.Context, query interface{}, args ...
 	return res, err
 }
 
-// InitCheckinRecords 用户第一次访问签到页面时，初始化签到信息并返回
-func (d *dao) InitCheckinRecords(ctx context.Context, customerID uint64) ([]*model.CheckinRecord, error) {
+// InitCheckinRecords 用户开始新一轮签到时，按签到周期初始化签到信息并返回
+// days 为每天需要签到的业务日开始时刻
+func (d *dao) InitCheckinRecords(ctx context.Context, customerID, campaignID uint64, days []time.Time) ([]*model.CheckinRecord, error) {
 	tx := d.db.Begin()
 
 	var res []*model.CheckinRecord
-	// 目前限制签到5天，只创建5条记录
-	for i := 0; i < 5; i++ {
+	now := time.Now()
+	for i, day := range days {
 		cr := &model.CheckinRecord{}
 		cr.Status = global.InactiveStatus
-		cr.UpdatedAt = time.Now()
-		cr.CreatedAt = time.Now()
+		cr.UpdatedAt = now
+		cr.CreatedAt = now
 		cr.CustomerID = customerID
+		cr.CampaignID = campaignID
 		cr.Day = uint64(i) + 1
-		cr.NeedCheckinTime = time.Now().Add(time.Duration(i) * 24 * time.Hour)
+		cr.NeedCheckinTime = day
 		res = append(res, cr)
 		if err := tx.Create(cr).Error; err != nil {
 			log.Warn(ctx, "dao.InitCheckinRecords() error", zap.Error(err))
@@ -77,11 +92,11 @@ func (d *dao) FindCheckinRecord(ctx context.Context, query interface{}, args ...
 	return &checkinRecord, err
 }
 
-// ExecCheckin 记录用户签到
-func (d *dao) ExecCheckin(ctx context.Context, customerID uint64) error {
+// ExecCheckin 记录用户签到，[dayStart, dayEnd) 为当前业务日，merchantID 为到店签到时所在的店铺
+func (d *dao) ExecCheckin(ctx context.Context, customerID, merchantID uint64, dayStart, dayEnd time.Time) error {
 	tx := d.db.Begin()
 
-	if err := tx.Exec(execCheckinSQL, global.ActiveStatus, time.Now(), global.InactiveStatus, customerID).Error; err != nil {
+	if err := tx.Exec(execCheckinSQL, global.ActiveStatus, time.Now(), merchantID, dayStart, dayEnd, global.InactiveStatus, customerID).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -96,6 +111,7 @@ func (d *dao) ExecCheckin(ctx context.Context, customerID uint64) error {
 	var recordLog model.CheckinRecordLog
 	recordLog.SetDefaultAttr()
 	recordLog.CustomerID = customerID
+	recordLog.MerchantID = merchantID
 	if err := tx.Create(&recordLog).Error; err != nil {
 		tx.Rollback()
 		return err
@@ -104,19 +120,79 @@ func (d *dao) ExecCheckin(ctx context.Context, customerID uint64) error {
 	return nil
 }
 
+// ListCheckinTime 获取客户在 [begin, end) 内签到的时间，按时间正序
+func (d *dao) ListCheckinTime(ctx context.Context, customerID uint64, begin, end time.Time) ([]time.Time, error) {
+	var times []time.Time
+	err := checkErr(d.db.Model(&model.CheckinRecordLog{}).Where(map[string]interface{}{
+		"customer_id": customerID,
+		"status":      global.ActiveStatus,
+	}).Where("created_at >= ? AND created_at < ?", begin, end).Order("created_at asc").Pluck("created_at", &times).Error)
+	return times, err
+}
+
+// CountCheckin 获取客户累计签到次数
+func (d *dao) CountCheckin(ctx context.Context, customerID uint64) (int, error) {
+	total := 0
+	err := checkErr(d.db.Model(&model.CheckinRecordLog{}).Where(map[string]interface{}{
+		"customer_id": customerID,
+		"status":      global.ActiveStatus,
+	}).Count(&total).Error)
+	return total, err
+}
+
+// ListClaimedGift 获取客户领取福利记录，按领取时间倒序
+// pageNo >= 1
+func (d *dao) ListClaimedGift(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.ClaimedGift, error) {
+	var gifts []*model.ClaimedGift
+	err := checkErr(d.db.Raw(listClaimedGiftSQL, customerID, global.ActiveStatus, pageSize, (pageNo-1)*pageSize).Scan(&gifts).Error)
+	return gifts, err
+}
+
+// SummaryClaimedGift 获取客户领取福利的次数及礼品总数
+func (d *dao) SummaryClaimedGift(ctx context.Context, customerID uint64) (times, num uint64, err error) {
+	err = checkErr(d.db.Raw(summaryClaimedGiftSQL, customerID, global.ActiveStatus).Row().Scan(&times, &num))
+	return
+}
+
 // InvalidCheckin 作废用户签到记录
 func (d *dao) InvalidCheckin(ctx context.Context, customerID uint64) error {
 	return checkErr(d.db.Model(&model.CheckinRecord{}).Where("status <> ? AND customer_id = ?", global.DeleteStatus, customerID).Update("status", global.DeleteStatus).Error)
 }
 
-// HelpCheckin 帮助他人补签
-func (d *dao) HelpCheckin(ctx context.Context, checkRecordID, customerID, helpCustomerID uint64) error {
+// HelpCheckin 帮助他人补签，通过补签邀请帮签时同时记录接受邀请
+// 锁定帮签人后在事务中校验其在 [dayStart, dayEnd) 内的帮签次数，dailyLimit 为0时不限制，超过时返回 supplement.ErrHelperDailyLimit
+// 签到记录已被补签时返回 ErrCheckinRecordSupplemented
+func (d *dao) HelpCheckin(ctx context.Context, checkRecordID, customerID, helpCustomerID, invitationID uint64, dayStart, dayEnd time.Time, dailyLimit int) error {
 	tx := d.db.Begin()
 
-	if err := tx.Exec(helpCheckinSQL, global.ActiveStatus, time.Now(), helpCustomerID, checkRecordID).Error; err != nil {
+	var helper model.Customer
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", helpCustomerID).First(&helper).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
+	if dailyLimit > 0 {
+		var helpedToday int
+		if err := tx.Model(&model.HelpCheckinMessage{}).
+			Where("help_customer_id = ? AND created_at >= ? AND created_at < ?", helpCustomerID, dayStart, dayEnd).
+			Count(&helpedToday).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if helpedToday >= dailyLimit {
+			tx.Rollback()
+			return supplement.ErrHelperDailyLimit
+		}
+	}
+
+	db := tx.Exec(helpCheckinSQL, global.ActiveStatus, time.Now(), helpCustomerID, supplement.TypeHelp, checkRecordID, global.InactiveStatus)
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrCheckinRecordSupplemented
+	}
 	if err := tx.Model(&model.Customer{}).Where(map[string]interface{}{
 		"id":     customerID,
 		"status": global.ActiveStatus,
@@ -128,20 +204,37 @@ func (d *dao) HelpCheckin(ctx context.Context, checkRecordID, customerID, helpCu
 	msg.SetDefaultAttr()
 	msg.CheckinRecordID = checkRecordID
 	msg.CustomerID = customerID
+	msg.HelpCustomerID = helpCustomerID
+	msg.InvitationID = invitationID
 	msg.IsRead = global.UnRead
 	if err := tx.Create(&msg).Error; err != nil {
 		log.Warn(ctx, "分享补签时创建补签消息失败", zap.Error(err))
 		tx.Rollback()
 		return err
 	}
+	if invitationID != 0 {
+		event := model.HelpInvitationEvent{
+			InvitationID: invitationID,
+			CustomerID:   customerID,
+			VisitorID:    helpCustomerID,
+			Event:        global.HelpInvitationAccept,
+		}
+		event.SetDefaultAttr()
+		event.CreatedBy = helpCustomerID
+		if err := tx.Create(&event).Error; err != nil {
+			log.Warn(ctx, "分享补签时记录接受邀请失败", zap.Error(err))
+			tx.Rollback()
+			return err
+		}
+	}
 	tx.Commit()
 	return nil
 }
 
-// HasChecked 用户当前是否已签到
-func (d *dao) HasChecked(ctx context.Context, customerID uint64) (bool, error) {
+// HasChecked 用户在 [dayStart, dayEnd) 业务日内是否已签到
+func (d *dao) HasChecked(ctx context.Context, customerID uint64, dayStart, dayEnd time.Time) (bool, error) {
 	var checkinRecord model.CheckinRecord
-	if err := d.db.Raw(hasCheckedSQL, customerID, global.ActiveStatus).First(&checkinRecord).Error; err != nil {
+	if err := d.db.Raw(hasCheckedSQL, dayStart, dayEnd, customerID, global.ActiveStatus).First(&checkinRecord).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return false, nil
 		}
@@ -153,18 +246,46 @@ func (d *dao) HasChecked(ctx context.Context, customerID uint64) (bool, error) {
 	return false, nil
 }
 
-// GetUnchecked 获取当天之前未签到的记录
-func (d *dao) GetUnchecked(ctx context.Context, customerID uint64) (*model.CheckinRecord, error) {
-	var checkinRecord model.CheckinRecord
-	err := checkErr(d.db.Raw(getUncheckedSQL, customerID, global.InactiveStatus).First(&checkinRecord).Error)
-	return &checkinRecord, err
-}
+// CreditCheckin 使用一次免费补签次数补签
+func (d *dao) CreditCheckin(ctx context.Context, checkRecordID, customerID uint64) error {
+	tx := d.db.Begin()
+
+	db := tx.Model(&model.Customer{}).Where("id = ? AND status = ? AND supplement_credits > 0", customerID, global.ActiveStatus).
+		Updates(map[string]interface{}{
+			"supplement_credits": gorm.Expr("supplement_credits - 1"),
+			"last_checkin_time":  time.Now(),
+		})
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.New("没有可用的免费补签次数")
+	}
+	db = tx.Exec(creditCheckinSQL, global.ActiveStatus, time.Now(), supplement.TypeCredit, checkRecordID, global.InactiveStatus)
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrCheckinRecordSupplemented
+	}
 
-// GetAllUnchecked 获取当天之前所有未签到的记录
-func (d *dao) GetAllUnchecked(ctx context.Context, customerID uint64) ([]*model.CheckinRecord, error) {
-	var checkinRecords []*model.CheckinRecord
-	err := checkErr(d.db.Raw(getUncheckedSQL, customerID, global.InactiveStatus).Find(&checkinRecords).Error)
-	return checkinRecords, err
+	msg := model.HelpCheckinMessage{}
+	msg.SetDefaultAttr()
+	msg.CheckinRecordID = checkRecordID
+	msg.CustomerID = customerID
+	msg.IsRead = global.UnRead
+	if err := tx.Create(&msg).Error; err != nil {
+		log.Warn(ctx, "免费补签时创建补签消息失败", zap.Error(err))
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	return nil
 }
 
 // PayCheckin 用户支付后补签
@@ -172,7 +293,7 @@ func (d *dao) PayCheckin(ctx context.Context, checkRecordIds []uint64, customerI
 	tx := d.db.Begin()
 
 	for i := 0; i < len(checkRecordIds); i++ {
-		if err := tx.Exec(payCheckinSQL, global.ActiveStatus, time.Now(), checkRecordIds[i]).Error; err != nil {
+		if err := tx.Exec(payCheckinSQL, global.ActiveStatus, time.Now(), supplement.TypePay, checkRecordIds[i]).Error; err != nil {
 			tx.Rollback()
 			return err
 		}
@@ -218,26 +339,28 @@ func (d *dao) GetNeedClearIssueRecords(ctx context.Context) ([]*model.IssueRecor
 	return issueRecords, nil
 }
 
-// FailureIssueRecord 失效过期的福利
+// FailureIssueRecord 失效过期的福利，已被失效或撤销的福利不做处理
+// 只按条件更新状态，不覆盖同时发生的核销，未核销数量在同一事务中按最新的已核销数量计算
 func (d *dao) FailureIssueRecord(ctx context.Context, issueRecord *model.IssueRecord) error {
 	tx := d.db.Begin()
 
-	issueRecord.Status = global.InactiveStatus
-	if err := checkErr(tx.Save(issueRecord).Error); err != nil {
+	db := tx.Model(&model.IssueRecord{}).Where("id = ? AND status = ?", issueRecord.ID, global.ActiveStatus).Updates(map[string]interface{}{
+		"status":     global.InactiveStatus,
+		"updated_at": time.Now(),
+	})
+	if db.Error != nil {
 		tx.Rollback()
-		return err
+		return db.Error
 	}
-	var merchant model.Merchant
-	num := issueRecord.TotalReceive - issueRecord.Received
-	if err := tx.Where(map[string]interface{}{
-		"id":     issueRecord.MerchantID,
-		"status": global.ActiveStatus,
-	}).First(&merchant).Error; err != nil {
+	if db.RowsAffected == 0 {
 		tx.Rollback()
-		return err
+		return nil
 	}
-	merchant.HasFailure += num
-	if err := tx.Save(merchant).Error; err != nil {
+	issueRecord.Status = global.InactiveStatus
+	if err := tx.Model(&model.Merchant{}).Where(map[string]interface{}{
+		"id":     issueRecord.MerchantID,
+		"status": global.ActiveStatus,
+	}).Update("has_failure", gorm.Expr("has_failure + (SELECT total_receive - received FROM issue_record WHERE id = ?)", issueRecord.ID)).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -30,7 +30,8 @@ func (d *dao) FindCustomer(ctx context.Context, query interface{}) (*model.Custo
 }
 
 // UpsertCustomer update or insert customer
-func (d *dao) UpsertCustomer(ctx context.Context, data *model.WxUserResp) (customer *model.Customer, err error) {
+// referrerID 不为0时，新注册的客户记录邀请人并创建邀请注册记录
+func (d *dao) UpsertCustomer(ctx context.Context, data *model.WxUserResp, referrerID uint64) (customer *model.Customer, err error) {
 	customer, err = d.FindCustomer(ctx, map[string]interface{}{"open_id": data.OpenID})
 	if checkErr(err) != nil {
 		return
@@ -39,9 +40,26 @@ func (d *dao) UpsertCustomer(ctx context.Context, data *model.WxUserResp) (custo
 		var c model.Customer
 		util.StructCopy(&c, data)
 		c.SetDefaultAttr()
-		if err := d.db.Create(&c).Error; err != nil {
+		c.ReferrerID = referrerID
+
+		tx := d.db.Begin()
+		if err := tx.Create(&c).Error; err != nil {
+			tx.Rollback()
 			return nil, err
 		}
+		if referrerID != 0 {
+			referral := model.Referral{
+				ReferrerID:    referrerID,
+				RefereeID:     c.ID,
+				RefereeOpenID: data.OpenID,
+			}
+			referral.SetDefaultAttr()
+			if err := tx.Create(&referral).Error; err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+		tx.Commit()
 		return d.FindCustomer(ctx, map[string]interface{}{"open_id": data.OpenID})
 	}
 	customer.UpdatedAt = time.Now()
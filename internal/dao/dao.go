@@ -2,6 +2,8 @@ package dao
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -25,26 +27,26 @@ type Dao interface {
 	FindMerchant(ctx context.Context, query interface{}) (*model.Merchant, error)
 	FindCustomer(ctx context.Context, query interface{}) (*model.Customer, error)
 	FindIssueRecord(ctx context.Context, query interface{}) (*model.IssueRecord, error)
-	EcecWriteOff(ctx context.Context, merchantID, customerID, hasRece, writeOffNum uint64) error
+	EcecWriteOff(ctx context.Context, writeOffLog *model.WriteOffLog) error
 	ListCheckinRecord(ctx context.Context, query interface{}, args ...interface{}) ([]*model.CheckinRecord, error)
-	InitCheckinRecords(ctx context.Context, customerID uint64) ([]*model.CheckinRecord, error)
-	UpsertCustomer(ctx context.Context, data *model.WxUserResp) (*model.Customer, error)
+	InitCheckinRecords(ctx context.Context, customerID, campaignID uint64, days []time.Time) ([]*model.CheckinRecord, error)
+	UpsertCustomer(ctx context.Context, data *model.WxUserResp, referrerID uint64) (*model.Customer, error)
 	NearMerchant(ctx context.Context, data *model.NearMerchantVO) ([]*model.Merchant, error)
+	FindNearestMerchant(ctx context.Context, lat, lon, distance float64, merchantIDs []uint64) (*model.Merchant, error)
 	FindCheckinRecord(ctx context.Context, query interface{}, args ...interface{}) (*model.CheckinRecord, error)
-	ExecCheckin(ctx context.Context, customerID uint64) error
+	ExecCheckin(ctx context.Context, customerID, merchantID uint64, dayStart, dayEnd time.Time) error
 	ListIssueRecord(ctx context.Context, query interface{}, args ...interface{}) ([]*model.IssueRecord, error)
 	ListIssueRecordDetail(ctx context.Context, query interface{}, args ...interface{}) ([]*model.IssueRecord, error)
-	CreateIssueRecord(ctx context.Context, data model.IssueRecord, merchant *model.Merchant, mobile string) error
+	CreateIssueRecord(ctx context.Context, data model.IssueRecord, mobile string, bonusGiftNum uint64) error
 	InvalidCheckin(ctx context.Context, customerID uint64) error
-	HelpCheckin(ctx context.Context, checkRecordID, customerID, helpCustomerID uint64) error
+	HelpCheckin(ctx context.Context, checkRecordID, customerID, helpCustomerID, invitationID uint64, dayStart, dayEnd time.Time, dailyLimit int) error
 	StoreWXAccessToken(ak string, expire time.Duration) error
 	StoreWXJSTicket(ticket string, expire time.Duration) error
 	GetWXAccessToken() (string, error)
 	GetWXJSTicket() (string, error)
-	HasChecked(ctx context.Context, customerID uint64) (bool, error)
-	GetUnchecked(ctx context.Context, customerID uint64) (*model.CheckinRecord, error)
-	GetAllUnchecked(ctx context.Context, customerID uint64) ([]*model.CheckinRecord, error)
+	HasChecked(ctx context.Context, customerID uint64, dayStart, dayEnd time.Time) (bool, error)
 	PayCheckin(ctx context.Context, checkRecordIds []uint64, customerID uint64, payRecord *model.WXPayRecord) error
+	CreditCheckin(ctx context.Context, checkRecordID, customerID uint64) error
 	FindWXPayRecord(ctx context.Context, query map[string]interface{}) (*model.WXPayRecord, error)
 	UpdateMerchant(ctx context.Context, data *model.Merchant) error
 	DeleteMerchant(ctx context.Context, merchantID uint64)
@@ -52,7 +54,7 @@ type Dao interface {
 	DeleteCustomer(ctx context.Context, customerID uint64)
 	GetRoundMerchantPoster() (*model.Merchant, error)
 	DelSMSCode(ctx context.Context, mobile string) error
-	GetTmpCheckinRecordList(ctx context.Context) ([]*model.CheckinRecordListResp, error)
+	GetTmpCheckinRecordList(ctx context.Context, before time.Time) ([]*model.CheckinRecordListResp, error)
 	UpdateCustomerCheckinRecord(ctx context.Context, checkinRecord uint64, status string) error
 	FindHelpCheckinMesage(ctx context.Context, query interface{}, args ...interface{}) (*model.HelpCheckinMessage, error)
 	UpdateHelpCheckinMessage(ctx context.Context, customerID uint64) error
@@ -64,11 +66,98 @@ type Dao interface {
 	StoreLuckyNumberRecord(ctx context.Context, customerID uint64, num int64) ([]int64, error)
 	GetCompositeIndexBefore(ctx context.Context) (*model.CompositeIndex, error)
 	GetLuckyNumberRecordBefore(ctx context.Context, customerID uint64) (*model.LuckyNumberRecord, error)
+	GetRoundLuckyNumberRecordSQL(ctx context.Context) ([]*model.LuckyNumberRecord, error)
 	GetLuckyPeopleBefore(ctx context.Context) (*model.Customer, error)
 	StoreCompositeIndex(ctx context.Context, compositeDate string, points float64) error
 	GetCompositeIndexByQuery(ctx context.Context, query interface{}) (*model.CompositeIndex, error)
 	GetRegisterStat(ctx context.Context, beginDate, endDate string) ([]*model.RegisterStat, error)
 	GetCheckinStat(ctx context.Context, beginDate, endDate string) ([]*model.CheckinStat, error)
+	SaveRefreshToken(ctx context.Context, token string, session *model.TokenSession, expire time.Duration) error
+	GetRefreshToken(ctx context.Context, token string) (*model.TokenSession, error)
+	DelRefreshToken(ctx context.Context, token string, session *model.TokenSession) (bool, error)
+	RevokeToken(ctx context.Context, jti string, expire time.Duration) error
+	RevokeAccount(ctx context.Context, role string, uid uint64, accessExpire time.Duration) error
+	IsTokenRevoked(ctx context.Context, jti, role string, uid uint64, issuedAt int64) (bool, error)
+	ListUser(ctx context.Context, query interface{}) ([]*model.User, error)
+	UpdateUserPassword(ctx context.Context, userID uint64, oldPassword, newPassword string) (bool, error)
+	IncrUserLoginFail(ctx context.Context, name, clientIP string, lockTime time.Duration) (int64, int64, error)
+	DelUserLoginFail(ctx context.Context, name, clientIP string) error
+	CreateUser(ctx context.Context, data model.User) error
+	UpdateUser(ctx context.Context, query interface{}, data map[string]interface{}) error
+	FindRole(ctx context.Context, query interface{}) (*model.Role, error)
+	ListRole(ctx context.Context) ([]*model.Role, error)
+	CreateRole(ctx context.Context, role *model.Role, permissions []string) error
+	ListRolePermission(ctx context.Context, roleID uint64) ([]string, error)
+	CreateRolePermission(ctx context.Context, roleID uint64, permissions []string) error
+	CreateAuditLog(ctx context.Context, data *model.AuditLog) error
+	ListAuditLog(ctx context.Context, vo *model.AuditLogListVO) ([]*model.AuditLog, int, error)
+	IncrSMSCodeAttempts(ctx context.Context, mobile string) (int64, error)
+	SetSMSCooldown(ctx context.Context, mobile string, interval time.Duration) (bool, error)
+	DelSMSCooldown(ctx context.Context, mobile string) error
+	IncrSMSDailyCount(ctx context.Context, subject string) (int64, error)
+	FindMerchantStaff(ctx context.Context, query interface{}) (*model.MerchantStaff, error)
+	ListMerchantStaff(ctx context.Context, query interface{}) ([]*model.MerchantStaff, error)
+	CreateMerchantStaff(ctx context.Context, data *model.MerchantStaff) error
+	UpdateMerchantStaff(ctx context.Context, query interface{}, data map[string]interface{}) error
+	SaveQRCodeNonce(ctx context.Context, nonce string, customerID uint64, expire time.Duration) error
+	GetQRCodeNonce(ctx context.Context, nonce string) (uint64, error)
+	DelQRCodeNonce(ctx context.Context, nonce string) (bool, error)
+	AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (bool, string, []byte, error)
+	SaveIdempotencyResponse(ctx context.Context, key, fingerprint string, data []byte, ttl time.Duration) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	FindCampaign(ctx context.Context, query interface{}) (*model.Campaign, error)
+	GetCurrentCampaign(ctx context.Context, date string) (*model.Campaign, error)
+	ListCampaign(ctx context.Context, query interface{}, pageNo, pageSize int) ([]*model.Campaign, int, error)
+	ListCampaignMerchant(ctx context.Context, campaignID uint64) ([]uint64, error)
+	CreateCampaign(ctx context.Context, campaign *model.Campaign) error
+	UpdateCampaign(ctx context.Context, campaign *model.Campaign) error
+	ListCheckinTime(ctx context.Context, customerID uint64, begin, end time.Time) ([]time.Time, error)
+	CountCheckin(ctx context.Context, customerID uint64) (int, error)
+	ListClaimedGift(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.ClaimedGift, error)
+	SummaryClaimedGift(ctx context.Context, customerID uint64) (uint64, uint64, error)
+	CountHelpCheckinMessage(ctx context.Context, helpCustomerID uint64, begin, end time.Time) (int, error)
+	CreateHelpInvitation(ctx context.Context, data *model.HelpInvitation) error
+	FindHelpInvitation(ctx context.Context, query interface{}, args ...interface{}) (*model.HelpInvitation, error)
+	CreateHelpInvitationEvent(ctx context.Context, data *model.HelpInvitationEvent) error
+	ListHelper(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.Helper, int, error)
+	GetInvitationStat(ctx context.Context, days []time.Time) ([]*model.InvitationStat, error)
+
+	FindReferral(ctx context.Context, query interface{}, args ...interface{}) (*model.Referral, error)
+	RewardReferral(ctx context.Context, referral *model.Referral, rewardType string, rewardNum uint64) error
+
+	PostPoints(ctx context.Context, data *model.PointsTx) error
+	RedeemPoints(ctx context.Context, data *model.PointsTx, item string, num uint64) error
+	ListPointsEntry(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.PointsEntry, int, error)
+
+	GetBadgeStat(ctx context.Context, customerID uint64) (*model.BadgeStat, error)
+	ListCustomerBadge(ctx context.Context, customerID uint64) ([]*model.CustomerBadge, error)
+	CreateCustomerBadge(ctx context.Context, data *model.CustomerBadge) error
+
+	SummaryWriteOffLog(ctx context.Context, merchantID uint64, days []time.Time) ([]*model.WriteOffSummary, error)
+	ListWriteOffLog(ctx context.Context, merchantID uint64, begin, end time.Time, pageNo, pageSize int) ([]*model.WriteOffLogItem, int, error)
+	FindWriteOffLog(ctx context.Context, query interface{}) (*model.WriteOffLog, error)
+	ReverseWriteOff(ctx context.Context, writeOffLog *model.WriteOffLog, windowStart, issuedAfter time.Time) error
+
+	CreateInboxMessage(ctx context.Context, data *model.InboxMessage) error
+	ListInboxMessage(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.InboxMessage, int, error)
+	CountUnreadInboxMessage(ctx context.Context, customerID uint64) (int, error)
+	ReadInboxMessage(ctx context.Context, customerID uint64) error
+	ListExpiringIssueRecords(ctx context.Context, begin, end time.Time, before int) ([]*model.IssueRecord, error)
+	CreateWelfareReminder(ctx context.Context, data *model.WelfareReminder) (bool, error)
+	DelWelfareReminder(ctx context.Context, issueRecordID uint64, before int) error
+
+	CreateGiftItem(ctx context.Context, data *model.GiftItem) error
+	FindGiftItem(ctx context.Context, query interface{}) (*model.GiftItem, error)
+	ListGiftItem(ctx context.Context, query interface{}, args ...interface{}) ([]*model.GiftItem, error)
+	UpdateGiftItem(ctx context.Context, data *model.GiftItem) error
+	DisableGiftItem(ctx context.Context, giftItemID uint64) error
+
+	CreateMerchantReview(ctx context.Context, data *model.MerchantReview, pending bool) error
+	FindMerchantReview(ctx context.Context, query interface{}) (*model.MerchantReview, error)
+	FindUnreviewedWriteOffLog(ctx context.Context, customerID, merchantID uint64, since time.Time) (*model.WriteOffLog, error)
+	ListMerchantReview(ctx context.Context, merchantID uint64, status string, pageNo, pageSize int) ([]*model.MerchantReviewItem, int, error)
+	ReplyMerchantReview(ctx context.Context, review *model.MerchantReview) error
+	ModerateMerchantReview(ctx context.Context, review *model.MerchantReview, status string, operatorID uint64) error
 }
 
 // dao dao.
@@ -91,6 +180,20 @@ func checkCacheError(err error) error {
 	return nil
 }
 
+// dayBucketSQL 按业务日分组的SQL表达式，值为 column 所在业务日在 days 中的下标
+// days 为升序排列的业务日开始时刻，最后一个元素为查询范围的结束时刻，由业务日历计算以正确处理时区和换日时间
+func dayBucketSQL(column string, days []time.Time) (string, []interface{}) {
+	var b strings.Builder
+	args := make([]interface{}, 0, len(days)-1)
+	b.WriteString("CASE")
+	for i := 1; i < len(days); i++ {
+		b.WriteString(" WHEN " + column + " < ? THEN " + strconv.Itoa(i-1))
+		args = append(args, days[i])
+	}
+	b.WriteString(" END")
+	return b.String(), args
+}
+
 // New new a dao and return.
 func New() Dao {
 	return &dao{
@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// ErrGiftItemStockTooLow 礼品库存总数小于已领取数量
+var ErrGiftItemStockTooLow = errors.New("礼品库存不能小于已领取数量")
+
+// CreateGiftItem 新增礼品
+func (d *dao) CreateGiftItem(ctx context.Context, data *model.GiftItem) error {
+	data.SetDefaultAttr()
+	data.Received = 0
+	return d.db.Create(data).Error
+}
+
+// FindGiftItem 获取礼品
+func (d *dao) FindGiftItem(ctx context.Context, query interface{}) (*model.GiftItem, error) {
+	var giftItem model.GiftItem
+	err := checkErr(d.db.Where(query).First(&giftItem).Error)
+	return &giftItem, err
+}
+
+// ListGiftItem 获取礼品列表，按排序值升序
+func (d *dao) ListGiftItem(ctx context.Context, query interface{}, args ...interface{}) ([]*model.GiftItem, error) {
+	var res []*model.GiftItem
+	err := checkErr(d.db.Where(query, args...).Order("sort, id").Find(&res).Error)
+	return res, err
+}
+
+// UpdateGiftItem 编辑礼品，已领取数量不会被覆盖，库存总数小于已领取数量时返回 ErrGiftItemStockTooLow
+func (d *dao) UpdateGiftItem(ctx context.Context, data *model.GiftItem) error {
+	db := d.db.Model(&model.GiftItem{}).Where("id = ? AND received <= ?", data.ID, data.TotalStock).Updates(map[string]interface{}{
+		"name":         data.Name,
+		"image":        data.Image,
+		"description":  data.Description,
+		"total_stock":  data.TotalStock,
+		"claim_num":    data.ClaimNum,
+		"checkin_days": data.CheckinDays,
+		"sort":         data.Sort,
+		"updated_at":   data.UpdatedAt,
+		"updated_by":   data.UpdatedBy,
+	})
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return ErrGiftItemStockTooLow
+	}
+	return nil
+}
+
+// DisableGiftItem 停用礼品，已领取的福利不受影响
+func (d *dao) DisableGiftItem(ctx context.Context, giftItemID uint64) error {
+	return d.db.Model(&model.GiftItem{}).Where("id = ?", giftItemID).Update("status", global.DeleteStatus).Error
+}
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"time"
 
 	"welfare-sign/internal/global"
 	"welfare-sign/internal/model"
@@ -24,3 +25,12 @@ func (d *dao) UpdateHelpCheckinMessage(ctx context.Context, customerID uint64) e
 	}
 	return nil
 }
+
+// CountHelpCheckinMessage 统计用户在 [begin, end) 内帮他人补签的次数
+func (d *dao) CountHelpCheckinMessage(ctx context.Context, helpCustomerID uint64, begin, end time.Time) (int, error) {
+	var count int
+	err := checkErr(d.db.Model(&model.HelpCheckinMessage{}).
+		Where("help_customer_id = ? AND created_at >= ? AND created_at < ?", helpCustomerID, begin, end).
+		Count(&count).Error)
+	return count, err
+}
@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"welfare-sign/internal/dao/mysql"
+	"welfare-sign/internal/model"
+)
+
+const (
+	listHelperSQL = `
+	SELECT m.help_customer_id AS customer_id, c.nickname, c.headimgurl, m.checkin_record_id, m.invitation_id, m.created_at AS helped_at
+FROM help_checkin_message AS m LEFT JOIN customer AS c ON c.id = m.help_customer_id
+WHERE m.customer_id = ? AND m.help_customer_id <> 0
+ORDER BY m.id DESC
+LIMIT ? OFFSET ?
+	`
+	getInvitationStatSQL = `
+	SELECT t.day, SUM(t.invitations) AS invitations, SUM(t.opens) AS opens, SUM(t.accepts) AS accepts FROM (
+	SELECT %[1]s AS day, COUNT(id) AS invitations, 0 AS opens, 0 AS accepts FROM help_invitation
+	WHERE created_at >= ? AND created_at < ?
+	GROUP BY day
+	UNION ALL
+	SELECT %[1]s AS day, 0 AS invitations,
+	COUNT(DISTINCT IF(event = 'open', CONCAT(invitation_id, '-', visitor_id), NULL)) AS opens,
+	COUNT(DISTINCT IF(event = 'accept', CONCAT(invitation_id, '-', visitor_id), NULL)) AS accepts
+	FROM help_invitation_event
+	WHERE created_at >= ? AND created_at < ?
+	GROUP BY day
+) AS t GROUP BY t.day ORDER BY t.day
+	`
+)
+
+// CreateHelpInvitation 新增补签邀请
+func (d *dao) CreateHelpInvitation(ctx context.Context, data *model.HelpInvitation) error {
+	data.SetDefaultAttr()
+	data.CreatedBy = data.CustomerID
+	return d.db.Create(data).Error
+}
+
+// FindHelpInvitation 获取补签邀请
+func (d *dao) FindHelpInvitation(ctx context.Context, query interface{}, args ...interface{}) (*model.HelpInvitation, error) {
+	var invitation model.HelpInvitation
+	err := checkErr(d.db.Where(query, args...).Order("id desc").First(&invitation).Error)
+	return &invitation, err
+}
+
+// CreateHelpInvitationEvent 记录补签邀请事件
+func (d *dao) CreateHelpInvitationEvent(ctx context.Context, data *model.HelpInvitationEvent) error {
+	data.SetDefaultAttr()
+	data.CreatedBy = data.VisitorID
+	return d.db.Create(data).Error
+}
+
+// ListHelper 获取帮客户补签过的好友，按帮签时间倒序
+// pageNo >= 1
+func (d *dao) ListHelper(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.Helper, int, error) {
+	var helpers []*model.Helper
+	total := 0
+	if err := d.db.Raw(listHelperSQL, customerID, pageSize, (pageNo-1)*pageSize).Scan(&helpers).Error; mysql.IsError(err) {
+		return helpers, total, err
+	}
+	if err := d.db.Model(&model.HelpCheckinMessage{}).Where("customer_id = ? AND help_customer_id <> 0", customerID).Count(&total).Error; mysql.IsError(err) {
+		return helpers, total, err
+	}
+	return helpers, total, nil
+}
+
+// GetInvitationStat 按业务日统计补签邀请的生成、打开及接受人数，days 为各业务日的开始时刻及结束时刻，Day 为业务日在 days 中的下标
+func (d *dao) GetInvitationStat(ctx context.Context, days []time.Time) ([]*model.InvitationStat, error) {
+	var stat []*model.InvitationStat
+	bucket, bucketArgs := dayBucketSQL("created_at", days)
+	begin, end := days[0], days[len(days)-1]
+	var args []interface{}
+	args = append(args, bucketArgs...)
+	args = append(args, begin, end)
+	args = append(args, bucketArgs...)
+	args = append(args, begin, end)
+	err := checkErr(d.db.Raw(fmt.Sprintf(getInvitationStatSQL, bucket), args...).Scan(&stat).Error)
+	return stat, err
+}
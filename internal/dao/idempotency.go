@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// KeyCacheIdempotencyPrefix 幂等键在缓存中的键前缀
+const KeyCacheIdempotencyPrefix = "welfare:idempotency:"
+
+// idempotencyProcessing 请求处理中的占位值，成功的响应为JSON，不会与之冲突
+const idempotencyProcessing = "processing"
+
+// idempotencySep 缓存值中请求参数摘要与响应的分隔符，摘要为十六进制字符串，不含该字符
+const idempotencySep = "|"
+
+// AcquireIdempotencyKey 占用幂等键，已被占用时返回false及占用时的请求参数摘要，已保存响应时一并返回
+func (d *dao) AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (bool, string, []byte, error) {
+	ok, err := d.cache.SetNX(KeyCacheIdempotencyPrefix+key, fingerprint+idempotencySep+idempotencyProcessing, lockTTL).Result()
+	if err != nil || ok {
+		return ok, fingerprint, nil, err
+	}
+	value, err := d.cache.Get(KeyCacheIdempotencyPrefix + key).Bytes()
+	if err == redis.Nil {
+		return false, fingerprint, nil, nil
+	}
+	if err != nil {
+		return false, "", nil, err
+	}
+	stored, data := fingerprint, value
+	if i := bytes.Index(value, []byte(idempotencySep)); i >= 0 {
+		stored, data = string(value[:i]), value[i+1:]
+	}
+	if string(data) == idempotencyProcessing {
+		return false, stored, nil, nil
+	}
+	return false, stored, data, nil
+}
+
+// SaveIdempotencyResponse 保存请求成功的响应及请求参数摘要
+func (d *dao) SaveIdempotencyResponse(ctx context.Context, key, fingerprint string, data []byte, ttl time.Duration) error {
+	value := append([]byte(fingerprint+idempotencySep), data...)
+	return d.cache.Set(KeyCacheIdempotencyPrefix+key, value, ttl).Err()
+}
+
+// ReleaseIdempotencyKey 释放幂等键
+func (d *dao) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	return checkCacheError(d.cache.Del(KeyCacheIdempotencyPrefix + key).Err())
+}
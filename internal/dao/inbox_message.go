@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"context"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// CreateInboxMessage 新增站内信
+func (d *dao) CreateInboxMessage(ctx context.Context, data *model.InboxMessage) error {
+	data.SetDefaultAttr()
+	data.IsRead = global.UnRead
+	return d.db.Create(data).Error
+}
+
+// ListInboxMessage 获取客户站内信，按时间倒序
+func (d *dao) ListInboxMessage(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.InboxMessage, int, error) {
+	var messages []*model.InboxMessage
+	total := 0
+	db := d.db.Model(&model.InboxMessage{}).Where("customer_id = ? AND status = ?", customerID, global.ActiveStatus)
+	if err := checkErr(db.Order("id DESC").Offset((pageNo - 1) * pageSize).Limit(pageSize).Find(&messages).Error); err != nil {
+		return messages, total, err
+	}
+	if err := checkErr(db.Count(&total).Error); err != nil {
+		return messages, total, err
+	}
+	return messages, total, nil
+}
+
+// CountUnreadInboxMessage 统计客户未读的站内信数
+func (d *dao) CountUnreadInboxMessage(ctx context.Context, customerID uint64) (int, error) {
+	var count int
+	err := checkErr(d.db.Model(&model.InboxMessage{}).Where(map[string]interface{}{
+		"customer_id": customerID,
+		"is_read":     global.UnRead,
+		"status":      global.ActiveStatus,
+	}).Count(&count).Error)
+	return count, err
+}
+
+// ReadInboxMessage 将客户的站内信全部标记为已读
+func (d *dao) ReadInboxMessage(ctx context.Context, customerID uint64) error {
+	return d.db.Model(&model.InboxMessage{}).Where(map[string]interface{}{
+		"customer_id": customerID,
+		"is_read":     global.UnRead,
+	}).Update("is_read", global.Readed).Error
+}
@@ -3,6 +3,9 @@ package dao
 import (
 	"context"
 
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
 	"welfare-sign/internal/global"
 	"welfare-sign/internal/model"
 	"welfare-sign/internal/pkg/log"
@@ -60,15 +63,44 @@ func (d *dao) ListIssueRecordDetail(ctx context.Context, query interface{}, args
 	return res, nil
 }
 
+// ErrMerchantStockNotEnough 商户剩余可领取的福利数量不足
+var ErrMerchantStockNotEnough = errors.New("该商家的福利已被领完了")
+
 // CreateIssueRecord create issue record
-func (d *dao) CreateIssueRecord(ctx context.Context, data model.IssueRecord, merchant *model.Merchant, mobile string) error {
+// 在事务中以条件更新预留库存，剩余数量不足 data.TotalReceive 时返回 ErrMerchantStockNotEnough
+// 指定礼品时只预留礼品库存，否则预留商户库存，礼品的领取不占用商户上的礼品数量
+// bonusGiftNum 为本次使用的邀请奖励额外福利数量，从客户的额外福利数量中扣除
+func (d *dao) CreateIssueRecord(ctx context.Context, data model.IssueRecord, mobile string, bonusGiftNum uint64) error {
 	tx := d.db.Begin()
 
+	var db *gorm.DB
+	if data.GiftItemID != 0 {
+		// 礼品属于正常营业的商户时才可领取
+		db = tx.Model(&model.GiftItem{}).
+			Where("id = ? AND merchant_id = ? AND status = ? AND received + ? <= total_stock", data.GiftItemID, data.MerchantID, global.ActiveStatus, data.TotalReceive).
+			Where("EXISTS (SELECT 1 FROM merchant WHERE merchant.id = gift_item.merchant_id AND merchant.status = ?)", global.ActiveStatus).
+			Update("received", gorm.Expr("received + ?", data.TotalReceive))
+	} else {
+		db = tx.Model(&model.Merchant{}).
+			Where("id = ? AND status = ? AND received + ? <= total_receive", data.MerchantID, global.ActiveStatus, data.TotalReceive).
+			Update("received", gorm.Expr("received + ?", data.TotalReceive))
+	}
+	if db.Error != nil {
+		log.Warn(ctx, "CreateIssueRecord.ReserveStock() error", zap.Error(db.Error))
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrMerchantStockNotEnough
+	}
+
 	var issueRecord model.IssueRecord
 	if err := checkErr(tx.Where(map[string]interface{}{
-		"merchant_id": data.MerchantID,
-		"customer_id": data.CustomerID,
-		"status":      global.ActiveStatus,
+		"merchant_id":  data.MerchantID,
+		"customer_id":  data.CustomerID,
+		"gift_item_id": data.GiftItemID,
+		"status":       global.ActiveStatus,
 	}).First(&issueRecord).Error); err != nil {
 		log.Warn(ctx, "CreateIssueRecord.FindIssueRecord() error", zap.Error(err))
 		tx.Rollback()
@@ -82,8 +114,8 @@ func (d *dao) CreateIssueRecord(ctx context.Context, data model.IssueRecord, mer
 			return err
 		}
 	} else { // 以前在该店领取过福利，则更新记录
-		issueRecord.TotalReceive += data.TotalReceive
-		if err := tx.Save(issueRecord).Error; err != nil {
+		// 只累加可领取数量，不覆盖同时发生的核销
+		if err := tx.Model(&issueRecord).Update("total_receive", gorm.Expr("total_receive + ?", data.TotalReceive)).Error; err != nil {
 			log.Warn(ctx, "CreateIssueRecord.SaveIssueRecord() error", zap.Error(err))
 			tx.Rollback()
 			return err
@@ -98,11 +130,6 @@ func (d *dao) CreateIssueRecord(ctx context.Context, data model.IssueRecord, mer
 		log.Warn(ctx, "CreateIssueRecord.Update() error", zap.Error(err))
 		return err
 	}
-	if err := tx.Save(merchant).Error; err != nil {
-		log.Warn(ctx, "CreateIssueRecord.SaveMerchant() error", zap.Error(err))
-		tx.Rollback()
-		return err
-	}
 	if mobile != "" {
 		if err := tx.Model(&model.Customer{}).Where(map[string]interface{}{
 			"status": global.ActiveStatus,
@@ -113,6 +140,15 @@ func (d *dao) CreateIssueRecord(ctx context.Context, data model.IssueRecord, mer
 			return err
 		}
 	}
+	if bonusGiftNum > 0 {
+		db := tx.Model(&model.Customer{}).Where("id = ? AND bonus_gift_num >= ?", data.CustomerID, bonusGiftNum).
+			Update("bonus_gift_num", gorm.Expr("bonus_gift_num - ?", bonusGiftNum))
+		if db.Error != nil || db.RowsAffected == 0 {
+			tx.Rollback()
+			log.Warn(ctx, "CreateIssueRecord.UseBonusGift() error", zap.Error(db.Error))
+			return errors.New("额外福利数量不足")
+		}
+	}
 
 	// 记录用户领取福利
 	var recordLog model.IssueRecordLog
@@ -120,6 +156,7 @@ func (d *dao) CreateIssueRecord(ctx context.Context, data model.IssueRecord, mer
 	recordLog.CustomerID = data.CustomerID
 	recordLog.MerchantID = data.MerchantID
 	recordLog.TotalReceive = data.TotalReceive
+	recordLog.GiftItemID = data.GiftItemID
 	if err := tx.Create(&recordLog).Error; err != nil {
 		log.Warn(ctx, "CreateIssueRecord.CreateIssueRecordLog() error", zap.Error(err))
 		tx.Rollback()
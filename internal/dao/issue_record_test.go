@@ -0,0 +1,153 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"welfare-sign/internal/model"
+)
+
+// testDSNEnv 并发测试使用的MySQL连接串，未设置时跳过需要数据库的测试
+const testDSNEnv = "WELFARE_TEST_MYSQL_DSN"
+
+func newTestDao(t *testing.T) *dao {
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", testDSNEnv)
+	}
+	db, err := gorm.Open("mysql", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SingularTable(true)
+	if err := db.AutoMigrate(&model.Merchant{}, &model.IssueRecord{}, &model.IssueRecordLog{}, &model.CheckinRecord{}, &model.Customer{}, &model.GiftItem{}, &model.MerchantReview{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &dao{db: db}
+}
+
+func TestCreateIssueRecordConcurrent(t *testing.T) {
+	d := newTestDao(t)
+	defer d.db.Close()
+	ctx := context.Background()
+
+	cases := []struct {
+		total, rewardNum uint64
+		claims           int
+	}{
+		{total: 10, rewardNum: 1, claims: 50},
+		{total: 10, rewardNum: 3, claims: 30},
+	}
+	for _, c := range cases {
+		merchant := model.Merchant{
+			StoreName:    "concurrency test",
+			ContactPhone: fmt.Sprintf("test-%d", time.Now().UnixNano()),
+			TotalReceive: c.total,
+			CheckinNum:   c.rewardNum,
+		}
+		merchant.SetDefaultAttr()
+		if err := d.db.Create(&merchant).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			succeeded uint64
+		)
+		for i := 0; i < c.claims; i++ {
+			wg.Add(1)
+			go func(customerID uint64) {
+				defer wg.Done()
+				err := d.CreateIssueRecord(ctx, model.IssueRecord{
+					MerchantID:   merchant.ID,
+					CustomerID:   customerID,
+					TotalReceive: c.rewardNum,
+				}, "", 0)
+				if err == ErrMerchantStockNotEnough {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}(merchant.ID*1000 + uint64(i) + 1)
+		}
+		wg.Wait()
+
+		var got model.Merchant
+		if err := d.db.Where("id = ?", merchant.ID).First(&got).Error; err != nil {
+			t.Fatal(err)
+		}
+		want := c.total / c.rewardNum
+		if succeeded != want || got.Received != want*c.rewardNum {
+			t.Errorf("total %d reward %d: succeeded %d received %d, want %d claims and %d received",
+				c.total, c.rewardNum, succeeded, got.Received, want, want*c.rewardNum)
+		}
+
+		d.db.Delete(model.IssueRecord{}, "merchant_id = ?", merchant.ID)
+		d.db.Delete(model.IssueRecordLog{}, "merchant_id = ?", merchant.ID)
+		d.db.Delete(model.Merchant{}, "id = ?", merchant.ID)
+	}
+}
+
+func TestCreateIssueRecordGiftItemConcurrent(t *testing.T) {
+	d := newTestDao(t)
+	defer d.db.Close()
+	ctx := context.Background()
+
+	merchant := model.Merchant{
+		StoreName:    "gift item concurrency test",
+		ContactPhone: fmt.Sprintf("test-%d", time.Now().UnixNano()),
+	}
+	merchant.SetDefaultAttr()
+	if err := d.db.Create(&merchant).Error; err != nil {
+		t.Fatal(err)
+	}
+	giftItem := model.GiftItem{MerchantID: merchant.ID, Name: "free drink", TotalStock: 10, ClaimNum: 2}
+	if err := d.CreateGiftItem(ctx, &giftItem); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(customerID uint64) {
+			defer wg.Done()
+			err := d.CreateIssueRecord(ctx, model.IssueRecord{
+				MerchantID:   merchant.ID,
+				CustomerID:   customerID,
+				GiftItemID:   giftItem.ID,
+				TotalReceive: giftItem.ClaimNum,
+			}, "", 0)
+			if err != nil && err != ErrMerchantStockNotEnough {
+				t.Error(err)
+			}
+		}(merchant.ID*1000 + uint64(i) + 1)
+	}
+	wg.Wait()
+
+	got, err := d.FindGiftItem(ctx, map[string]interface{}{"id": giftItem.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims int
+	d.db.Model(&model.IssueRecord{}).Where("gift_item_id = ?", giftItem.ID).Count(&claims)
+	if got.Received != giftItem.TotalStock || claims != 5 {
+		t.Errorf("received %d claims %d, want %d received and 5 claims", got.Received, claims, giftItem.TotalStock)
+	}
+
+	d.db.Delete(model.IssueRecord{}, "merchant_id = ?", merchant.ID)
+	d.db.Delete(model.IssueRecordLog{}, "merchant_id = ?", merchant.ID)
+	d.db.Delete(model.GiftItem{}, "id = ?", giftItem.ID)
+	d.db.Delete(model.Merchant{}, "id = ?", merchant.ID)
+}
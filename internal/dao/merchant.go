@@ -3,27 +3,50 @@ package dao
 import (
 	"context"
 
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
 	"welfare-sign/internal/dao/mysql"
 	"welfare-sign/internal/global"
 	"welfare-sign/internal/model"
 )
 
 const (
-	nearMerchantSQL = `SELECT
-*, (
+	// merchantDistanceSQL 商户与指定位置的距离，单位公里，参数依次为纬度、经度、纬度
+	merchantDistanceSQL = `
 	6371 * acos (
 	cos ( radians(?) )
 	* cos( radians( lat ) )
 	* cos( radians( lon ) - radians(?) )
 	+ sin ( radians(?) )
 	* sin( radians( lat ) )
-  )
+  )`
+	nearMerchantSQL = `SELECT
+*, (` + merchantDistanceSQL + `
 ) AS distance
 FROM merchant
-WHERE received + checkin_num <= total_receive AND status = 'A' 
+WHERE status = 'A' AND (received + checkin_num <= total_receive OR EXISTS (
+	SELECT 1 FROM gift_item AS g WHERE g.merchant_id = merchant.id AND g.status = 'A' AND g.received + g.claim_num <= g.total_stock
+))
 HAVING distance <= ?
 ORDER BY distance ASC
 LIMIT ?;`
+	nearestMerchantSQL = `SELECT
+*, (` + merchantDistanceSQL + `
+) AS distance
+FROM merchant
+WHERE status = 'A'
+HAVING distance <= ?
+ORDER BY distance ASC
+LIMIT 1;`
+	nearestMerchantInSQL = `SELECT
+*, (` + merchantDistanceSQL + `
+) AS distance
+FROM merchant
+WHERE status = 'A' AND id IN (?)
+HAVING distance <= ?
+ORDER BY distance ASC
+LIMIT 1;`
 	getRoundMerchantPosterSQL = `
 	SELECT *
 FROM merchant AS t1 JOIN (SELECT ROUND(RAND() * ((SELECT MAX(id) FROM merchant)-(SELECT MIN(id) FROM merchant))+(SELECT MIN(id) FROM merchant)) AS id) AS t2
@@ -60,23 +83,36 @@ func (d *dao) FindMerchant(ctx context.Context, query interface{}) (*model.Merch
 	return &merchant, err
 }
 
-// EcecWriteOff 执行核销
-func (d *dao) EcecWriteOff(ctx context.Context, merchantID, customerID, hasRece, writeOffNum uint64) error {
+// ErrWriteOffNotEnough 福利剩余可核销数量不足
+var ErrWriteOffNotEnough = errors.New("核销数目不正确")
+
+// EcecWriteOff 执行核销，在同一事务中累加已核销数量并记录核销日志
+// 福利剩余可核销数量不足 writeOffLog.Num 时返回 ErrWriteOffNotEnough
+func (d *dao) EcecWriteOff(ctx context.Context, writeOffLog *model.WriteOffLog) error {
 	tx := d.db.Begin()
-	if err := tx.Model(&model.IssueRecord{}).Where(map[string]interface{}{
-		"merchant_id": merchantID,
-		"customer_id": customerID,
-		"status":      global.ActiveStatus,
-	}).Updates(map[string]interface{}{"received": hasRece}).Error; err != nil {
+	db := tx.Model(&model.IssueRecord{}).
+		Where("id = ? AND status = ? AND received + ? <= total_receive", writeOffLog.IssueRecordID, global.ActiveStatus, writeOffLog.Num).
+		Update("received", gorm.Expr("received + ?", writeOffLog.Num))
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrWriteOffNotEnough
+	}
+
+	if err := tx.Model(&model.Merchant{}).Where("id = ?", writeOffLog.MerchantID).
+		Update("has_write_off_num", gorm.Expr("has_write_off_num + ?", writeOffLog.Num)).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	if err := tx.Model(&model.Merchant{}).Where("id = ?", merchantID).Updates(map[string]interface{}{
-		"has_write_off_num": writeOffNum,
-	}).Error; err != nil {
+	writeOffLog.SetDefaultAttr()
+	writeOffLog.CreatedBy = writeOffLog.StaffID
+	if err := tx.Create(writeOffLog).Error; err != nil {
 		tx.Rollback()
-		return nil
+		return err
 	}
 	tx.Commit()
 	return nil
@@ -87,7 +123,7 @@ type nears struct {
 	Distance float64 `json:"distance"`
 }
 
-// NearMerchant 附近的商家
+// NearMerchant 附近还有福利可领取的商家，商户本身或任一礼品有剩余库存即可
 func (d *dao) NearMerchant(ctx context.Context, data *model.NearMerchantVO) ([]*model.Merchant, error) {
 	var (
 		merchants []*model.Merchant
@@ -100,9 +136,24 @@ func (d *dao) NearMerchant(ctx context.Context, data *model.NearMerchantVO) ([]*
 	return merchants, nil
 }
 
+// FindNearestMerchant 获取指定范围内最近的正常商户，merchantIDs不为空时只在其中查找，没有时返回的商户ID为0
+func (d *dao) FindNearestMerchant(ctx context.Context, lat, lon, distance float64, merchantIDs []uint64) (*model.Merchant, error) {
+	var (
+		merchant model.Merchant
+		err      error
+	)
+	if len(merchantIDs) == 0 {
+		err = d.db.Raw(nearestMerchantSQL, lat, lon, lat, distance).Scan(&merchant).Error
+	} else {
+		err = d.db.Raw(nearestMerchantInSQL, lat, lon, lat, merchantIDs, distance).Scan(&merchant).Error
+	}
+	return &merchant, checkErr(err)
+}
+
 // UpdateMerchant 更新商户信息
+// 已领取、已核销、已失效数量在领取、核销、失效时原子更新，这里不覆盖
 func (d *dao) UpdateMerchant(ctx context.Context, data *model.Merchant) error {
-	return d.db.Save(data).Error
+	return d.db.Omit("received", "has_write_off_num", "has_failure", "rating_count", "rating_total", "rating").Save(data).Error
 }
 
 // DeleteMerchant 删除商户信息
@@ -0,0 +1,185 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/dao/mysql"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// ErrReviewModerated 评价已被其他人审核
+var ErrReviewModerated = errors.New("评价状态已变更，请刷新后重试")
+
+// ErrReviewExists 该次核销已评价
+var ErrReviewExists = errors.New("该次核销已评价")
+
+// updateMerchantRatingSQL 调整商户的评价数和评分总和并重新计算平均评分，MySQL 按从左到右的顺序使用更新后的值
+const updateMerchantRatingSQL = `UPDATE merchant SET
+rating_count = rating_count + ?,
+rating_total = rating_total + ?,
+rating = IF(rating_count = 0, 0, ROUND(rating_total / rating_count, 1))
+WHERE id = ?`
+
+const listMerchantReviewSQL = `
+	SELECT r.*, c.nickname, c.headimgurl, g.name AS gift_item_name FROM merchant_review AS r
+LEFT JOIN customer AS c ON c.id = r.customer_id
+LEFT JOIN gift_item AS g ON g.id = r.gift_item_id
+	`
+
+const findUnreviewedWriteOffLogSQL = `
+	SELECT w.* FROM write_off_log AS w
+LEFT JOIN merchant_review AS r ON r.write_off_log_id = w.id
+WHERE w.customer_id = ? AND w.merchant_id = ? AND w.reversed_at IS NULL AND w.created_at >= ? AND r.id IS NULL
+ORDER BY w.id DESC
+LIMIT 1
+	`
+
+// CreateMerchantReview 新增评价，pending 为 true 时需审核后才展示，否则在同一事务中计入商户评分
+// 同一核销已有评价时返回 ErrReviewExists
+func (d *dao) CreateMerchantReview(ctx context.Context, data *model.MerchantReview, pending bool) error {
+	data.SetDefaultAttr()
+	if pending {
+		data.Status = global.InactiveStatus
+	}
+	data.PhotoNames = strings.Join(data.Photos, ",")
+
+	tx := d.db.Begin()
+	if err := tx.Create(data).Error; err != nil {
+		tx.Rollback()
+		if mysql.IsDuplicateError(err) {
+			return ErrReviewExists
+		}
+		return err
+	}
+	if !pending {
+		if err := updateMerchantRating(tx, data.MerchantID, 1, int64(data.Rating)); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// FindMerchantReview 获取评价
+func (d *dao) FindMerchantReview(ctx context.Context, query interface{}) (*model.MerchantReview, error) {
+	var review model.MerchantReview
+	err := checkErr(d.db.Where(query).First(&review).Error)
+	review.Photos = splitPhotos(review.PhotoNames)
+	return &review, err
+}
+
+// ListMerchantReview 获取评价列表，按时间倒序，merchantID 为0时不限商户，status 为空时不限状态
+// pageNo >= 1
+func (d *dao) ListMerchantReview(ctx context.Context, merchantID uint64, status string, pageNo, pageSize int) ([]*model.MerchantReviewItem, int, error) {
+	var reviews []*model.MerchantReviewItem
+	total := 0
+	var where []string
+	var args []interface{}
+	if merchantID != 0 {
+		where = append(where, "r.merchant_id = ?")
+		args = append(args, merchantID)
+	}
+	if status != "" {
+		where = append(where, "r.status = ?")
+		args = append(args, status)
+	}
+	cond := "1 = 1"
+	if len(where) > 0 {
+		cond = strings.Join(where, " AND ")
+	}
+
+	sql := listMerchantReviewSQL + " WHERE " + cond + " ORDER BY r.id DESC LIMIT ? OFFSET ?"
+	if err := d.db.Raw(sql, append(args, pageSize, (pageNo-1)*pageSize)...).Scan(&reviews).Error; mysql.IsError(err) {
+		return reviews, total, err
+	}
+	if err := d.db.Table("merchant_review AS r").Where(cond, args...).Count(&total).Error; mysql.IsError(err) {
+		return reviews, total, err
+	}
+	for _, review := range reviews {
+		review.Photos = splitPhotos(review.PhotoNames)
+	}
+	return reviews, total, nil
+}
+
+// FindUnreviewedWriteOffLog 获取客户在商户 since 之后最近一次未撤销且未评价的核销记录
+func (d *dao) FindUnreviewedWriteOffLog(ctx context.Context, customerID, merchantID uint64, since time.Time) (*model.WriteOffLog, error) {
+	var writeOffLog model.WriteOffLog
+	err := checkErr(d.db.Raw(findUnreviewedWriteOffLogSQL, customerID, merchantID, since).Scan(&writeOffLog).Error)
+	return &writeOffLog, err
+}
+
+// ReplyMerchantReview 商户回复评价，重复回复时覆盖之前的回复
+func (d *dao) ReplyMerchantReview(ctx context.Context, review *model.MerchantReview) error {
+	now := time.Now()
+	review.RepliedAt = &now
+	return d.db.Model(&model.MerchantReview{}).Where("id = ? AND merchant_id = ?", review.ID, review.MerchantID).Updates(map[string]interface{}{
+		"reply":      review.Reply,
+		"replied_at": now,
+		"replied_by": review.RepliedBy,
+		"updated_at": now,
+	}).Error
+}
+
+// ModerateMerchantReview 审核评价，在同一事务中修改评价状态并调整商户评分
+// 评价状态已不是 review.Status 或通过的评价对应的核销已撤销时返回 ErrReviewModerated
+func (d *dao) ModerateMerchantReview(ctx context.Context, review *model.MerchantReview, status string, operatorID uint64) error {
+	tx := d.db.Begin()
+	query := tx.Model(&model.MerchantReview{}).Where("id = ? AND status = ?", review.ID, review.Status)
+	if status == global.ActiveStatus {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM write_off_log WHERE id = ? AND reversed_at IS NOT NULL)", review.WriteOffLogID)
+	}
+	db := query.Updates(map[string]interface{}{
+		"status":     status,
+		"updated_at": time.Now(),
+		"updated_by": operatorID,
+	})
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrReviewModerated
+	}
+
+	var count, rating int64
+	if status == global.ActiveStatus {
+		count, rating = 1, int64(review.Rating)
+	} else if review.Status == global.ActiveStatus {
+		count, rating = -1, -int64(review.Rating)
+	}
+	if count != 0 {
+		if err := updateMerchantRating(tx, review.MerchantID, count, rating); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	review.Status = status
+	return tx.Commit().Error
+}
+
+// updateMerchantRating 调整商户评分
+func updateMerchantRating(tx *gorm.DB, merchantID uint64, count, rating int64) error {
+	db := tx.Exec(updateMerchantRatingSQL, count, rating, merchantID)
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return errors.New("商户不存在")
+	}
+	return nil
+}
+
+// splitPhotos 拆分逗号分隔的图片文件名
+func splitPhotos(photoNames string) []string {
+	if photoNames == "" {
+		return []string{}
+	}
+	return strings.Split(photoNames, ",")
+}
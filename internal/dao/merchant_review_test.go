@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// TestMerchantReviewRatingConcurrent 并发评价及审核后商户评分与展示中的评价一致
+func TestMerchantReviewRatingConcurrent(t *testing.T) {
+	d := newTestDao(t)
+	defer d.db.Close()
+	ctx := context.Background()
+
+	merchant := model.Merchant{
+		StoreName:    "review concurrency test",
+		ContactPhone: fmt.Sprintf("test-%d", time.Now().UnixNano()),
+	}
+	merchant.SetDefaultAttr()
+	if err := d.db.Create(&merchant).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	reviews := make([]*model.MerchantReview, 20)
+	var wg sync.WaitGroup
+	for i := range reviews {
+		reviews[i] = &model.MerchantReview{
+			MerchantID:    merchant.ID,
+			CustomerID:    uint64(i) + 1,
+			WriteOffLogID: merchant.ID*1000 + uint64(i) + 1,
+			Rating:        uint64(i%5) + 1,
+		}
+		wg.Add(1)
+		go func(review *model.MerchantReview) {
+			defer wg.Done()
+			if err := d.CreateMerchantReview(ctx, review, false); err != nil {
+				t.Error(err)
+			}
+		}(reviews[i])
+	}
+	wg.Wait()
+
+	// 同一评价并发隐藏只扣除一次
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(review model.MerchantReview) {
+			defer wg.Done()
+			if err := d.ModerateMerchantReview(ctx, &review, global.InactiveStatus, 0); err != nil && err != ErrReviewModerated {
+				t.Error(err)
+			}
+		}(*reviews[i%5])
+	}
+	wg.Wait()
+
+	var got model.Merchant
+	if err := d.db.First(&got, merchant.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	// 隐藏了评分为1-5的各一条，剩余15条评分总和为45
+	if got.RatingCount != 15 || got.RatingTotal != 45 || got.Rating != 3 {
+		t.Errorf("rating count %d total %d avg %v, want 15, 45, 3", got.RatingCount, got.RatingTotal, got.Rating)
+	}
+
+	d.db.Delete(model.MerchantReview{}, "merchant_id = ?", merchant.ID)
+	d.db.Delete(model.Merchant{}, "id = ?", merchant.ID)
+}
@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/dao/mysql"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// ErrStaffMobileExists 手机号已是其他未删除的员工
+var ErrStaffMobileExists = errors.New("该手机号已是其他员工")
+
+// FindMerchantStaff 获取商户员工
+func (d *dao) FindMerchantStaff(ctx context.Context, query interface{}) (*model.MerchantStaff, error) {
+	var staff model.MerchantStaff
+	err := checkErr(d.db.Where(query).First(&staff).Error)
+	return &staff, err
+}
+
+// ListMerchantStaff 获取商户员工列表
+func (d *dao) ListMerchantStaff(ctx context.Context, query interface{}) ([]*model.MerchantStaff, error) {
+	var staffs []*model.MerchantStaff
+	err := checkErr(d.db.Where(query).Order("id asc").Find(&staffs).Error)
+	return staffs, err
+}
+
+// CreateMerchantStaff 新增商户员工，手机号已是其他未删除的员工时返回 ErrStaffMobileExists
+func (d *dao) CreateMerchantStaff(ctx context.Context, data *model.MerchantStaff) error {
+	data.SetDefaultAttr()
+	mobile := data.Mobile
+	data.MobileKey = &mobile
+	err := d.db.Create(data).Error
+	if mysql.IsDuplicateError(err) {
+		return ErrStaffMobileExists
+	}
+	return err
+}
+
+// UpdateMerchantStaff 更新商户员工，修改手机号或删除时同步维护 mobile_key
+// 新手机号已是其他未删除的员工时返回 ErrStaffMobileExists
+func (d *dao) UpdateMerchantStaff(ctx context.Context, query interface{}, data map[string]interface{}) error {
+	data["updated_at"] = time.Now()
+	if mobile, ok := data["mobile"]; ok {
+		data["mobile_key"] = mobile
+	}
+	if data["status"] == global.DeleteStatus {
+		data["mobile_key"] = nil
+	}
+	err := d.db.Model(&model.MerchantStaff{}).Where(query).Updates(data).Error
+	if mysql.IsDuplicateError(err) {
+		return ErrStaffMobileExists
+	}
+	return err
+}
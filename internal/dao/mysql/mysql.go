@@ -3,6 +3,7 @@ package mysql
 import (
 	"database/sql"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql" // mysql driver
 	"github.com/pkg/errors"
@@ -20,7 +21,7 @@ func New() *gorm.DB {
 	}
 	db.SingularTable(true)
 	db = db.LogMode(true)
-	db.AutoMigrate(&model.CheckinRecord{}, &model.Customer{}, &model.IssueRecord{}, &model.Merchant{}, &model.User{}, &model.WXPayRecord{}, &model.HelpCheckinMessage{}, &model.IssueRecordLog{}, &model.LuckyNumberRecord{}, &model.CompositeIndex{}, &model.CheckinRecordLog{})
+	db.AutoMigrate(&model.CheckinRecord{}, &model.Customer{}, &model.IssueRecord{}, &model.Merchant{}, &model.User{}, &model.WXPayRecord{}, &model.HelpCheckinMessage{}, &model.IssueRecordLog{}, &model.LuckyNumberRecord{}, &model.CompositeIndex{}, &model.CheckinRecordLog{}, &model.Role{}, &model.RolePermission{}, &model.AuditLog{}, &model.MerchantStaff{}, &model.WriteOffLog{}, &model.Campaign{}, &model.CampaignMerchant{}, &model.HelpInvitation{}, &model.HelpInvitationEvent{}, &model.Referral{}, &model.PointsEntry{}, &model.CustomerBadge{}, &model.InboxMessage{}, &model.WelfareReminder{}, &model.GiftItem{}, &model.MerchantReview{})
 	return db
 }
 
@@ -28,3 +29,9 @@ func New() *gorm.DB {
 func IsError(err error) bool {
 	return err != nil && err != gorm.ErrRecordNotFound && err != sql.ErrNoRows
 }
+
+// IsDuplicateError 是否是唯一索引冲突
+func IsDuplicateError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*mysqldriver.MySQLError)
+	return ok && mysqlErr.Number == 1062
+}
@@ -0,0 +1,106 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/dao/mysql"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// ErrPointsNotEnough 客户积分余额不足
+var ErrPointsNotEnough = errors.New("积分不足")
+
+// PostPoints 记一笔积分交易，相同交易号已记账时不重复记账
+func (d *dao) PostPoints(ctx context.Context, data *model.PointsTx) error {
+	tx := d.db.Begin()
+	if err := postPoints(tx, data); err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// RedeemPoints 积分兑换，扣减积分的同时增加客户的免费补签次数或额外福利数量
+func (d *dao) RedeemPoints(ctx context.Context, data *model.PointsTx, item string, num uint64) error {
+	column := "supplement_credits"
+	if item == global.PointsRedeemGift {
+		column = "bonus_gift_num"
+	}
+
+	tx := d.db.Begin()
+	if err := postPoints(tx, data); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.Customer{}).Where("id = ?", data.CustomerID).
+		Update(column, gorm.Expr(column+" + ?", num)).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// ListPointsEntry 获取客户积分流水，按时间倒序
+// pageNo >= 1
+func (d *dao) ListPointsEntry(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.PointsEntry, int, error) {
+	var entries []*model.PointsEntry
+	total := 0
+	query := map[string]interface{}{"account": model.CustomerPointsAccount(customerID)}
+	err := d.db.Where(query).Limit(pageSize).Offset((pageNo - 1) * pageSize).Order("id desc").Find(&entries).Error
+	if mysql.IsError(err) {
+		return entries, total, err
+	}
+	if err := d.db.Model(&model.PointsEntry{}).Where(query).Count(&total).Error; mysql.IsError(err) {
+		return entries, total, err
+	}
+	return entries, total, nil
+}
+
+// postPoints 在事务中记账：更新客户积分余额，并在客户账户和系统账户各记一条流水
+func postPoints(tx *gorm.DB, data *model.PointsTx) error {
+	count := 0
+	if err := tx.Model(&model.PointsEntry{}).Where("tx_id = ?", data.TxID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	db := tx.Model(&model.Customer{}).Where("id = ? AND points + ? >= 0", data.CustomerID, data.Amount).
+		Update("points", gorm.Expr("points + ?", data.Amount))
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return ErrPointsNotEnough
+	}
+	var customer model.Customer
+	if err := tx.Select("points").Where("id = ?", data.CustomerID).First(&customer).Error; err != nil {
+		return err
+	}
+
+	entries := []*model.PointsEntry{
+		{Account: model.CustomerPointsAccount(data.CustomerID), Amount: data.Amount, Balance: customer.Points},
+		{Account: model.SystemPointsAccount(data.Reason), Amount: -data.Amount},
+	}
+	for _, entry := range entries {
+		entry.SetDefaultAttr()
+		entry.CreatedBy = data.OperatorID
+		entry.UpdatedBy = data.OperatorID
+		entry.TxID = data.TxID
+		entry.CustomerID = data.CustomerID
+		entry.Reason = data.Reason
+		entry.RefID = data.RefID
+		entry.Remark = data.Remark
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
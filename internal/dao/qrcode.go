@@ -0,0 +1,26 @@
+package dao
+
+import (
+	"context"
+	"time"
+)
+
+// KeyCacheQRCodeNoncePrefix 核销二维码一次性随机数在缓存中的键前缀
+const KeyCacheQRCodeNoncePrefix = "welfare:qrcode:nonce:"
+
+// SaveQRCodeNonce 保存核销二维码随机数，值为客户ID
+func (d *dao) SaveQRCodeNonce(ctx context.Context, nonce string, customerID uint64, expire time.Duration) error {
+	return d.cache.Set(KeyCacheQRCodeNoncePrefix+nonce, customerID, expire).Err()
+}
+
+// GetQRCodeNonce 获取核销二维码随机数对应的客户ID，不存在时返回0
+func (d *dao) GetQRCodeNonce(ctx context.Context, nonce string) (uint64, error) {
+	customerID, err := d.cache.Get(KeyCacheQRCodeNoncePrefix + nonce).Uint64()
+	return customerID, checkCacheError(err)
+}
+
+// DelQRCodeNonce 使用核销二维码随机数，返回是否删除成功，并发使用时只有一个能成功
+func (d *dao) DelQRCodeNonce(ctx context.Context, nonce string) (bool, error) {
+	n, err := d.cache.Del(KeyCacheQRCodeNoncePrefix + nonce).Result()
+	return n == 1, err
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// ErrReferralRewarded 邀请奖励已发放过
+var ErrReferralRewarded = errors.New("邀请奖励已发放")
+
+// FindReferral 查询邀请注册记录
+func (d *dao) FindReferral(ctx context.Context, query interface{}, args ...interface{}) (*model.Referral, error) {
+	var referral model.Referral
+	err := checkErr(d.db.Where(query, args...).First(&referral).Error)
+	return &referral, err
+}
+
+// RewardReferral 发放邀请奖励，同一条邀请记录只会发放一次
+func (d *dao) RewardReferral(ctx context.Context, referral *model.Referral, rewardType string, rewardNum uint64) error {
+	now := time.Now()
+	tx := d.db.Begin()
+
+	db := tx.Model(&model.Referral{}).Where("id = ? AND rewarded_at IS NULL", referral.ID).Updates(map[string]interface{}{
+		"reward_type": rewardType,
+		"reward_num":  rewardNum,
+		"rewarded_at": now,
+		"updated_at":  now,
+	})
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrReferralRewarded
+	}
+
+	column := "supplement_credits"
+	if rewardType == global.ReferralRewardGift {
+		column = "bonus_gift_num"
+	}
+	if err := tx.Model(&model.Customer{}).Where("id = ?", referral.ReferrerID).
+		Update(column, gorm.Expr(column+" + ?", rewardNum)).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	tx.Commit()
+	referral.RewardType, referral.RewardNum, referral.RewardedAt = rewardType, rewardNum, &now
+	return nil
+}
@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"context"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+// FindRole 获取角色
+func (d *dao) FindRole(ctx context.Context, query interface{}) (*model.Role, error) {
+	var role model.Role
+	err := checkErr(d.db.Where(query).First(&role).Error)
+	return &role, err
+}
+
+// ListRole 获取有效的角色列表，包含角色权限
+func (d *dao) ListRole(ctx context.Context) ([]*model.Role, error) {
+	var roles []*model.Role
+	if err := checkErr(d.db.Where("status = ?", global.ActiveStatus).Order("id asc").Find(&roles).Error); err != nil {
+		return roles, err
+	}
+	for _, role := range roles {
+		permissions, err := d.ListRolePermission(ctx, role.ID)
+		if err != nil {
+			return roles, err
+		}
+		role.Permissions = permissions
+	}
+	return roles, nil
+}
+
+// CreateRole 创建角色及其权限
+func (d *dao) CreateRole(ctx context.Context, role *model.Role, permissions []string) error {
+	tx := d.db.Begin()
+	role.SetDefaultAttr()
+	if err := tx.Create(role).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, perm := range permissions {
+		data := model.RolePermission{
+			RoleID:     role.ID,
+			Permission: perm,
+		}
+		data.SetDefaultAttr()
+		if err := tx.Create(&data).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// ListRolePermission 获取角色的权限列表
+func (d *dao) ListRolePermission(ctx context.Context, roleID uint64) ([]string, error) {
+	var permissions []string
+	err := checkErr(d.db.Model(&model.RolePermission{}).Where(map[string]interface{}{
+		"role_id": roleID,
+		"status":  global.ActiveStatus,
+	}).Pluck("permission", &permissions).Error)
+	return permissions, err
+}
+
+// CreateRolePermission 为角色新增权限
+func (d *dao) CreateRolePermission(ctx context.Context, roleID uint64, permissions []string) error {
+	tx := d.db.Begin()
+	for _, perm := range permissions {
+		data := model.RolePermission{
+			RoleID:     roleID,
+			Permission: perm,
+		}
+		data.SetDefaultAttr()
+		if err := tx.Create(&data).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,106 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"welfare-sign/internal/model"
+)
+
+// token相关缓存键前缀
+const (
+	KeyCacheRefreshTokenPrefix   = "welfare:token:refresh:"   // refresh token对应的会话信息
+	KeyCacheAccountTokensPrefix  = "welfare:token:account:"   // 账号下所有的refresh token
+	KeyCacheRevokedTokenPrefix   = "welfare:token:revoked:"   // 已注销的access token
+	KeyCacheRevokedAccountPrefix = "welfare:account:revoked:" // 账号注销token的时间点
+)
+
+func accountKey(role string, uid uint64) string {
+	return role + ":" + strconv.FormatUint(uid, 10)
+}
+
+// SaveRefreshToken 保存refresh token对应的会话信息
+func (d *dao) SaveRefreshToken(ctx context.Context, token string, session *model.TokenSession, expire time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	accountTokens := KeyCacheAccountTokensPrefix + accountKey(session.Role, session.UID)
+	pipe := d.cache.TxPipeline()
+	pipe.Set(KeyCacheRefreshTokenPrefix+token, data, expire)
+	pipe.SAdd(accountTokens, token)
+	pipe.Expire(accountTokens, expire)
+	_, err = pipe.Exec()
+	return err
+}
+
+// GetRefreshToken 获取refresh token对应的会话信息，不存在时返回nil
+func (d *dao) GetRefreshToken(ctx context.Context, token string) (*model.TokenSession, error) {
+	data, err := d.cache.Get(KeyCacheRefreshTokenPrefix + token).Bytes()
+	if err != nil {
+		return nil, checkCacheError(err)
+	}
+	var session model.TokenSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// DelRefreshToken 删除refresh token，token已被删除时返回false，可用于保证refresh token只能使用一次
+func (d *dao) DelRefreshToken(ctx context.Context, token string, session *model.TokenSession) (bool, error) {
+	pipe := d.cache.TxPipeline()
+	del := pipe.Del(KeyCacheRefreshTokenPrefix + token)
+	pipe.SRem(KeyCacheAccountTokensPrefix+accountKey(session.Role, session.UID), token)
+	if _, err := pipe.Exec(); checkCacheError(err) != nil {
+		return false, err
+	}
+	return del.Val() > 0, nil
+}
+
+// RevokeToken 注销单个access token，expire为该token剩余的有效期
+func (d *dao) RevokeToken(ctx context.Context, jti string, expire time.Duration) error {
+	if expire <= 0 {
+		return nil
+	}
+	return d.cache.Set(KeyCacheRevokedTokenPrefix+jti, 1, expire).Err()
+}
+
+// RevokeAccount 注销账号在此刻之前签发的所有token
+// accessExpire 为access token的有效期，超过该时间后之前签发的access token已自然失效
+func (d *dao) RevokeAccount(ctx context.Context, role string, uid uint64, accessExpire time.Duration) error {
+	key := accountKey(role, uid)
+	tokens, err := d.cache.SMembers(KeyCacheAccountTokensPrefix + key).Result()
+	if checkCacheError(err) != nil {
+		return err
+	}
+
+	pipe := d.cache.TxPipeline()
+	pipe.Set(KeyCacheRevokedAccountPrefix+key, time.Now().Unix(), accessExpire)
+	for _, token := range tokens {
+		pipe.Del(KeyCacheRefreshTokenPrefix + token)
+	}
+	pipe.Del(KeyCacheAccountTokensPrefix + key)
+	_, err = pipe.Exec()
+	return err
+}
+
+// IsTokenRevoked access token是否已被注销
+func (d *dao) IsTokenRevoked(ctx context.Context, jti, role string, uid uint64, issuedAt int64) (bool, error) {
+	if jti != "" {
+		n, err := d.cache.Exists(KeyCacheRevokedTokenPrefix + jti).Result()
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+	revokedAt, err := d.cache.Get(KeyCacheRevokedAccountPrefix + accountKey(role, uid)).Int64()
+	if err != nil {
+		return false, checkCacheError(err)
+	}
+	return issuedAt <= revokedAt, nil
+}
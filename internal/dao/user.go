@@ -2,31 +2,88 @@ package dao
 
 import (
 	"context"
+	"time"
 
 	"welfare-sign/internal/model"
 )
 
+// KeyCacheUserLoginFailPrefix 后台用户登录失败次数在缓存中的键前缀
+const KeyCacheUserLoginFailPrefix = "welfare:user:login_fail:"
+
+// KeyCacheUserAccountLoginFailPrefix 后台用户在所有IP累计登录失败次数在缓存中的键前缀
+const KeyCacheUserAccountLoginFailPrefix = "welfare:user:account_login_fail:"
+
 const (
 	getTmpCheckinRecordListSQL = `
-	SELECT *FROM checkin_record WHERE status <> 'X' AND DATE(need_checkin_time) <= CURRENT_DATE
+	SELECT *FROM checkin_record WHERE status <> 'X' AND need_checkin_time < ?
 	`
 )
 
 // FindUser find user
 func (d *dao) FindUser(ctx context.Context, query interface{}) (*model.User, error) {
 	var user model.User
-	err := d.db.Where(query).Find(&user).Error
+	err := checkErr(d.db.Where(query).First(&user).Error)
 	return &user, err
 }
 
-// GetTmpCheckinRecordList 获取全部用户签到列表
+// CreateUser 新增后台用户
+func (d *dao) CreateUser(ctx context.Context, data model.User) error {
+	data.SetDefaultAttr()
+	return d.db.Create(&data).Error
+}
+
+// UpdateUser 更新后台用户
+func (d *dao) UpdateUser(ctx context.Context, query interface{}, data map[string]interface{}) error {
+	data["updated_at"] = time.Now()
+	return d.db.Model(&model.User{}).Where(query).Updates(data).Error
+}
+
+// ListUser 获取后台用户列表
+func (d *dao) ListUser(ctx context.Context, query interface{}) ([]*model.User, error) {
+	var users []*model.User
+	err := checkErr(d.db.Where(query).Find(&users).Error)
+	return users, err
+}
+
+// UpdateUserPassword 更新用户密码，仅当原密码未被修改时才更新
+func (d *dao) UpdateUserPassword(ctx context.Context, userID uint64, oldPassword, newPassword string) (bool, error) {
+	now := time.Now()
+	db := d.db.Model(&model.User{}).Where("id = ? AND password = ?", userID, oldPassword).Updates(map[string]interface{}{
+		"password":            newPassword,
+		"password_updated_at": now,
+		"updated_at":          now,
+	})
+	return db.RowsAffected == 1, db.Error
+}
+
+// IncrUserLoginFail 记录用户的一次登录尝试，返回登录成功前该用户在该IP及在所有IP的尝试次数，lockTime 内没有再次尝试则清零
+func (d *dao) IncrUserLoginFail(ctx context.Context, name, clientIP string, lockTime time.Duration) (int64, int64, error) {
+	ipKey := KeyCacheUserLoginFailPrefix + name + ":" + clientIP
+	accountKey := KeyCacheUserAccountLoginFailPrefix + name
+	pipe := d.cache.TxPipeline()
+	ipIncr := pipe.Incr(ipKey)
+	pipe.Expire(ipKey, lockTime)
+	accountIncr := pipe.Incr(accountKey)
+	pipe.Expire(accountKey, lockTime)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, 0, err
+	}
+	return ipIncr.Val(), accountIncr.Val(), nil
+}
+
+// DelUserLoginFail 清除用户在该IP及在所有IP的登录尝试次数
+func (d *dao) DelUserLoginFail(ctx context.Context, name, clientIP string) error {
+	return checkCacheError(d.cache.Del(KeyCacheUserLoginFailPrefix+name+":"+clientIP, KeyCacheUserAccountLoginFailPrefix+name).Err())
+}
+
+// GetTmpCheckinRecordList 获取全部用户 before 之前的签到列表
 // TODO: 临时
-func (d *dao) GetTmpCheckinRecordList(ctx context.Context) ([]*model.CheckinRecordListResp, error) {
+func (d *dao) GetTmpCheckinRecordList(ctx context.Context, before time.Time) ([]*model.CheckinRecordListResp, error) {
 	var (
 		checkinRecords []*model.CheckinRecord
 		data           = make([]*model.CheckinRecordListResp, 0, 30)
 	)
-	err := d.db.Raw(getTmpCheckinRecordListSQL).Find(&checkinRecords).Error
+	err := d.db.Raw(getTmpCheckinRecordListSQL, before).Find(&checkinRecords).Error
 	if err != nil {
 		return data, err
 	}
@@ -9,12 +9,21 @@ import (
 	"welfare-sign/internal/pkg/config"
 )
 
-// KeyCacheSMSCodePrefix 验证码在缓存中的键前缀
-const KeyCacheSMSCodePrefix = "welfare:sms:"
+// 验证码相关缓存键前缀
+const (
+	KeyCacheSMSCodePrefix     = "welfare:sms:"          // 验证码
+	KeyCacheSMSAttemptsPrefix = "welfare:sms:attempts:" // 验证码验证失败次数
+	KeyCacheSMSCooldownPrefix = "welfare:sms:cooldown:" // 手机号发送冷却
+	KeyCacheSMSDailyPrefix    = "welfare:sms:daily:"    // 每天发送次数，按手机号或IP统计
+)
 
-// SaveSMSCode 保存验证码，后续验证使用
+// SaveSMSCode 保存验证码，后续验证使用，同时重置验证失败次数
 func (d *dao) SaveSMSCode(ctx context.Context, mobile, code string) error {
-	return d.cache.Set(KeyCacheSMSCodePrefix+mobile, code, viper.GetDuration(config.KeySMSExpire)*time.Minute).Err()
+	pipe := d.cache.TxPipeline()
+	pipe.Set(KeyCacheSMSCodePrefix+mobile, code, viper.GetDuration(config.KeySMSExpire)*time.Minute)
+	pipe.Del(KeyCacheSMSAttemptsPrefix + mobile)
+	_, err := pipe.Exec()
+	return err
 }
 
 // GetSMSCode 验证传入的手机，获取验证码
@@ -24,5 +33,39 @@ func (d *dao) GetSMSCode(ctx context.Context, mobile string) (string, error) {
 
 // DelSMSCode 删除保存的code
 func (d *dao) DelSMSCode(ctx context.Context, mobile string) error {
-	return checkCacheError(d.cache.Del(KeyCacheSMSCodePrefix + mobile).Err())
+	return checkCacheError(d.cache.Del(KeyCacheSMSCodePrefix+mobile, KeyCacheSMSAttemptsPrefix+mobile).Err())
+}
+
+// IncrSMSCodeAttempts 记录一次验证码验证，返回已验证次数
+func (d *dao) IncrSMSCodeAttempts(ctx context.Context, mobile string) (int64, error) {
+	key := KeyCacheSMSAttemptsPrefix + mobile
+	pipe := d.cache.TxPipeline()
+	incr := pipe.Incr(key)
+	pipe.Expire(key, viper.GetDuration(config.KeySMSExpire)*time.Minute)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// SetSMSCooldown 设置手机号发送冷却，冷却期内已存在时返回false
+func (d *dao) SetSMSCooldown(ctx context.Context, mobile string, interval time.Duration) (bool, error) {
+	return d.cache.SetNX(KeyCacheSMSCooldownPrefix+mobile, 1, interval).Result()
+}
+
+// DelSMSCooldown 清除手机号发送冷却
+func (d *dao) DelSMSCooldown(ctx context.Context, mobile string) error {
+	return checkCacheError(d.cache.Del(KeyCacheSMSCooldownPrefix + mobile).Err())
+}
+
+// IncrSMSDailyCount 当天发送次数加一，返回当天已发送次数，subject为 mobile:手机号 或 ip:IP
+func (d *dao) IncrSMSDailyCount(ctx context.Context, subject string) (int64, error) {
+	key := KeyCacheSMSDailyPrefix + time.Now().Format("20060102") + ":" + subject
+	pipe := d.cache.TxPipeline()
+	incr := pipe.Incr(key)
+	pipe.Expire(key, 25*time.Hour)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
 }
@@ -0,0 +1,37 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+const listExpiringIssueRecordSQL = `
+	SELECT i.* FROM issue_record AS i WHERE i.status = ? AND i.total_receive > i.received
+AND i.created_at > ? AND i.created_at <= ?
+AND NOT EXISTS (SELECT 1 FROM welfare_reminder AS r WHERE r.issue_record_id = i.id AND r.remind_before = ?)
+	`
+
+// ListExpiringIssueRecords 获取在 (begin, end] 内发放、未核销完且尚未按 before 提醒过的福利
+func (d *dao) ListExpiringIssueRecords(ctx context.Context, begin, end time.Time, before int) ([]*model.IssueRecord, error) {
+	var issueRecords []*model.IssueRecord
+	err := checkErr(d.db.Raw(listExpiringIssueRecordSQL, global.ActiveStatus, begin, end, before).Scan(&issueRecords).Error)
+	return issueRecords, err
+}
+
+// CreateWelfareReminder 记录福利过期提醒，已提醒过时返回false
+func (d *dao) CreateWelfareReminder(ctx context.Context, data *model.WelfareReminder) (bool, error) {
+	data.SetDefaultAttr()
+	db := d.db.Set("gorm:insert_modifier", "IGNORE").Create(data)
+	if db.Error != nil {
+		return false, db.Error
+	}
+	return db.RowsAffected > 0, nil
+}
+
+// DelWelfareReminder 删除福利过期提醒记录，提醒发送失败时调用，允许下次任务重新提醒
+func (d *dao) DelWelfareReminder(ctx context.Context, issueRecordID uint64, before int) error {
+	return d.db.Where("issue_record_id = ? AND remind_before = ?", issueRecordID, before).Delete(&model.WelfareReminder{}).Error
+}
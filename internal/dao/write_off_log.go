@@ -0,0 +1,123 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/dao/mysql"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+)
+
+const listWriteOffLogSQL = `
+	SELECT w.*, c.nickname, g.name AS gift_item_name FROM write_off_log AS w
+LEFT JOIN customer AS c ON c.id = w.customer_id
+LEFT JOIN gift_item AS g ON g.id = w.gift_item_id
+WHERE w.merchant_id = ? AND w.created_at >= ? AND w.created_at < ?
+ORDER BY w.id DESC
+	`
+
+// ListWriteOffLog 获取商户在 [begin, end) 内的核销记录，按时间倒序
+// pageNo 为0时不分页，返回全部记录
+func (d *dao) ListWriteOffLog(ctx context.Context, merchantID uint64, begin, end time.Time, pageNo, pageSize int) ([]*model.WriteOffLogItem, int, error) {
+	var logs []*model.WriteOffLogItem
+	total := 0
+	sql, args := listWriteOffLogSQL, []interface{}{merchantID, begin, end}
+	if pageNo > 0 {
+		sql += " LIMIT ? OFFSET ?"
+		args = append(args, pageSize, (pageNo-1)*pageSize)
+	}
+	if err := d.db.Raw(sql, args...).Scan(&logs).Error; mysql.IsError(err) {
+		return logs, total, err
+	}
+	if err := d.db.Model(&model.WriteOffLog{}).Where("merchant_id = ? AND created_at >= ? AND created_at < ?", merchantID, begin, end).
+		Count(&total).Error; mysql.IsError(err) {
+		return logs, total, err
+	}
+	return logs, total, nil
+}
+
+const summaryWriteOffLogSQL = `
+	SELECT %s AS day, COUNT(*) AS times, SUM(num) AS num, COUNT(DISTINCT customer_id) AS customers FROM write_off_log
+WHERE merchant_id = ? AND reversed_at IS NULL AND created_at >= ? AND created_at < ?
+GROUP BY day
+ORDER BY day
+	`
+
+// SummaryWriteOffLog 按业务日汇总商户未撤销的核销，days 为各业务日的开始时刻及结束时刻，Day 为业务日在 days 中的下标
+func (d *dao) SummaryWriteOffLog(ctx context.Context, merchantID uint64, days []time.Time) ([]*model.WriteOffSummary, error) {
+	var summaries []*model.WriteOffSummary
+	bucket, args := dayBucketSQL("created_at", days)
+	args = append(args, merchantID, days[0], days[len(days)-1])
+	err := checkErr(d.db.Raw(fmt.Sprintf(summaryWriteOffLogSQL, bucket), args...).Scan(&summaries).Error)
+	return summaries, err
+}
+
+// 撤销核销失败的原因
+var (
+	ErrWriteOffReversed    = errors.New("核销记录已撤销或已超过可撤销时间")
+	ErrWriteOffNotReversed = errors.New("福利已过期或已核销数量不足")
+)
+
+// FindWriteOffLog 获取核销记录
+func (d *dao) FindWriteOffLog(ctx context.Context, query interface{}) (*model.WriteOffLog, error) {
+	var writeOffLog model.WriteOffLog
+	err := checkErr(d.db.Where(query).First(&writeOffLog).Error)
+	return &writeOffLog, err
+}
+
+// ReverseWriteOff 撤销核销，在同一事务中标记核销记录并退回福利的已核销数量和商户的核销数
+// 只能撤销 windowStart 之后的核销，福利需未失效且在 issuedAfter 之后发放
+func (d *dao) ReverseWriteOff(ctx context.Context, writeOffLog *model.WriteOffLog, windowStart, issuedAfter time.Time) error {
+	now := time.Now()
+	tx := d.db.Begin()
+	db := tx.Model(&model.WriteOffLog{}).
+		Where("id = ? AND reversed_at IS NULL AND created_at >= ?", writeOffLog.ID, windowStart).
+		Updates(map[string]interface{}{
+			"reversed_at":    now,
+			"reversed_by":    writeOffLog.ReversedBy,
+			"reverse_reason": writeOffLog.ReverseReason,
+			"updated_at":     now,
+			"updated_by":     writeOffLog.ReversedBy,
+		})
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrWriteOffReversed
+	}
+
+	db = tx.Model(&model.IssueRecord{}).
+		Where("id = ? AND status = ? AND received >= ? AND created_at > ?", writeOffLog.IssueRecordID, global.ActiveStatus, writeOffLog.Num, issuedAfter).
+		Update("received", gorm.Expr("received - ?", writeOffLog.Num))
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrWriteOffNotReversed
+	}
+
+	db = tx.Model(&model.Merchant{}).Where("id = ? AND has_write_off_num >= ?", writeOffLog.MerchantID, writeOffLog.Num).
+		Update("has_write_off_num", gorm.Expr("has_write_off_num - ?", writeOffLog.Num))
+	if db.Error != nil {
+		tx.Rollback()
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrWriteOffNotReversed
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	writeOffLog.ReversedAt = &now
+	return nil
+}
@@ -0,0 +1,29 @@
+package global
+
+// 审计日志操作对象类型
+const (
+	AuditEntityMerchant       = "merchant"        // 商户
+	AuditEntityCustomer       = "customer"        // 客户
+	AuditEntityCheckinRecord  = "checkin_record"  // 签到记录
+	AuditEntityCompositeIndex = "composite_index" // 上证指数
+	AuditEntityCampaign       = "campaign"        // 签到活动
+	AuditEntityPoints         = "points"          // 客户积分
+	AuditEntityReview         = "review"          // 商户评价
+)
+
+// 审计日志操作
+const (
+	AuditActionMerchantEdit        = "merchant.edit"         // 编辑商户
+	AuditActionMerchantDisable     = "merchant.disable"      // 禁用商户
+	AuditActionMerchantDelete      = "merchant.delete"       // 删除商户
+	AuditActionCustomerDisable     = "customer.disable"      // 禁用客户
+	AuditActionCustomerDelete      = "customer.delete"       // 删除客户
+	AuditActionCheckinRecordModify = "checkin_record.modify" // 修改签到记录
+	AuditActionCompositeIndexSave  = "composite_index.save"  // 录入上证指数
+	AuditActionCampaignAdd         = "campaign.add"          // 新增签到活动
+	AuditActionCampaignEdit        = "campaign.edit"         // 编辑签到活动
+	AuditActionCampaignDisable     = "campaign.disable"      // 停用签到活动
+	AuditActionPointsAdjust        = "points.adjust"         // 调整客户积分
+	AuditActionReviewApprove       = "review.approve"        // 审核通过商户评价
+	AuditActionReviewHide          = "review.hide"           // 隐藏商户评价
+)
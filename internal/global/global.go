@@ -8,3 +8,30 @@ const (
 	Readed         = "Y" // 是否读取了补签消息
 	UnRead         = "N" // 没有阅读补签消息
 )
+
+// 补签邀请事件
+const (
+	HelpInvitationOpen   = "open"   // 打开邀请
+	HelpInvitationAccept = "accept" // 接受邀请并帮签
+)
+
+// 邀请注册奖励类型
+const (
+	ReferralRewardSupplement = "supplement" // 免费补签次数
+	ReferralRewardGift       = "gift"       // 下次领取福利时额外的福利数量
+)
+
+// 积分变动原因
+const (
+	PointsReasonCheckin     = "checkin"      // 签到
+	PointsReasonHelpCheckin = "help_checkin" // 帮他人补签
+	PointsReasonCycle       = "cycle"        // 完成一轮签到
+	PointsReasonRedeem      = "redeem"       // 积分兑换
+	PointsReasonAdjust      = "adjust"       // 后台调整
+)
+
+// 积分兑换项目
+const (
+	PointsRedeemSupplement = "supplement" // 免费补签次数
+	PointsRedeemGift       = "gift"       // 下次领取福利时额外的福利数量
+)
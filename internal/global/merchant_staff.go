@@ -0,0 +1,7 @@
+package global
+
+// 商户员工角色
+const (
+	StaffRoleOwner = "owner" // 店主，可以管理员工
+	StaffRoleClerk = "clerk" // 店员，只能核销
+)
@@ -0,0 +1,45 @@
+package global
+
+// 后台角色
+const (
+	RoleSuperAdmin = "super_admin" // 超级管理员
+	RoleOperator   = "operator"    // 运营
+	RoleAnalyst    = "analyst"     // 只读分析
+)
+
+// 后台权限
+const (
+	PermAll            = "*"               // 全部权限
+	PermMerchantRead   = "merchant:read"   // 查看商户
+	PermMerchantWrite  = "merchant:write"  // 新增、编辑、禁用商户
+	PermMerchantDelete = "merchant:delete" // 删除商户
+	PermCustomerRead   = "customer:read"   // 查看客户
+	PermCustomerWrite  = "customer:write"  // 禁用客户
+	PermCustomerDelete = "customer:delete" // 删除客户
+	PermCheckinRead    = "checkin:read"    // 查看签到记录
+	PermCheckinWrite   = "checkin:write"   // 修改签到记录
+	PermCompositeIndex = "composite_index" // 录入上证指数
+	PermStatRead       = "stat:read"       // 查看统计
+	PermUserManage     = "user:manage"     // 管理后台用户及角色
+	PermAuditRead      = "audit:read"      // 查看审计日志
+	PermCampaignRead   = "campaign:read"   // 查看签到活动
+	PermCampaignWrite  = "campaign:write"  // 新增、编辑、停用签到活动
+	PermPointsWrite    = "points:write"    // 调整客户积分
+	PermReviewRead     = "review:read"     // 查看商户评价
+	PermReviewModerate = "review:moderate" // 审核、隐藏商户评价
+)
+
+// DefaultRolePermissions 内置角色及其权限，服务启动时若角色不存在则自动创建，已存在则补齐缺少的权限
+var DefaultRolePermissions = map[string][]string{
+	RoleSuperAdmin: {PermAll},
+	RoleOperator: {
+		PermMerchantRead, PermMerchantWrite,
+		PermCustomerRead, PermCustomerWrite,
+		PermCheckinRead, PermCheckinWrite,
+		PermCompositeIndex, PermStatRead,
+		PermCampaignRead, PermCampaignWrite,
+		PermPointsWrite,
+		PermReviewRead, PermReviewModerate,
+	},
+	RoleAnalyst: {PermMerchantRead, PermCustomerRead, PermCheckinRead, PermStatRead, PermCampaignRead, PermReviewRead},
+}
@@ -0,0 +1,30 @@
+package model
+
+// AuditLog 后台操作审计日志
+type AuditLog struct {
+	Base
+
+	ActorID    uint64 `json:"actor_id" gorm:"not null;index"`                     // 操作人ID
+	ActorName  string `json:"actor_name"`                                         // 操作人
+	ActorRole  string `json:"actor_role" gorm:"type:varchar(20)"`                 // 操作人角色
+	Action     string `json:"action" gorm:"not null;type:varchar(50);index"`      // 操作
+	EntityType string `json:"entity_type" gorm:"not null;type:varchar(50);index"` // 操作对象类型
+	EntityID   uint64 `json:"entity_id" gorm:"not null;index"`                    // 操作对象ID
+	Before     string `json:"before" gorm:"type:text"`                            // 修改前的数据JSON
+	After      string `json:"after" gorm:"type:text"`                             // 修改后的数据JSON
+	Diff       string `json:"diff" gorm:"type:text"`                              // 变化的字段JSON，{"字段":{"before":旧值,"after":新值}}
+	ClientIP   string `json:"client_ip" gorm:"type:varchar(64)"`                  // 客户端IP
+	RequestID  string `json:"request_id" gorm:"type:varchar(64)"`                 // 请求ID
+}
+
+// AuditLogListVO 审计日志查询参数
+type AuditLogListVO struct {
+	ActorID    uint64 `form:"actor_id" json:"actor_id"`
+	Action     string `form:"action" json:"action"`
+	EntityType string `form:"entity_type" json:"entity_type"`
+	EntityID   uint64 `form:"entity_id" json:"entity_id"`
+	BeginDate  string `form:"begin_date" json:"begin_date"`
+	EndDate    string `form:"end_date" json:"end_date"`
+	PageNo     int    `form:"page_no" json:"page_no"`
+	PageSize   int    `form:"page_size" json:"page_size"`
+}
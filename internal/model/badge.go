@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// CustomerBadge 客户获得的徽章
+type CustomerBadge struct {
+	Base
+
+	CustomerID uint64 `json:"customer_id" gorm:"not null;unique_index:uix_customer_badge"`           // 客户ID
+	Code       string `json:"code" gorm:"type:varchar(50);not null;unique_index:uix_customer_badge"` // 徽章编码
+}
+
+// BadgeStat 计算徽章所需的客户统计数据
+type BadgeStat struct {
+	HelpFriends int64 `json:"help_friends"` // 帮助补签过的好友人数
+	Merchants   int64 `json:"merchants"`    // 领取过福利的商户数
+	LuckyWins   int64 `json:"lucky_wins"`   // 幸运数字获得第一名的次数
+}
+
+// BadgeVO 客户的徽章，包含已获得和未获得的徽章
+type BadgeVO struct {
+	Code        string     `json:"code"`        // 徽章编码
+	Name        string     `json:"name"`        // 徽章名称
+	Description string     `json:"description"` // 获得条件说明
+	Threshold   int64      `json:"threshold"`   // 获得徽章需要达到的指标值
+	Progress    int64      `json:"progress"`    // 当前指标值，超过阈值时为阈值
+	Earned      bool       `json:"earned"`      // 是否已获得
+	EarnedAt    *time.Time `json:"earned_at"`   // 获得时间
+}
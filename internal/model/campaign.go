@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// Campaign 签到活动，客户按活动的签到周期签到，签满后可在活动商户领取礼品
+type Campaign struct {
+	Base
+
+	Name           string    `json:"name" gorm:"not null"`                      // 活动名称
+	CycleDays      uint64    `json:"cycle_days" gorm:"not null"`                // 签到周期天数
+	StartDate      time.Time `json:"start_date" gorm:"not null;type:date"`      // 开始日期
+	EndDate        time.Time `json:"end_date" gorm:"not null;type:date"`        // 结束日期，当天仍可参与
+	RewardNum      uint64    `json:"reward_num" gorm:"not null;default:0"`      // 签满后可领取的礼品数量，为0时使用商户设置的数量
+	Remark         string    `json:"remark" gorm:"type:varchar(255)"`           // 备注
+	GeofenceRadius float64   `json:"geofence_radius" gorm:"not null;default:0"` // 到店签到半径，单位公里，为0时不限制签到位置
+	Merchants      []uint64  `json:"merchants" gorm:"-"`                        // 参与活动的商户ID，为空时所有商户均可参与
+}
+
+// CampaignMerchant 参与签到活动的商户
+type CampaignMerchant struct {
+	Base
+
+	CampaignID uint64 `json:"campaign_id" gorm:"not null;index"` // 活动ID
+	MerchantID uint64 `json:"merchant_id" gorm:"not null;index"` // 商户ID
+}
+
+// CampaignVO 新增、编辑签到活动参数
+type CampaignVO struct {
+	Name           string   `json:"name" binding:"required" example:"活动名称"`
+	CycleDays      uint64   `json:"cycle_days" binding:"required,min=1,max=366" example:"7"`
+	StartDate      string   `json:"start_date" binding:"required" example:"2019-10-01"`
+	EndDate        string   `json:"end_date" binding:"required" example:"2019-10-31"`
+	RewardNum      uint64   `json:"reward_num" example:"1"`
+	Remark         string   `json:"remark"`
+	GeofenceRadius float64  `json:"geofence_radius" binding:"min=0" example:"0.5"` // 到店签到半径，单位公里，为0时不限制签到位置
+	Merchants      []uint64 `json:"merchants"`                                     // 参与活动的商户ID，为空时所有商户均可参与
+}
+
+// CampaignListVO 获取签到活动列表参数
+type CampaignListVO struct {
+	Name     string `form:"name" json:"name"`
+	Status   string `form:"status" json:"status"`
+	PageNo   int    `form:"page_no" json:"page_no"`
+	PageSize int    `form:"page_size" json:"page_size"`
+}
+
+// IsEligibleMerchant 商户是否参与了该活动
+func (c *Campaign) IsEligibleMerchant(merchantID uint64) bool {
+	if len(c.Merchants) == 0 {
+		return true
+	}
+	for _, id := range c.Merchants {
+		if id == merchantID {
+			return true
+		}
+	}
+	return false
+}
@@ -6,17 +6,27 @@ import "time"
 type CheckinRecord struct {
 	Base
 
-	CustomerID            uint64    `json:"customer_id" gorm:"not null"`                     // 签到人ID
-	HelpCheckinCustomerID uint64    `json:"help_checkin_customer_id" gorm:"default:0"`       // 帮签人ID
-	Day                   uint64    `json:"day" gorm:"not null"`                             // 签到第几天
-	NeedCheckinTime       time.Time `json:"need_checkin_time" gorm:"not null;type:datetime"` // 需要签到的日期
+	CustomerID            uint64    `json:"customer_id" gorm:"not null"`                        // 签到人ID
+	CampaignID            uint64    `json:"campaign_id" gorm:"not null;default:0"`              // 签到活动ID，为0时使用默认签到周期
+	MerchantID            uint64    `json:"merchant_id" gorm:"not null;default:0"`              // 到店签到时所在的店铺ID
+	HelpCheckinCustomerID uint64    `json:"help_checkin_customer_id" gorm:"default:0"`          // 帮签人ID
+	SupplementType        string    `json:"supplement_type" gorm:"type:varchar(10);default:''"` // 补签方式：help(好友帮签)，pay(付费补签)，为空时非补签
+	Day                   uint64    `json:"day" gorm:"not null"`                                // 签到第几天
+	NeedCheckinTime       time.Time `json:"need_checkin_time" gorm:"not null;type:datetime"`    // 需要签到的日期
 }
 
 // CheckinRecordLog 签到记录日志
 type CheckinRecordLog struct {
 	Base
 
-	CustomerID uint64 `json:"customer_id" gorm:"not null"` // 签到人ID
+	CustomerID uint64 `json:"customer_id" gorm:"not null"`           // 签到人ID
+	MerchantID uint64 `json:"merchant_id" gorm:"not null;default:0"` // 到店签到时所在的店铺ID
+}
+
+// CheckinLocation 签到时客户所在位置
+type CheckinLocation struct {
+	Lon float64 // 经度
+	Lat float64 // 纬度
 }
 
 // CheckinRecordVO 记录客户签到
@@ -30,3 +40,23 @@ type CheckinRecordListResp struct {
 	CheckinRecord *CheckinRecord `json:"checkin_record"`
 	Customer      *Customer      `json:"customer"`
 }
+
+// CheckinHistory 客户签到历史
+type CheckinHistory struct {
+	Month           string         `json:"month"`            // 日历月份，格式为2006-01
+	CheckinDates    []string       `json:"checkin_dates"`    // 该月已签到的日期
+	CurrentStreak   uint64         `json:"current_streak"`   // 当前连续签到天数，今天未签到时截止到昨天
+	LongestStreak   uint64         `json:"longest_streak"`   // 最近一年内最长连续签到天数
+	TotalCheckins   uint64         `json:"total_checkins"`   // 累计签到天数
+	CompletedCycles uint64         `json:"completed_cycles"` // 已完成并领取福利的签到周期数，即领取福利记录总数
+	ClaimedGifts    uint64         `json:"claimed_gifts"`    // 累计领取的礼品数量
+	Gifts           []*ClaimedGift `json:"gifts"`            // 领取福利记录当前页，按领取时间倒序
+}
+
+// ClaimedGift 客户领取福利记录
+type ClaimedGift struct {
+	MerchantID uint64    `json:"merchant_id"` // 店铺ID
+	StoreName  string    `json:"store_name"`  // 店名
+	Num        uint64    `json:"num"`         // 领取的礼品数量
+	ClaimedAt  time.Time `json:"claimed_at"`  // 领取时间
+}
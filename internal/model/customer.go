@@ -6,16 +6,20 @@ import "time"
 type Customer struct {
 	Base
 
-	OpenID          string     `json:"open_id" gorm:"not null"`                 // 微信用户openid
-	Nickname        string     `json:"nickname"`                                // 微信用户昵称
-	Sex             int        `json:"sex"`                                     // 微信用户性别
-	Country         string     `json:"country"`                                 // 微信用户所在国家
-	Province        string     `json:"province"`                                // 微信用户所在市
-	City            string     `json:"city"`                                    // 微信用户所在区
-	Headimgurl      string     `json:"headimgurl"`                              // 微信用户头像
-	Name            string     `json:"name" gorm:"not null"`                    // 称呼
-	Mobile          string     `json:"mobile" gorm:"type:varchar(50);not null"` // 手机号
-	LastCheckinTime *time.Time `json:"last_checkin_time" gorm:"type:datetime"`  // 最后一次签到时间
+	OpenID            string     `json:"open_id" gorm:"not null"`                      // 微信用户openid
+	Nickname          string     `json:"nickname"`                                     // 微信用户昵称
+	Sex               int        `json:"sex"`                                          // 微信用户性别
+	Country           string     `json:"country"`                                      // 微信用户所在国家
+	Province          string     `json:"province"`                                     // 微信用户所在市
+	City              string     `json:"city"`                                         // 微信用户所在区
+	Headimgurl        string     `json:"headimgurl"`                                   // 微信用户头像
+	Name              string     `json:"name" gorm:"not null"`                         // 称呼
+	Mobile            string     `json:"mobile" gorm:"type:varchar(50);not null"`      // 手机号
+	LastCheckinTime   *time.Time `json:"last_checkin_time" gorm:"type:datetime"`       // 最后一次签到时间
+	ReferrerID        uint64     `json:"referrer_id" gorm:"not null;default:0"`        // 邀请人ID，不是被邀请注册时为0
+	SupplementCredits uint64     `json:"supplement_credits" gorm:"not null;default:0"` // 邀请奖励的免费补签次数
+	BonusGiftNum      uint64     `json:"bonus_gift_num" gorm:"not null;default:0"`     // 邀请奖励的额外福利数量，下次领取福利时发放
+	Points            int64      `json:"points" gorm:"not null;default:0"`             // 积分余额，与积分流水同步更新
 }
 
 // CustomerListVO 查询顾客列表参数
@@ -0,0 +1,27 @@
+package model
+
+// GiftItem 商户提供的礼品，商户没有礼品时按商户上的礼品数量发放
+type GiftItem struct {
+	Base
+
+	MerchantID  uint64 `json:"merchant_id" gorm:"not null;index"`      // 商户ID
+	Name        string `json:"name" gorm:"type:varchar(100);not null"` // 礼品名称
+	Image       string `json:"image"`                                  // 礼品图片
+	Description string `json:"description"`                            // 礼品说明
+	TotalStock  uint64 `json:"total_stock" gorm:"not null"`            // 礼品库存总数
+	Received    uint64 `json:"received" gorm:"not null"`               // 已领取数量
+	ClaimNum    uint64 `json:"claim_num" gorm:"not null"`              // 每次领取的数量
+	CheckinDays uint64 `json:"checkin_days" gorm:"not null"`           // 签满多少天可领取
+	Sort        int    `json:"sort" gorm:"not null"`                   // 排序，越小越靠前
+}
+
+// GiftItemVO 新增、编辑礼品参数
+type GiftItemVO struct {
+	Name        string `json:"name" binding:"required,max=100"` // 礼品名称
+	Image       string `json:"image"`                           // 礼品图片
+	Description string `json:"description"`                     // 礼品说明
+	TotalStock  uint64 `json:"total_stock" binding:"required"`  // 礼品库存总数
+	ClaimNum    uint64 `json:"claim_num" binding:"required"`    // 每次领取的数量
+	CheckinDays uint64 `json:"checkin_days" binding:"required"` // 签满多少天可领取
+	Sort        int    `json:"sort"`                            // 排序，越小越靠前
+}
@@ -4,7 +4,9 @@ package model
 type HelpCheckinMessage struct {
 	Base
 
-	CustomerID      uint64 `json:"customer_id" gorm:"not null"` // 用户ID
-	CheckinRecordID uint64 `json:"checkin_record_id"`           // 关联签到记录ID
+	CustomerID      uint64 `json:"customer_id" gorm:"not null"`                      // 用户ID
+	CheckinRecordID uint64 `json:"checkin_record_id"`                                // 关联签到记录ID
+	HelpCustomerID  uint64 `json:"help_customer_id" gorm:"not null;default:0;index"` // 帮签人ID，付费补签时为0
+	InvitationID    uint64 `json:"invitation_id" gorm:"not null;default:0"`          // 通过补签邀请帮签时的邀请ID
 	IsRead          string `json:"is_read" gorm:"type:char(1);not null"`
 }
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// HelpInvitation 补签邀请，客户分享给好友帮自己补签，有效期至本轮签到结束
+type HelpInvitation struct {
+	Base
+
+	CustomerID uint64    `json:"customer_id" gorm:"not null;index"`               // 邀请人ID
+	Nonce      string    `json:"-" gorm:"not null;type:varchar(32);unique_index"` // 邀请token中的随机数
+	ExpiresAt  time.Time `json:"expires_at" gorm:"not null;type:datetime"`        // 过期时间
+}
+
+// HelpInvitationEvent 补签邀请的打开、接受记录
+type HelpInvitationEvent struct {
+	Base
+
+	InvitationID uint64 `json:"invitation_id" gorm:"not null;index"`          // 邀请ID
+	CustomerID   uint64 `json:"customer_id" gorm:"not null"`                  // 邀请人ID
+	VisitorID    uint64 `json:"visitor_id" gorm:"not null"`                   // 打开或接受邀请的客户ID
+	Event        string `json:"event" gorm:"not null;type:varchar(10);index"` // 事件：open(打开)，accept(接受并帮签)
+}
+
+// HelpInvitationResp 生成补签邀请的响应
+type HelpInvitationResp struct {
+	Token     string    `json:"token"`      // 邀请token，好友帮签时传入
+	ExpiresAt time.Time `json:"expires_at"` // 过期时间
+}
+
+// HelpInvitationDetail 好友打开补签邀请时看到的信息
+type HelpInvitationDetail struct {
+	Customer  *Customer `json:"customer"`   // 邀请人
+	ExpiresAt time.Time `json:"expires_at"` // 过期时间
+}
+
+// Helper 帮自己补签的好友
+type Helper struct {
+	CustomerID      uint64    `json:"customer_id"`       // 帮签人ID
+	Nickname        string    `json:"nickname"`          // 微信昵称
+	Headimgurl      string    `json:"headimgurl"`        // 微信头像
+	CheckinRecordID uint64    `json:"checkin_record_id"` // 补签的签到记录ID
+	InvitationID    uint64    `json:"invitation_id"`     // 通过的补签邀请ID，为0时非邀请帮签
+	HelpedAt        time.Time `json:"helped_at"`         // 帮签时间
+}
+
+// InvitationStat 补签邀请转化统计
+type InvitationStat struct {
+	Day            int     `json:"-"`               // 业务日在查询范围中的下标
+	Date           string  `json:"date"`            // 日期
+	Invitations    uint64  `json:"invitations"`     // 生成的邀请数
+	Opens          uint64  `json:"opens"`           // 打开邀请的人数
+	Accepts        uint64  `json:"accepts"`         // 接受邀请帮签的人数
+	ConversionRate float64 `json:"conversion_rate"` // 转化率，接受人数/打开人数
+}
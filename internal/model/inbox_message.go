@@ -0,0 +1,12 @@
+package model
+
+// InboxMessage 客户站内信
+type InboxMessage struct {
+	Base
+
+	CustomerID uint64 `json:"customer_id" gorm:"not null;index"`         // 客户ID
+	Title      string `json:"title" gorm:"type:varchar(100);not null"`   // 标题
+	Content    string `json:"content" gorm:"type:varchar(500);not null"` // 内容
+	Template   string `json:"template" gorm:"type:varchar(50);not null"` // 消息模板名
+	IsRead     string `json:"is_read" gorm:"type:char(1);not null"`      // 是否已读
+}
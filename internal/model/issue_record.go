@@ -4,19 +4,22 @@ package model
 type IssueRecord struct {
 	Base
 
-	MerchantID   uint64    `json:"merchant_id" gorm:"not null"` // 店铺ID
-	CustomerID   uint64    `json:"customer_id" gorm:"not null"` // 顾客ID
-	TotalReceive uint64    `json:"total_receive"`               // 在对应店铺可领取的总礼品数
-	Received     uint64    `json:"received"`                    // 在对应店铺已兑换的礼品数
+	MerchantID   uint64    `json:"merchant_id" gorm:"not null"`            // 店铺ID
+	CustomerID   uint64    `json:"customer_id" gorm:"not null"`            // 顾客ID
+	TotalReceive uint64    `json:"total_receive"`                          // 在对应店铺可领取的总礼品数
+	Received     uint64    `json:"received"`                               // 在对应店铺已兑换的礼品数
+	GiftItemID   uint64    `json:"gift_item_id" gorm:"not null;default:0"` // 礼品ID，为0时为商户未区分礼品时发放的福利
 	Merchant     *Merchant `json:"merchant" gorm:"-"`
 	Customer     *Customer `json:"customer" gorm:"-"`
+	GiftItem     *GiftItem `json:"gift_item" gorm:"-"`
 }
 
 // IssueRecordLog 用户领取福利记录
 type IssueRecordLog struct {
 	Base
 
-	MerchantID   uint64 `json:"merchant_id" gorm:"not null"` // 店铺ID
-	CustomerID   uint64 `json:"customer_id" gorm:"not null"` // 顾客ID
-	TotalReceive uint64 `json:"total_receive"`               // 在对应店铺可领取的总礼品数
+	MerchantID   uint64 `json:"merchant_id" gorm:"not null"`            // 店铺ID
+	CustomerID   uint64 `json:"customer_id" gorm:"not null"`            // 顾客ID
+	TotalReceive uint64 `json:"total_receive"`                          // 在对应店铺可领取的总礼品数
+	GiftItemID   uint64 `json:"gift_item_id" gorm:"not null;default:0"` // 礼品ID
 }
@@ -13,12 +13,15 @@ type Merchant struct {
 	Poster         string  `json:"poster"`                                                // 商户海报
 	ContactName    string  `json:"contact_name"`                                          // 联系人
 	ContactPhone   string  `json:"contact_phone" gorm:"unique;not null;type:varchar(50)"` // 联系人电话
-	Received       uint64  `json:"received"`                                              // 已领礼品数量
+	Received       uint64  `json:"received"`                                              // 已领礼品数量，不含按礼品目录领取的数量
 	TotalReceive   uint64  `json:"total_receive"`                                         // 该店礼品一共可领取总数
 	CheckinDays    uint64  `json:"checkin_days"`                                          // 签到天数多少天可领取礼品
 	CheckinNum     uint64  `json:"checkin_num"`                                           // 达到指定签到天数后，可领取的礼品数量
 	HasWriteOffNum uint64  `json:"has_write_off_num"`                                     // 已核销总数
 	HasFailure     uint64  `json:"has_failure"`                                           // 已失效
+	RatingCount    uint64  `json:"rating_count" gorm:"not null;default:0"`                // 展示中的评价数
+	RatingTotal    uint64  `json:"rating_total" gorm:"not null;default:0"`                // 展示中的评价评分总和
+	Rating         float64 `json:"rating" gorm:"not null;default:0"`                      // 平均评分，保留一位小数
 }
 
 // MerchantVO 新增店铺参数
@@ -62,16 +65,21 @@ type MerchantWriteOffVO struct {
 
 // MerchantWriteOffRespVO 商户核销响应
 type MerchantWriteOffRespVO struct {
-	Merchant    *Merchant    `json:"merchant"`
-	Customer    *Customer    `json:"customer"`
-	IssueRecord *IssueRecord `json:"issue_record"`
-	Num         uint64       `json:"num"`
+	Merchant     *Merchant      `json:"merchant"`
+	Customer     *Customer      `json:"customer"`
+	IssueRecord  *IssueRecord   `json:"issue_record"`
+	IssueRecords []*IssueRecord `json:"issue_records"` // 客户在该商户所有未失效的福利，按礼品区分
+	Num          uint64         `json:"num"`
+	WriteOffID   uint64         `json:"write_off_id"` // 核销记录ID，撤销核销时使用
 }
 
 // MerchantExecWriteOffVO 商户执行核销参数
 type MerchantExecWriteOffVO struct {
 	MerchantID uint64
-	CustomerID uint64
+	Code       string // 客户二维码中的核销码
+	CustomerID uint64 // 客户ID，仅在允许使用客户ID核销时有效
+	StaffID    uint64 // 执行核销的员工ID
+	GiftItemID uint64 // 核销的礼品ID，客户在该商户只有一份福利时可不传
 	Num        uint64
 }
 
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// MerchantReview 商户评价，客户每次核销后可评价一次
+// 状态为 A 时展示并计入商户评分，U 为待审核或已被隐藏
+type MerchantReview struct {
+	Base
+
+	MerchantID    uint64     `json:"merchant_id" gorm:"not null;index"`         // 商户ID
+	CustomerID    uint64     `json:"customer_id" gorm:"not null;index"`         // 客户ID
+	WriteOffLogID uint64     `json:"write_off_log_id" gorm:"not null;unique"`   // 核销记录ID
+	GiftItemID    uint64     `json:"gift_item_id" gorm:"not null;default:0"`    // 礼品ID
+	Rating        uint64     `json:"rating" gorm:"not null"`                    // 评分，1-5
+	Content       string     `json:"content" gorm:"type:varchar(500)"`          // 评价内容
+	Photos        []string   `json:"photos" gorm:"-"`                           // 评价图片文件名
+	PhotoNames    string     `json:"-" gorm:"column:photos;type:varchar(1000)"` // 评价图片文件名，逗号分隔
+	Reply         string     `json:"reply" gorm:"type:varchar(500)"`            // 商户回复
+	RepliedAt     *time.Time `json:"replied_at" gorm:"type:datetime"`           // 商户回复时间，未回复时为空
+	RepliedBy     uint64     `json:"replied_by"`                                // 回复的员工ID
+}
+
+// MerchantReviewItem 商户评价及客户信息
+type MerchantReviewItem struct {
+	MerchantReview
+
+	Nickname     string `json:"nickname"`       // 客户微信昵称
+	Headimgurl   string `json:"headimgurl"`     // 客户微信头像
+	GiftItemName string `json:"gift_item_name"` // 礼品名称
+}
+
+// MerchantReviewVO 客户评价参数
+type MerchantReviewVO struct {
+	CustomerID    uint64
+	MerchantID    uint64 // 未指定核销记录时评价客户在该商户最近一次未评价的核销
+	WriteOffLogID uint64
+	Rating        uint64
+	Content       string
+	Photos        []string
+}
+
+// MerchantReviewListVO 查询商户评价参数
+type MerchantReviewListVO struct {
+	MerchantID uint64
+	Status     string
+	PageNo     int
+	PageSize   int
+}
@@ -0,0 +1,21 @@
+package model
+
+// MerchantStaff 商户员工，可使用自己的手机号登录商户端进行核销
+type MerchantStaff struct {
+	Base
+
+	MerchantID uint64  `json:"merchant_id" gorm:"not null;index"`             // 商户ID
+	Name       string  `json:"name" gorm:"not null"`                          // 姓名
+	Mobile     string  `json:"mobile" gorm:"not null;type:varchar(50);index"` // 手机号
+	MobileKey  *string `json:"-" gorm:"type:varchar(50);unique_index"`        // 未删除员工的手机号，删除后为NULL，保证同一手机号只有一个未删除的员工
+	Role       string  `json:"role" gorm:"not null;type:varchar(20)"`         // 角色：owner(店主)，clerk(店员)
+	Enabled    bool    `json:"enabled" gorm:"not null;default:true"`          // 是否启用
+}
+
+// MerchantStaffVO 新增商户员工参数
+type MerchantStaffVO struct {
+	MerchantID uint64 `json:"-"`
+	Name       string `json:"name" binding:"required"`                   // 姓名
+	Mobile     string `json:"mobile" binding:"required,mobile"`          // 手机号
+	Role       string `json:"role" binding:"required,oneof=owner clerk"` // 角色：owner(店主)，clerk(店员)
+}
@@ -0,0 +1,57 @@
+package model
+
+import "strconv"
+
+// PointsEntry 积分流水，复式记账：每笔积分交易在客户账户和对应的系统账户各记一条流水，两条流水金额互为相反数
+// 流水只新增不修改，客户积分余额等于其账户下所有流水金额之和
+type PointsEntry struct {
+	Base
+
+	TxID       string `json:"tx_id" gorm:"not null;type:varchar(64);unique_index:uix_points_entry_tx_account"`   // 交易号，同一笔交易的两条流水相同
+	Account    string `json:"account" gorm:"not null;type:varchar(50);unique_index:uix_points_entry_tx_account"` // 记账账户：customer:<客户ID>或system:<原因>
+	CustomerID uint64 `json:"customer_id" gorm:"not null;index"`                                                 // 客户ID
+	Amount     int64  `json:"amount" gorm:"not null"`                                                            // 积分变动，正数为入账，负数为出账
+	Balance    int64  `json:"balance" gorm:"not null"`                                                           // 记账后客户的积分余额，系统账户流水为0
+	Reason     string `json:"reason" gorm:"type:varchar(20);not null"`                                           // 原因：checkin(签到)，help_checkin(帮签)，cycle(完成一轮签到)，redeem(兑换)，adjust(后台调整)
+	RefID      uint64 `json:"ref_id" gorm:"not null"`                                                            // 关联的业务记录ID
+	Remark     string `json:"remark"`                                                                            // 备注，后台调整时为调整原因
+}
+
+// PointsTx 一笔积分交易
+type PointsTx struct {
+	TxID       string // 交易号，相同交易号只记账一次
+	CustomerID uint64 // 客户ID
+	Amount     int64  // 客户积分变动
+	Reason     string // 原因
+	RefID      uint64 // 关联的业务记录ID
+	Remark     string // 备注
+	OperatorID uint64 // 操作人ID，后台调整时为后台用户ID
+}
+
+// CustomerPointsAccount 客户积分账户
+func CustomerPointsAccount(customerID uint64) string {
+	return "customer:" + strconv.FormatUint(customerID, 10)
+}
+
+// SystemPointsAccount 与客户账户对记的系统账户
+func SystemPointsAccount(reason string) string {
+	return "system:" + reason
+}
+
+// PointsBalance 客户积分余额
+type PointsBalance struct {
+	Balance int64 `json:"balance"` // 积分余额
+}
+
+// PointsRedeemVO 积分兑换参数
+type PointsRedeemVO struct {
+	Item string // 兑换项目：supplement(免费补签次数)，gift(下次领取福利时的额外福利)
+	Num  uint64 // 兑换数量
+}
+
+// PointsAdjustVO 后台调整积分参数
+type PointsAdjustVO struct {
+	CustomerID uint64 // 客户ID
+	Amount     int64  // 调整的积分，正数为增加，负数为扣减
+	Reason     string // 调整原因
+}
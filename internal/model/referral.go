@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Referral 邀请注册记录，同一个微信用户只能被邀请一次
+type Referral struct {
+	Base
+
+	ReferrerID    uint64     `json:"referrer_id" gorm:"not null;index"`               // 邀请人ID
+	RefereeID     uint64     `json:"referee_id" gorm:"not null;index"`                // 被邀请人ID
+	RefereeOpenID string     `json:"-" gorm:"not null;type:varchar(64);unique_index"` // 被邀请人微信openid
+	RewardType    string     `json:"reward_type" gorm:"type:varchar(20);not null"`    // 奖励类型：supplement(免费补签)，gift(额外福利)
+	RewardNum     uint64     `json:"reward_num" gorm:"not null"`                      // 奖励数量
+	RewardedAt    *time.Time `json:"rewarded_at" gorm:"type:datetime"`                // 发放奖励时间，为空时未发放
+}
+
+// ReferralCodeResp 邀请码，作为微信网页授权的state传入登录接口
+type ReferralCodeResp struct {
+	Code      string    `json:"code"`       // 邀请码
+	ExpiresAt time.Time `json:"expires_at"` // 过期时间
+}
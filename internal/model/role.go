@@ -0,0 +1,19 @@
+package model
+
+// Role 后台角色
+type Role struct {
+	Base
+
+	Name        string `json:"name" gorm:"not null;unique_index"` // 角色名
+	Description string `json:"description"`                       // 描述
+
+	Permissions []string `json:"permissions" gorm:"-"` // 角色拥有的权限
+}
+
+// RolePermission 角色权限
+type RolePermission struct {
+	Base
+
+	RoleID     uint64 `json:"role_id" gorm:"not null;index"` // 角色ID
+	Permission string `json:"permission" gorm:"not null"`    // 权限标识
+}
@@ -0,0 +1,19 @@
+package model
+
+// TokenResp 登录或刷新token后返回的数据
+type TokenResp struct {
+	AccessToken  string `json:"access_token"`  // 访问接口使用的token
+	RefreshToken string `json:"refresh_token"` // 用于刷新access_token
+	ExpiresIn    int64  `json:"expires_in"`    // access_token有效期，单位秒
+}
+
+// TokenSession refresh token对应的会话信息
+type TokenSession struct {
+	UID    uint64 `json:"uid"`
+	Name   string `json:"name"`
+	Mobile string `json:"mobile"`
+	Role   string `json:"role"`
+
+	StaffID   uint64 `json:"staff_id,omitempty"`   // 商户员工ID
+	StaffRole string `json:"staff_role,omitempty"` // 商户员工角色
+}
@@ -1,11 +1,15 @@
 package model
 
+import "time"
+
 // User 后台管理员
 type User struct {
 	Base
 
-	Name     string `json:"name" gorm:"not null"`     // 用户名
-	Password string `json:"password" gorm:"not null"` // 密码
+	Name              string     `json:"name" gorm:"not null"`                     // 用户名
+	Password          string     `json:"-" gorm:"not null"`                        // 密码哈希
+	PasswordUpdatedAt *time.Time `json:"password_updated_at" gorm:"type:datetime"` // 最后一次修改密码时间
+	RoleID            uint64     `json:"role_id" gorm:"not null;default:0"`        // 角色ID
 }
 
 // UserVO 用户登录
@@ -13,3 +17,16 @@ type UserVO struct {
 	Name     string `json:"name" binding:"required"`     // 用户名
 	Password string `json:"password" binding:"required"` // 密码
 }
+
+// UserPasswordVO 修改密码
+type UserPasswordVO struct {
+	OldPassword string `json:"old_password" binding:"required"` // 原密码
+	NewPassword string `json:"new_password" binding:"required"` // 新密码
+}
+
+// UserAddVO 新增后台用户
+type UserAddVO struct {
+	Name     string `json:"name" binding:"required"`     // 用户名
+	Password string `json:"password" binding:"required"` // 密码
+	RoleID   uint64 `json:"role_id" binding:"required"`  // 角色ID
+}
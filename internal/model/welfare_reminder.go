@@ -0,0 +1,10 @@
+package model
+
+// WelfareReminder 福利过期提醒记录，同一福利在每个提醒时间点最多提醒一次
+type WelfareReminder struct {
+	Base
+
+	IssueRecordID uint64 `json:"issue_record_id" gorm:"not null;unique_index:uix_welfare_reminder"` // 福利记录ID
+	RemindBefore  int    `json:"remind_before" gorm:"not null;unique_index:uix_welfare_reminder"`   // 过期前多少分钟提醒
+	CustomerID    uint64 `json:"customer_id" gorm:"not null"`                                       // 客户ID
+}
@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// WriteOffLog 核销记录，每次核销在同一事务中写入一条
+type WriteOffLog struct {
+	Base
+
+	MerchantID    uint64 `json:"merchant_id" gorm:"not null;index"`      // 商户ID
+	CustomerID    uint64 `json:"customer_id" gorm:"not null;index"`      // 客户ID
+	IssueRecordID uint64 `json:"issue_record_id" gorm:"not null;index"`  // 福利记录ID
+	GiftItemID    uint64 `json:"gift_item_id" gorm:"not null;default:0"` // 礼品ID
+	StaffID       uint64 `json:"staff_id" gorm:"not null;index"`         // 执行核销的员工ID
+	StaffName     string `json:"staff_name"`                             // 执行核销的员工姓名
+	Num           uint64 `json:"num" gorm:"not null"`                    // 核销数目
+	ClientIP      string `json:"client_ip" gorm:"type:varchar(64)"`      // 核销时的客户端IP
+
+	ReversedAt    *time.Time `json:"reversed_at" gorm:"type:datetime"` // 撤销时间，未撤销时为空
+	ReversedBy    uint64     `json:"reversed_by"`                      // 执行撤销的员工ID
+	ReverseReason string     `json:"reverse_reason"`                   // 撤销原因
+}
+
+// WriteOffLogItem 核销记录及客户信息
+type WriteOffLogItem struct {
+	WriteOffLog
+
+	Nickname     string `json:"nickname"`       // 客户微信昵称
+	GiftItemName string `json:"gift_item_name"` // 礼品名称
+}
+
+// WriteOffSummary 商户每日核销汇总
+type WriteOffSummary struct {
+	Day       int    `json:"-"`         // 业务日在查询范围内的下标
+	Date      string `json:"date"`      // 日期
+	Times     uint64 `json:"times"`     // 核销次数
+	Num       uint64 `json:"num"`       // 核销数量
+	Customers uint64 `json:"customers"` // 核销的客户数
+}
+
+// WriteOffReverseVO 撤销核销参数
+type WriteOffReverseVO struct {
+	ID         uint64
+	MerchantID uint64
+	StaffID    uint64
+	Reason     string
+}
+
+// WriteOffLogListVO 查询核销记录参数
+type WriteOffLogListVO struct {
+	MerchantID uint64
+	BeginDate  string
+	EndDate    string
+	PageNo     int
+	PageSize   int
+}
@@ -0,0 +1,84 @@
+package badge
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"welfare-sign/internal/pkg/config"
+)
+
+// 徽章统计指标
+const (
+	MetricCheckinDays   = "checkin_days"   // 累计签到天数
+	MetricCheckinStreak = "checkin_streak" // 最长连续签到天数
+	MetricHelpFriends   = "help_friends"   // 帮助补签过的好友人数
+	MetricMerchants     = "merchants"      // 领取过福利的商户数
+	MetricLuckyWins     = "lucky_wins"     // 幸运数字获得第一名的次数
+)
+
+var metrics = map[string]bool{
+	MetricCheckinDays:   true,
+	MetricCheckinStreak: true,
+	MetricHelpFriends:   true,
+	MetricMerchants:     true,
+	MetricLuckyWins:     true,
+}
+
+// Definition 徽章定义，客户的统计指标达到阈值时获得该徽章
+type Definition struct {
+	Code        string `mapstructure:"code"`        // 徽章编码，已发放的徽章以编码记录，修改编码相当于新增徽章
+	Name        string `mapstructure:"name"`        // 徽章名称
+	Description string `mapstructure:"description"` // 获得条件说明
+	Metric      string `mapstructure:"metric"`      // 统计指标
+	Threshold   int64  `mapstructure:"threshold"`   // 获得徽章需要达到的指标值
+}
+
+// Defaults 未配置 badge.definitions 时使用的内置徽章
+var Defaults = []Definition{
+	{Code: "first_checkin", Name: "初次签到", Description: "完成第一次签到", Metric: MetricCheckinDays, Threshold: 1},
+	{Code: "streak_7", Name: "连续签到7天", Description: "连续7天签到", Metric: MetricCheckinStreak, Threshold: 7},
+	{Code: "helper_10", Name: "热心好友", Description: "帮助10位好友补签", Metric: MetricHelpFriends, Threshold: 10},
+	{Code: "merchants_5", Name: "探店达人", Description: "在5家商户领取过福利", Metric: MetricMerchants, Threshold: 5},
+	{Code: "lucky_winner", Name: "幸运之星", Description: "幸运数字获得第一名", Metric: MetricLuckyWins, Threshold: 1},
+}
+
+// Stats 客户各项统计指标的值
+type Stats map[string]int64
+
+// Reached 客户的统计指标是否达到获得徽章的条件
+func (d Definition) Reached(stats Stats) bool {
+	return stats[d.Metric] >= d.Threshold
+}
+
+// Load 读取配置 badge.definitions 中的徽章定义，未配置时使用内置徽章
+func Load() ([]Definition, error) {
+	var defs []Definition
+	if err := viper.UnmarshalKey(config.KeyBadgeDefinitions, &defs); err != nil {
+		return nil, err
+	}
+	if len(defs) == 0 {
+		return Defaults, nil
+	}
+	return defs, Validate(defs)
+}
+
+// Validate 校验徽章定义：编码不能为空且不能重复，统计指标必须存在，阈值必须大于0
+func Validate(defs []Definition) error {
+	seen := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		if def.Code == "" {
+			return errors.New("badge: empty code")
+		}
+		if seen[def.Code] {
+			return errors.Errorf("badge: duplicate code %s", def.Code)
+		}
+		seen[def.Code] = true
+		if !metrics[def.Metric] {
+			return errors.Errorf("badge: unknown metric %s of %s", def.Metric, def.Code)
+		}
+		if def.Threshold <= 0 {
+			return errors.Errorf("badge: threshold of %s must be positive", def.Code)
+		}
+	}
+	return nil
+}
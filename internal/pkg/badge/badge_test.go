@@ -0,0 +1,41 @@
+package badge
+
+import "testing"
+
+func TestReached(t *testing.T) {
+	def := Definition{Code: "streak_7", Metric: MetricCheckinStreak, Threshold: 7}
+
+	if def.Reached(Stats{MetricCheckinStreak: 6}) {
+		t.Error("streak 6 should not reach threshold 7")
+	}
+	if !def.Reached(Stats{MetricCheckinStreak: 7}) {
+		t.Error("streak 7 should reach threshold 7")
+	}
+	if def.Reached(Stats{MetricCheckinDays: 30}) {
+		t.Error("other metrics should not count")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(Defaults); err != nil {
+		t.Fatalf("defaults: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		defs []Definition
+	}{
+		{"empty code", []Definition{{Metric: MetricCheckinDays, Threshold: 1}}},
+		{"duplicate code", []Definition{
+			{Code: "a", Metric: MetricCheckinDays, Threshold: 1},
+			{Code: "a", Metric: MetricMerchants, Threshold: 1},
+		}},
+		{"unknown metric", []Definition{{Code: "a", Metric: "steps", Threshold: 1}}},
+		{"zero threshold", []Definition{{Code: "a", Metric: MetricCheckinDays}}},
+	}
+	for _, c := range cases {
+		if err := Validate(c.defs); err == nil {
+			t.Errorf("%s: expected error", c.name)
+		}
+	}
+}
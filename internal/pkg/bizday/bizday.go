@@ -0,0 +1,135 @@
+package bizday
+
+import (
+	"time"
+	_ "time/tzdata" // 运行环境缺少时区数据库时仍可加载业务时区
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"welfare-sign/internal/pkg/config"
+)
+
+// DateLayout 业务日期格式
+const DateLayout = "2006-01-02"
+
+// Calendar 业务日历，按业务时区及换日时间计算“今天”
+// 例如换日时间为4点时，凌晨3点仍属于前一天
+type Calendar struct {
+	loc          *time.Location
+	rolloverHour int
+}
+
+// New 根据配置 checkin.timezone、checkin.rollover_hour 创建业务日历
+func New() (*Calendar, error) {
+	loc, err := time.LoadLocation(viper.GetString(config.KeyCheckinTimezone))
+	if err != nil {
+		return nil, errors.WithMessage(err, "bizday: load timezone")
+	}
+	return NewCalendar(loc, viper.GetInt(config.KeyCheckinRolloverHour))
+}
+
+// NewCalendar 创建业务日历，rolloverHour 为每天开始的整点，取值0-23
+func NewCalendar(loc *time.Location, rolloverHour int) (*Calendar, error) {
+	if rolloverHour < 0 || rolloverHour > 23 {
+		return nil, errors.Errorf("bizday: invalid rollover hour %d", rolloverHour)
+	}
+	return &Calendar{loc: loc, rolloverHour: rolloverHour}, nil
+}
+
+// Location 业务时区
+func (c *Calendar) Location() *time.Location {
+	return c.loc
+}
+
+// DayStart t 所在业务日的开始时刻
+func (c *Calendar) DayStart(t time.Time) time.Time {
+	t = t.In(c.loc).Add(-time.Duration(c.rolloverHour) * time.Hour)
+	return time.Date(t.Year(), t.Month(), t.Day(), c.rolloverHour, 0, 0, 0, c.loc)
+}
+
+// AddDays 业务日开始时刻 start 之后第 n 个业务日的开始时刻，n 可为负数
+// 按日历日计算，夏令时切换当天也不会错位
+func (c *Calendar) AddDays(start time.Time, n int) time.Time {
+	return c.DayStart(start).AddDate(0, 0, n)
+}
+
+// Range t 所在业务日的时间范围 [start, end)
+func (c *Calendar) Range(t time.Time) (start, end time.Time) {
+	start = c.DayStart(t)
+	return start, c.AddDays(start, 1)
+}
+
+// Days 从 t 所在业务日开始连续 n 个业务日的开始时刻
+func (c *Calendar) Days(t time.Time, n int) []time.Time {
+	start := c.DayStart(t)
+	days := make([]time.Time, n)
+	for i := range days {
+		days[i] = c.AddDays(start, i)
+	}
+	return days
+}
+
+// Date t 所在业务日的日期，格式为 DateLayout
+func (c *Calendar) Date(t time.Time) string {
+	return c.DayStart(t).Format(DateLayout)
+}
+
+// ParseDate 按业务时区解析 DateLayout 格式的日期
+func (c *Calendar) ParseDate(s string) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, s, c.loc)
+}
+
+// DateRange 业务日期 [begin, end] 对应的时间范围 [start, stop)，日期格式为 DateLayout
+func (c *Calendar) DateRange(begin, end string) (start, stop time.Time, err error) {
+	b, err := c.ParseDate(begin)
+	if err != nil {
+		return
+	}
+	e, err := c.ParseDate(end)
+	if err != nil {
+		return
+	}
+	if e.Before(b) {
+		err = errors.New("bizday: end date before begin date")
+		return
+	}
+	start = time.Date(b.Year(), b.Month(), b.Day(), c.rolloverHour, 0, 0, 0, c.loc)
+	stop = time.Date(e.Year(), e.Month(), e.Day()+1, c.rolloverHour, 0, 0, 0, c.loc)
+	return
+}
+
+// Streaks 根据按时间正序排列的业务日期计算当前及最长连续天数，日期格式为 DateLayout
+// today 还未出现在 dates 中时，截止到前一天的连续天数仍计为当前连续天数
+func Streaks(dates []string, today string) (current, longest uint64) {
+	var (
+		run  uint64
+		prev time.Time
+	)
+	for i, date := range dates {
+		day, err := time.Parse(DateLayout, date)
+		if err != nil {
+			continue
+		}
+		if i > 0 && day.Equal(prev.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+		prev = day
+	}
+	if len(dates) == 0 {
+		return 0, longest
+	}
+	todayDay, err := time.Parse(DateLayout, today)
+	if err != nil {
+		return 0, longest
+	}
+	if prev.Equal(todayDay) || prev.Equal(todayDay.AddDate(0, 0, -1)) {
+		current = run
+	}
+	return current, longest
+}
@@ -0,0 +1,93 @@
+package bizday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayStart(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	cal, err := NewCalendar(shanghai, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		in   time.Time
+		want string
+	}{
+		{time.Date(2019, 10, 2, 3, 59, 0, 0, shanghai), "2019-10-01"},
+		{time.Date(2019, 10, 2, 4, 0, 0, 0, shanghai), "2019-10-02"},
+		{time.Date(2019, 10, 2, 23, 0, 0, 0, shanghai), "2019-10-02"},
+		// UTC 18:00 为北京时间次日2点，仍属于前一天
+		{time.Date(2019, 10, 1, 18, 0, 0, 0, time.UTC), "2019-10-01"},
+		{time.Date(2019, 10, 1, 20, 0, 0, 0, time.UTC), "2019-10-02"},
+	}
+	for _, c := range cases {
+		if got := cal.Date(c.in); got != c.want {
+			t.Errorf("Date(%v) = %s, want %s", c.in, got, c.want)
+		}
+	}
+
+	start, end := cal.Range(time.Date(2019, 10, 2, 1, 0, 0, 0, shanghai))
+	if !start.Equal(time.Date(2019, 10, 1, 4, 0, 0, 0, shanghai)) || !end.Equal(time.Date(2019, 10, 2, 4, 0, 0, 0, shanghai)) {
+		t.Errorf("Range = [%v, %v)", start, end)
+	}
+
+	days := cal.Days(time.Date(2019, 12, 30, 12, 0, 0, 0, shanghai), 3)
+	if len(days) != 3 || cal.Date(days[2]) != "2020-01-01" {
+		t.Errorf("Days = %v", days)
+	}
+
+	if _, err := NewCalendar(shanghai, 24); err == nil {
+		t.Error("rollover hour 24 should be invalid")
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	cal, err := NewCalendar(shanghai, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start, stop, err := cal.DateRange("2019-10-01", "2019-10-03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2019, 10, 1, 4, 0, 0, 0, shanghai); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2019, 10, 4, 4, 0, 0, 0, shanghai); !stop.Equal(want) {
+		t.Errorf("stop = %v, want %v", stop, want)
+	}
+	if _, _, err := cal.DateRange("2019-10-03", "2019-10-01"); err == nil {
+		t.Error("expected error for end before begin")
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	cases := []struct {
+		name    string
+		dates   []string
+		today   string
+		current uint64
+		longest uint64
+	}{
+		{"empty", nil, "2019-10-10", 0, 0},
+		{"checked in today", []string{"2019-10-08", "2019-10-09", "2019-10-10"}, "2019-10-10", 3, 3},
+		{"not yet today", []string{"2019-10-08", "2019-10-09"}, "2019-10-10", 2, 2},
+		{"broken streak", []string{"2019-10-01", "2019-10-02", "2019-10-03", "2019-10-08"}, "2019-10-10", 0, 3},
+		{"current shorter than longest", []string{"2019-10-01", "2019-10-02", "2019-10-03", "2019-10-09", "2019-10-10"}, "2019-10-10", 2, 3},
+		{"across month end", []string{"2019-09-29", "2019-09-30", "2019-10-01"}, "2019-10-01", 3, 3},
+		{"invalid date skipped", []string{"2019-10-09", "bad", "2019-10-10"}, "2019-10-10", 2, 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			current, longest := Streaks(c.dates, c.today)
+			if current != c.current || longest != c.longest {
+				t.Errorf("Streaks() = (%d, %d), want (%d, %d)", current, longest, c.current, c.longest)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package config
 import "github.com/spf13/viper"
 
 func init() {
+	setDefaults()
+
 	viper.SetConfigName("dev")
 	viper.AddConfigPath("./config/")
 	viper.AddConfigPath("../config/")
@@ -12,3 +14,37 @@ func init() {
 		panic(err)
 	}
 }
+
+// setDefaults 配置项默认值，配置文件中未设置时生效
+func setDefaults() {
+	viper.SetDefault(KeyJWTAccessExpire, 30)
+	viper.SetDefault(KeyUserLoginMaxAttempts, 5)
+	viper.SetDefault(KeyUserLoginAccountMaxAttempts, 20)
+	viper.SetDefault(KeyUserLoginLockTime, 15)
+	viper.SetDefault(KeySMSSendInterval, 60)
+	viper.SetDefault(KeySMSMobileDailyMax, 10)
+	viper.SetDefault(KeySMSIPDailyMax, 50)
+	viper.SetDefault(KeySMSMaxAttempts, 5)
+	viper.SetDefault(KeyNotifyChannels, []string{"sms", "inbox"})
+	viper.SetDefault(KeyQRCodeExpire, 120)
+	viper.SetDefault(KeyWriteOffReverseWindow, 30)
+	viper.SetDefault(KeyReviewWindowDays, 30)
+	viper.SetDefault(KeyIdempotencyTTL, 86400)
+	viper.SetDefault(KeyCheckinCycleDays, 5)
+	viper.SetDefault(KeyCheckinTimezone, "Asia/Shanghai")
+	viper.SetDefault(KeyCheckinRolloverHour, 0)
+	viper.SetDefault(KeySupplementHelpsPerTarget, 1)
+	viper.SetDefault(KeySupplementPayEnable, true)
+	viper.SetDefault(KeySupplementBeforeCheckin, true)
+	viper.SetDefault(KeyReferralEnable, true)
+	viper.SetDefault(KeyReferralRewardType, "supplement")
+	viper.SetDefault(KeyReferralRewardNum, 1)
+	viper.SetDefault(KeyReferralCodeExpire, 30)
+	viper.SetDefault(KeyPointsCheckin, 10)
+	viper.SetDefault(KeyPointsHelpCheckin, 5)
+	viper.SetDefault(KeyPointsCycle, 50)
+	viper.SetDefault(KeyPointsRedeemSupplement, 100)
+	viper.SetDefault(KeyPointsRedeemGift, 200)
+	viper.SetDefault(KeyTaskWelfareRemindInterval, "@every 10m")
+	viper.SetDefault(KeyTaskWelfareRemindBefore, []int{1440, 60})
+}
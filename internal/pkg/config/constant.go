@@ -4,11 +4,13 @@ package config
 const (
 	KeyMysqlDSN = "mysql.dsn"
 
-	KeyHTTPAddr = "http.addr"
+	KeyHTTPAddr           = "http.addr"
+	KeyHTTPTrustedProxies = "http.trusted_proxies" // 受信任的反向代理IP或CIDR，只有来自这些地址的请求才读取 X-Forwarded-For
 
-	KeyJWTSign   = "jwt.sign"
-	KeyJWTIssuer = "jwt.issuer"
-	KeyJWTExpire = "jwt.expire"
+	KeyJWTSign         = "jwt.sign"
+	KeyJWTIssuer       = "jwt.issuer"
+	KeyJWTExpire       = "jwt.expire"        // refresh token有效期，单位小时
+	KeyJWTAccessExpire = "jwt.access_expire" // access token有效期，单位分钟
 
 	KeyRedisDB   = "redis.db"
 	KeyRedisHost = "redis.host"
@@ -23,22 +25,75 @@ const (
 	KeySMSSignName = "sms.sign_name"
 	KeySMSAK       = "sms.ak"
 	KeySMSAS       = "sms.as"
-	KeySMSTemplate = "sms.template"
+	KeySMSTemplate = "sms.template" // 验证码模板，已由 sms.templates.login_code 取代
 	KeySMSLength   = "sms.length"
 	KeySMSExpire   = "sms.expire"
 	KeySMSEnable   = "sms.enable"
 
+	KeySMSProvider  = "sms.provider"  // 短信服务提供方：aliyun、log、memory
+	KeySMSTemplates = "sms.templates" // 短信模板编号，sms.templates.<模板名>
+	KeySMSLogFile   = "sms.log_file"  // provider为log时短信记录文件，为空时输出到标准输出
+
+	KeySMSSendInterval   = "sms.send_interval"      // 同一手机号两次发送的最小间隔，单位秒
+	KeySMSMobileDailyMax = "sms.mobile_daily_limit" // 同一手机号每天最多发送次数
+	KeySMSIPDailyMax     = "sms.ip_daily_limit"     // 同一IP每天最多发送次数
+	KeySMSMaxAttempts    = "sms.max_attempts"       // 验证码最多允许验证失败的次数，超过后验证码失效
+
+	KeyNotifyChannels        = "notify.channels"         // 通知渠道：sms、wechat、inbox，可同时配置多个
+	KeyNotifyWeChatTemplates = "notify.wechat_templates" // 微信模板消息编号，notify.wechat_templates.<模板名>
+
 	KeyWxAppID     = "wx.appid"
 	KeyWxAppSecret = "wx.appsecret"
 
-	KeyQRCodeURL = "qrcode.url"
+	KeyQRCodeURL              = "qrcode.url"
+	KeyQRCodeSign             = "qrcode.sign"               // 核销二维码签名密钥
+	KeyQRCodeExpire           = "qrcode.expire"             // 核销二维码有效期，单位秒
+	KeyQRCodeLegacyCustomerID = "qrcode.legacy_customer_id" // 是否仍允许直接使用客户ID核销
+
+	KeyWriteOffReverseWindow = "writeoff.reverse_window" // 核销后多少分钟内可撤销，0为不允许撤销
+
+	KeyReviewWindowDays    = "review.window_days"    // 核销后多少天内可评价，0为不限制
+	KeyReviewPreModeration = "review.pre_moderation" // 评价是否需要后台审核通过后才展示
 
 	KeyWXPayMchID     = "wx.pay_mch_id"
 	KeyWXPayAPI       = "wx.pay_api_key"
 	KeyWXPayNotifyURL = "wx.pay_notify_url"
 	KeyWXPayAmount    = "wx.pay_amount"
 
+	KeyIdempotencyTTL = "idempotency.ttl" // Idempotency-Key 对应的成功响应保存时间，单位秒
+
+	KeyUserLoginMaxAttempts        = "user.login_max_attempts"         // 后台用户在同一IP连续登录失败多少次后锁定该IP
+	KeyUserLoginAccountMaxAttempts = "user.login_account_max_attempts" // 后台用户在所有IP累计连续登录失败多少次后锁定该用户
+	KeyUserLoginLockTime           = "user.login_lock_time"            // 后台用户登录锁定时间，单位分钟
+
+	KeyCheckinCycleDays      = "checkin.cycle_days"      // 没有进行中的签到活动时默认的签到周期天数
+	KeyCheckinTimezone       = "checkin.timezone"        // 业务时区，签到按该时区计算日期
+	KeyCheckinRolloverHour   = "checkin.rollover_hour"   // 每天的换日时间(整点)，如4表示凌晨4点后才算新的一天
+	KeyCheckinGeofenceRadius = "checkin.geofence_radius" // 未参加签到活动时到店签到的半径，单位公里，为0时不限制签到位置
+
+	KeySupplementMaxDays          = "supplement.max_days"           // 每轮签到最多可补签的天数，0为不限制
+	KeySupplementHelperDailyLimit = "supplement.helper_daily_limit" // 每个用户每天最多可帮他人补签的次数，0为不限制
+	KeySupplementHelpsPerTarget   = "supplement.helps_per_target"   // 每轮签到中同一用户最多可帮同一人补签的次数，0为不限制
+	KeySupplementWindowDays       = "supplement.window_days"        // 只能补签最近多少天内漏签的记录，0为不限制
+	KeySupplementPayEnable        = "supplement.pay_enable"         // 是否允许付费补签
+	KeySupplementBeforeCheckin    = "supplement.before_checkin"     // 有漏签未补时是否禁止当天签到
+
+	KeyReferralEnable     = "referral.enable"      // 是否开启邀请注册奖励
+	KeyReferralRewardType = "referral.reward_type" // 邀请奖励类型：supplement(免费补签次数)，gift(额外福利数量)
+	KeyReferralRewardNum  = "referral.reward_num"  // 每邀请一人的奖励数量
+	KeyReferralCodeExpire = "referral.code_expire" // 邀请码有效期，单位天
+
+	KeyPointsCheckin          = "points.checkin"           // 每次签到获得的积分，0为不奖励
+	KeyPointsHelpCheckin      = "points.help_checkin"      // 每次帮他人补签获得的积分，0为不奖励
+	KeyPointsCycle            = "points.cycle"             // 每完成一轮签到获得的积分，0为不奖励
+	KeyPointsRedeemSupplement = "points.redeem_supplement" // 兑换一次免费补签所需积分，0为不开放兑换
+	KeyPointsRedeemGift       = "points.redeem_gift"       // 兑换一份额外福利所需积分，0为不开放兑换
+
+	KeyBadgeDefinitions = "badge.definitions" // 徽章定义列表，未配置时使用内置徽章
+
 	KeyTaskEnable                          = "task.enable"
 	KeyTaskCheckinExpiredTime              = "task.checkin_expired_time"
 	KeyTaskCheckinExpiredTimeStartInterval = "task.checkin_expired_time_start_interval"
+	KeyTaskWelfareRemindInterval           = "task.welfare_remind_interval" // 福利过期提醒任务的执行周期
+	KeyTaskWelfareRemindBefore             = "task.welfare_remind_before"   // 福利过期前多少分钟提醒，可配置多个，每个时间点最多提醒一次
 )
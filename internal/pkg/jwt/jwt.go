@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -8,10 +9,18 @@ import (
 	"github.com/spf13/viper"
 
 	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/util"
 )
 
 var sign = []byte(viper.GetString(config.KeyJWTSign))
 
+// token 角色
+const (
+	RoleCustomer = "customer" // 客户
+	RoleMerchant = "merchant" // 商户
+	RoleAdmin    = "admin"    // 后台管理员
+)
+
 // TokenParames 生成的token中含有的参数
 type TokenParames struct {
 	jwt.StandardClaims
@@ -19,23 +28,73 @@ type TokenParames struct {
 	UID    uint64 // uid
 	Name   string // 姓名
 	Mobile string // 手机号
+	Role   string // 角色
+
+	StaffID   uint64 `json:",omitempty"` // 商户员工ID，仅商户token
+	StaffRole string `json:",omitempty"` // 商户员工角色，仅商户token
+}
+
+// HasRole token是否属于指定角色之一，同时校验audience与角色一致
+func (t *TokenParames) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if t.Role == role && t.VerifyAudience(Audience(role), true) {
+			return true
+		}
+	}
+	return false
+}
+
+// Audience 角色对应的token audience
+func Audience(role string) string {
+	return viper.GetString(config.KeyJWTIssuer) + ":" + role
+}
+
+// AccessExpire access token有效期
+func AccessExpire() time.Duration {
+	return viper.GetDuration(config.KeyJWTAccessExpire) * time.Minute
 }
 
-// CreateToken 生成Token
-func CreateToken(uid uint64, name, mobile string) (string, error) {
-	tokenClaims := jwt.NewWithClaims(jwt.SigningMethodHS256, TokenParames{
+// RefreshExpire refresh token有效期
+func RefreshExpire() time.Duration {
+	return viper.GetDuration(config.KeyJWTExpire) * time.Hour
+}
+
+// CreateToken 生成短期有效的access token
+func CreateToken(uid uint64, name, mobile, role string) (string, error) {
+	return CreateTokenWithParams(TokenParames{
 		UID:    uid,
 		Name:   name,
 		Mobile: mobile,
-		StandardClaims: jwt.StandardClaims{
-			Issuer:    viper.GetString(config.KeyJWTIssuer),
-			ExpiresAt: time.Now().Add(viper.GetDuration(config.KeyJWTExpire) * time.Hour).Unix(),
-			IssuedAt:  time.Now().Unix(),
-		},
+		Role:   role,
 	})
+}
+
+// CreateTokenWithParams 根据传入的参数生成短期有效的access token，StandardClaims由该方法填充
+func CreateTokenWithParams(params TokenParames) (string, error) {
+	jti, err := util.NewV4()
+	if err != nil {
+		return "", err
+	}
+	params.StandardClaims = jwt.StandardClaims{
+		Id:        jti.String(),
+		Audience:  Audience(params.Role),
+		Issuer:    viper.GetString(config.KeyJWTIssuer),
+		ExpiresAt: time.Now().Add(AccessExpire()).Unix(),
+		IssuedAt:  time.Now().Unix(),
+	}
+	tokenClaims := jwt.NewWithClaims(jwt.SigningMethodHS256, params)
 	return tokenClaims.SignedString(sign)
 }
 
+// CreateRefreshToken 生成refresh token，不包含任何信息，会话信息保存在服务端
+func CreateRefreshToken() (string, error) {
+	uid, err := util.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(uid.String(), "-", "", -1), nil
+}
+
 // ParseToken 解析Token
 func ParseToken(token string) (*TokenParames, error) {
 	// 基于公钥验证Token合法性
@@ -6,6 +6,29 @@ import (
 )
 
 func TestCreateToken(t *testing.T) {
-	got, _ := CreateToken(1, "NPC", "")
+	got, _ := CreateToken(1, "NPC", "", RoleAdmin)
 	fmt.Println(got)
 }
+
+func TestParseTokenRole(t *testing.T) {
+	token, err := CreateToken(3, "NPC", "", RoleCustomer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := ParseToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !params.HasRole(RoleCustomer) {
+		t.Errorf("token should have role %s", RoleCustomer)
+	}
+	if params.HasRole(RoleMerchant, RoleAdmin) {
+		t.Errorf("customer token should not have role %s or %s", RoleMerchant, RoleAdmin)
+	}
+
+	// 角色与audience不一致时视为无效
+	params.Role = RoleMerchant
+	if params.HasRole(RoleMerchant) {
+		t.Errorf("token with mismatched audience should be rejected")
+	}
+}
@@ -0,0 +1,24 @@
+package notify
+
+import "context"
+
+// InboxFunc 保存站内信
+type InboxFunc func(ctx context.Context, msg *Message) error
+
+// Inbox 站内信通知
+type Inbox struct {
+	save InboxFunc
+}
+
+// NewInbox 创建站内信通知实例
+func NewInbox(save InboxFunc) *Inbox {
+	return &Inbox{save: save}
+}
+
+// Notify 保存站内信，没有客户ID或内容时跳过
+func (i *Inbox) Notify(ctx context.Context, msg *Message) error {
+	if msg.CustomerID == 0 || msg.Content == "" {
+		return ErrSkipped
+	}
+	return i.save(ctx, msg)
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/sms"
+)
+
+// 通知渠道
+const (
+	ChannelSMS    = "sms"    // 短信
+	ChannelWeChat = "wechat" // 微信模板消息
+	ChannelInbox  = "inbox"  // 站内信
+)
+
+// ErrSkipped 渠道无法送达该接收人，如未绑定手机号或模板未配置
+var ErrSkipped = errors.New("notify: skipped")
+
+// Message 通知内容，各渠道只使用自己需要的字段
+type Message struct {
+	CustomerID uint64            // 客户ID，站内信使用
+	Mobile     string            // 手机号，短信使用
+	OpenID     string            // 微信openid，模板消息使用
+	Template   string            // 模板名，对应配置 sms.templates.<模板名> 与 notify.wechat_templates.<模板名>
+	Params     map[string]string // 模板参数，key 值全小写
+	Title      string            // 标题，站内信使用
+	Content    string            // 内容，站内信使用
+	URL        string            // 点击通知后跳转的链接，模板消息使用
+}
+
+// Notifier 通知发送接口
+type Notifier interface {
+	// Notify 发送通知，无法送达该接收人时返回 ErrSkipped
+	Notify(ctx context.Context, msg *Message) error
+}
+
+// Multi 依次通过多个渠道发送通知
+type Multi []Notifier
+
+// Notify 通过所有渠道发送通知，任一渠道发送成功即视为成功
+// 每个渠道的发送错误都会记录日志，所有渠道都未发送成功时返回合并后的错误
+func (m Multi) Notify(ctx context.Context, msg *Message) error {
+	var errs []string
+	sent := false
+	for _, n := range m {
+		err := n.Notify(ctx, msg)
+		switch {
+		case err == nil:
+			sent = true
+		case err != ErrSkipped:
+			channel := fmt.Sprintf("%T", n)
+			log.Warn(ctx, "notify channel error", zap.String("channel", channel), zap.String("template", msg.Template), zap.Error(err))
+			errs = append(errs, channel+": "+err.Error())
+		}
+	}
+	if sent {
+		return nil
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return ErrSkipped
+}
+
+// New 根据配置 notify.channels 创建通知实例
+// wxToken 为获取公众号access_token的方法，inbox 为保存站内信的方法
+func New(sender sms.Sender, wxToken TokenFunc, inbox InboxFunc) (Notifier, error) {
+	var m Multi
+	for _, channel := range viper.GetStringSlice(config.KeyNotifyChannels) {
+		switch channel {
+		case ChannelSMS:
+			m = append(m, NewSMS(sender))
+		case ChannelWeChat:
+			m = append(m, NewWeChat(wxToken))
+		case ChannelInbox:
+			m = append(m, NewInbox(inbox))
+		default:
+			return nil, errors.Errorf("unknown notify channel %s", channel)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/sms"
+)
+
+type notifierFunc func(ctx context.Context, msg *Message) error
+
+func (f notifierFunc) Notify(ctx context.Context, msg *Message) error {
+	return f(ctx, msg)
+}
+
+func TestSMS(t *testing.T) {
+	viper.Set(config.KeySMSTemplates+".welfare_expiring", "SMS_2")
+	defer viper.Set(config.KeySMSTemplates+".welfare_expiring", "")
+
+	r := sms.NewRecorder()
+	n := NewSMS(r)
+	msg := &Message{Mobile: "13800000000", Template: sms.TemplateWelfareExpiring, Params: map[string]string{"num": "2"}}
+	if err := n.Notify(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	last, ok := r.Last("13800000000")
+	if !ok || last.TemplateCode != "SMS_2" || last.TemplateValue["num"] != "2" {
+		t.Errorf("unexpected message %+v", last)
+	}
+
+	if err := n.Notify(context.Background(), &Message{Template: sms.TemplateWelfareExpiring}); err != ErrSkipped {
+		t.Errorf("got %v, want ErrSkipped without mobile", err)
+	}
+	if err := n.Notify(context.Background(), &Message{Mobile: "13800000000", Template: "unknown"}); err != ErrSkipped {
+		t.Errorf("got %v, want ErrSkipped without template", err)
+	}
+}
+
+func TestWeChat(t *testing.T) {
+	viper.Set(config.KeyNotifyWeChatTemplates+".welfare_expiring", "TPL_1")
+	defer viper.Set(config.KeyNotifyWeChatTemplates+".welfare_expiring", "")
+
+	var got wechatTemplateMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("access_token") != "token" {
+			w.Write([]byte(`{"errcode":40001,"errmsg":"invalid credential"}`))
+			return
+		}
+		json.NewDecoder(req.Body).Decode(&got)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer srv.Close()
+
+	token := "token"
+	n := NewWeChat(func(ctx context.Context) (string, error) { return token, nil })
+	n.url = srv.URL + "/?access_token="
+	msg := &Message{OpenID: "openid", Template: "welfare_expiring", Params: map[string]string{"num": "2"}}
+	if err := n.Notify(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if got.ToUser != "openid" || got.TemplateID != "TPL_1" || got.Data["num"].Value != "2" {
+		t.Errorf("unexpected template message %+v", got)
+	}
+
+	token = "expired"
+	if err := n.Notify(context.Background(), msg); err == nil {
+		t.Error("want error when wechat returns errcode")
+	}
+	if err := n.Notify(context.Background(), &Message{Template: "welfare_expiring"}); err != ErrSkipped {
+		t.Errorf("got %v, want ErrSkipped without openid", err)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	skip := notifierFunc(func(ctx context.Context, msg *Message) error { return ErrSkipped })
+	fail := notifierFunc(func(ctx context.Context, msg *Message) error { return errors.New("fail") })
+	sent := 0
+	ok := notifierFunc(func(ctx context.Context, msg *Message) error { sent++; return nil })
+
+	tests := []struct {
+		name string
+		m    Multi
+		want error
+	}{
+		{"empty", Multi{}, ErrSkipped},
+		{"all skipped", Multi{skip, skip}, ErrSkipped},
+		{"one sent", Multi{fail, skip, ok}, nil},
+	}
+	for _, tt := range tests {
+		if err := tt.m.Notify(context.Background(), &Message{}); err != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if sent != 1 {
+		t.Errorf("got %d sent, want 1", sent)
+	}
+	if err := (Multi{fail, skip, fail}).Notify(context.Background(), &Message{}); err == nil || err == ErrSkipped {
+		t.Errorf("got %v, want failure", err)
+	} else if got := strings.Count(err.Error(), "fail"); got != 2 {
+		t.Errorf("got %q, want both channel errors", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	defer viper.Set(config.KeyNotifyChannels, nil)
+
+	viper.Set(config.KeyNotifyChannels, []string{ChannelSMS, ChannelWeChat, ChannelInbox})
+	n, err := New(sms.NewRecorder(), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := n.(Multi); len(m) != 3 {
+		t.Errorf("got %d channels, want 3", len(m))
+	}
+
+	viper.Set(config.KeyNotifyChannels, []string{"email"})
+	if _, err := New(sms.NewRecorder(), nil, nil); err == nil {
+		t.Error("want error for unknown channel")
+	}
+}
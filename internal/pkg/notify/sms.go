@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"context"
+
+	"welfare-sign/internal/pkg/sms"
+)
+
+// SMS 短信通知
+type SMS struct {
+	sender sms.Sender
+}
+
+// NewSMS 创建短信通知实例
+func NewSMS(sender sms.Sender) *SMS {
+	return &SMS{sender: sender}
+}
+
+// Notify 发送短信，未绑定手机号或短信模板未配置时跳过
+func (s *SMS) Notify(ctx context.Context, msg *Message) error {
+	code := sms.TemplateCode(msg.Template)
+	if msg.Mobile == "" || code == "" {
+		return ErrSkipped
+	}
+	return s.sender.Send(msg.Mobile, code, msg.Params)
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/tidwall/gjson"
+
+	"welfare-sign/internal/pkg/config"
+)
+
+// wechatTemplateURL 公众号发送模板消息接口
+const wechatTemplateURL = "https://api.weixin.qq.com/cgi-bin/message/template/send?access_token="
+
+// TokenFunc 获取公众号access_token
+type TokenFunc func(ctx context.Context) (string, error)
+
+// WeChat 微信公众号模板消息通知
+type WeChat struct {
+	token  TokenFunc
+	url    string
+	client *http.Client
+}
+
+// NewWeChat 创建模板消息通知实例
+func NewWeChat(token TokenFunc) *WeChat {
+	return &WeChat{
+		token:  token,
+		url:    wechatTemplateURL,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type wechatValue struct {
+	Value string `json:"value"`
+}
+
+type wechatTemplateMessage struct {
+	ToUser     string                 `json:"touser"`
+	TemplateID string                 `json:"template_id"`
+	URL        string                 `json:"url,omitempty"`
+	Data       map[string]wechatValue `json:"data"`
+}
+
+// Notify 发送模板消息，未关联openid或模板未配置时跳过
+// 模板参数按名称对应模板中的关键词
+func (w *WeChat) Notify(ctx context.Context, msg *Message) error {
+	templateID := viper.GetString(config.KeyNotifyWeChatTemplates + "." + msg.Template)
+	if msg.OpenID == "" || templateID == "" {
+		return ErrSkipped
+	}
+	token, err := w.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := make(map[string]wechatValue, len(msg.Params))
+	for k, v := range msg.Params {
+		data[k] = wechatValue{Value: v}
+	}
+	body, err := json.Marshal(wechatTemplateMessage{
+		ToUser:     msg.OpenID,
+		TemplateID: templateID,
+		URL:        msg.URL,
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.url+token, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return err
+	}
+	if code := gjson.GetBytes(buf.Bytes(), "errcode").Int(); code != 0 {
+		return errors.Errorf("wechat template message error %d: %s", code, gjson.GetBytes(buf.Bytes(), "errmsg").String())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package sigtoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// 校验失败的错误
+var (
+	ErrInvalid = errors.New("sigtoken: invalid token")
+	ErrExpired = errors.New("sigtoken: token expired")
+)
+
+// Payload 签名token中的数据
+type Payload struct {
+	Type      string `json:"t"`           // token用途，防止不同用途的token互相冒用
+	Subject   uint64 `json:"s"`           // 绑定的对象ID，如客户ID
+	Nonce     string `json:"n,omitempty"` // 随机数，配合服务端存储实现一次性使用
+	ExpiresAt int64  `json:"e"`           // 过期时间，unix时间戳
+}
+
+var encoding = base64.RawURLEncoding
+
+// Sign 生成签名token，格式为 base64(payload).base64(hmac-sha256)
+func Sign(key []byte, payload Payload) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	body := encoding.EncodeToString(data)
+	return body + "." + encoding.EncodeToString(sum(key, body)), nil
+}
+
+// Verify 校验token签名、用途及有效期，返回token中的数据
+func Verify(key []byte, token, typ string, now time.Time) (*Payload, error) {
+	i := strings.IndexByte(token, '.')
+	if i <= 0 {
+		return nil, ErrInvalid
+	}
+	body := token[:i]
+	sig, err := encoding.DecodeString(token[i+1:])
+	if err != nil || !hmac.Equal(sig, sum(key, body)) {
+		return nil, ErrInvalid
+	}
+	data, err := encoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	var payload Payload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, ErrInvalid
+	}
+	if payload.Type != typ {
+		return nil, ErrInvalid
+	}
+	if now.Unix() >= payload.ExpiresAt {
+		return nil, ErrExpired
+	}
+	return &payload, nil
+}
+
+func sum(key []byte, body string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,36 @@
+package sigtoken
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	key := []byte("secret")
+	now := time.Now()
+	token, err := Sign(key, Payload{Type: "writeoff", Subject: 42, Nonce: "abc", ExpiresAt: now.Add(time.Minute).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := Verify(key, token, "writeoff", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.Subject != 42 || payload.Nonce != "abc" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	if _, err := Verify([]byte("other"), token, "writeoff", now); err != ErrInvalid {
+		t.Errorf("wrong key: got %v, want ErrInvalid", err)
+	}
+	if _, err := Verify(key, token, "invite", now); err != ErrInvalid {
+		t.Errorf("wrong type: got %v, want ErrInvalid", err)
+	}
+	if _, err := Verify(key, token, "writeoff", now.Add(2*time.Minute)); err != ErrExpired {
+		t.Errorf("expired: got %v, want ErrExpired", err)
+	}
+	if _, err := Verify(key, "x"+token, "writeoff", now); err != ErrInvalid {
+		t.Errorf("tampered: got %v, want ErrInvalid", err)
+	}
+}
@@ -0,0 +1,36 @@
+package sms
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+)
+
+// LogSender 不真正发送短信，只记录到文件或标准输出，开发环境使用
+type LogSender struct {
+	logger *log.Logger
+}
+
+// NewLogSender 创建记录短信的实例，path为空时输出到标准输出
+func NewLogSender(path string) (*LogSender, error) {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		w = f
+	}
+	return &LogSender{logger: log.New(w, "[sms] ", log.LstdFlags)}, nil
+}
+
+// Send 记录短信内容
+func (s *LogSender) Send(mobile string, templateCode string, templateValue map[string]string) error {
+	param, err := json.Marshal(templateValue)
+	if err != nil {
+		return err
+	}
+	s.logger.Printf("mobile=%s template=%s param=%s", mobile, templateCode, param)
+	return nil
+}
@@ -0,0 +1,63 @@
+package sms
+
+import "sync"
+
+// Message 已发送的短信
+type Message struct {
+	Mobile        string
+	TemplateCode  string
+	TemplateValue map[string]string
+}
+
+// Recorder 将短信保存在内存中，测试使用
+type Recorder struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewRecorder 创建内存短信记录器
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Send 保存短信
+func (r *Recorder) Send(mobile string, templateCode string, templateValue map[string]string) error {
+	value := make(map[string]string, len(templateValue))
+	for k, v := range templateValue {
+		value[k] = v
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, Message{
+		Mobile:        mobile,
+		TemplateCode:  templateCode,
+		TemplateValue: value,
+	})
+	return nil
+}
+
+// Messages 获取所有已保存的短信
+func (r *Recorder) Messages() []Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Message(nil), r.messages...)
+}
+
+// Last 获取发送给某手机号的最后一条短信
+func (r *Recorder) Last(mobile string) (Message, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.messages) - 1; i >= 0; i-- {
+		if r.messages[i].Mobile == mobile {
+			return r.messages[i], true
+		}
+	}
+	return Message{}, false
+}
+
+// Reset 清空已保存的短信
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = nil
+}
@@ -0,0 +1,26 @@
+package sms
+
+import "testing"
+
+func TestRecorder(t *testing.T) {
+	var s Sender = NewRecorder()
+	if err := s.Send("13800000000", "SMS_1", map[string]string{"code": "1234"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send("13800000001", "SMS_1", map[string]string{"code": "5678"}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := s.(*Recorder)
+	if n := len(r.Messages()); n != 2 {
+		t.Fatalf("got %d messages, want 2", n)
+	}
+	msg, ok := r.Last("13800000000")
+	if !ok || msg.TemplateValue["code"] != "1234" {
+		t.Errorf("unexpected last message %+v", msg)
+	}
+	r.Reset()
+	if _, ok := r.Last("13800000000"); ok {
+		t.Error("messages should be empty after reset")
+	}
+}
@@ -1,55 +1,35 @@
 package sms
 
 import (
-	"encoding/json"
-
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
-	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
 	"welfare-sign/internal/pkg/config"
 )
 
+// 短信服务提供方
 const (
-	poolSize         = 2 // 最大并发数
-	maxTaskQueueSize = 5 // 可缓存的最大请求数
+	ProviderAliyun = "aliyun" // 阿里云短信
+	ProviderLog    = "log"    // 仅记录到日志或文件，开发环境使用
+	ProviderMemory = "memory" // 保存在内存中，测试使用
 )
 
-var client *sdk.Client
-
-func init() {
-	var err error
-	client, err = sdk.NewClientWithAccessKey(viper.GetString(config.KeySMSRegion), viper.GetString(config.KeySMSAK), viper.GetString(config.KeySMSAS))
-	if err != nil {
-		panic(errors.WithMessage(err, "sms client init error"))
-	}
-	client.EnableAsync(poolSize, maxTaskQueueSize)
+// Sender 短信发送接口
+type Sender interface {
+	// Send 发送短信，templateCode 为短信服务商的模板编号，templateValue 中 key 值全小写
+	Send(mobile string, templateCode string, templateValue map[string]string) error
 }
 
-// Send send sms
-// templateValue 中 key 值全小写
-// 模版地址：https://dysms.console.aliyun.com/dysms.htm?spm=5176.2020520001.106.d20dysms.27c24bd3dNl0g6#/domestic/text/template
-func Send(mobile string, templateName string, templateValue map[string]string) error {
-	param, err := json.Marshal(templateValue)
-	if err != nil {
-		return err
-	}
-
-	request := requests.NewCommonRequest()
-	request.Method = "POST"
-	request.Scheme = "https" // https | http
-	request.Domain = viper.GetString(config.KeySMSDomain)
-	request.Version = "2017-05-25"
-	request.ApiName = "SendSms"
-	request.QueryParams["RegionId"] = viper.GetString(config.KeySMSRegion)
-	request.QueryParams["PhoneNumbers"] = mobile
-	request.QueryParams["SignName"] = viper.GetString(config.KeySMSSignName)
-	request.QueryParams["TemplateCode"] = templateName
-	request.QueryParams["TemplateParam"] = string(param)
-
-	if _, err = client.ProcessCommonRequest(request); err != nil {
-		return err
+// New 根据配置 sms.provider 创建短信发送实例，默认为阿里云
+func New() (Sender, error) {
+	switch provider := viper.GetString(config.KeySMSProvider); provider {
+	case "", ProviderAliyun:
+		return NewAliyunSender()
+	case ProviderLog:
+		return NewLogSender(viper.GetString(config.KeySMSLogFile))
+	case ProviderMemory:
+		return NewRecorder(), nil
+	default:
+		return nil, errors.Errorf("unknown sms provider %s", provider)
 	}
-	return nil
 }
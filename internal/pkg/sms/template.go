@@ -0,0 +1,24 @@
+package sms
+
+import (
+	"github.com/spf13/viper"
+
+	"welfare-sign/internal/pkg/config"
+)
+
+// 短信模板名，对应配置 sms.templates.<模板名>
+const (
+	TemplateLoginCode       = "login_code"       // 验证码，参数 code
+	TemplateWelfareClaimed  = "welfare_claimed"  // 福利领取成功，参数 merchant、num
+	TemplateWelfareExpiring = "welfare_expiring" // 福利即将过期，参数 merchant、num、time
+)
+
+// TemplateCode 获取模板名对应的服务商模板编号
+// 验证码模板未配置时兼容旧的 sms.template 配置
+func TemplateCode(name string) string {
+	code := viper.GetString(config.KeySMSTemplates + "." + name)
+	if code == "" && name == TemplateLoginCode {
+		code = viper.GetString(config.KeySMSTemplate)
+	}
+	return code
+}
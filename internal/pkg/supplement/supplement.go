@@ -0,0 +1,124 @@
+package supplement
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"welfare-sign/internal/pkg/config"
+)
+
+// 补签方式
+const (
+	TypeHelp   = "help"   // 好友帮签
+	TypePay    = "pay"    // 付费补签
+	TypeCredit = "credit" // 使用邀请奖励的免费补签次数
+)
+
+// 不允许补签的原因
+var (
+	ErrNothingToMakeUp     = errors.New("supplement: no missed day can be made up")
+	ErrCycleLimit          = errors.New("supplement: make-up days limit of this cycle reached")
+	ErrHelperDailyLimit    = errors.New("supplement: helper daily limit reached")
+	ErrHelperTargetLimit   = errors.New("supplement: helper already helped this customer in this cycle")
+	ErrPayDisabled         = errors.New("supplement: paid make-up is disabled")
+	ErrMakeUpBeforeCheckin = errors.New("supplement: missed days must be made up before check-in")
+)
+
+// Policy 补签规则，各项限制为0时表示不限制
+type Policy struct {
+	MaxDaysPerCycle     int  // 每轮签到最多可补签的天数
+	HelperDailyLimit    int  // 每个用户每天最多可帮他人补签的次数
+	HelpsPerTarget      int  // 每轮签到中同一个用户最多可帮同一人补签的次数
+	WindowDays          int  // 只能补签最近多少天内漏签的记录
+	PayEnabled          bool // 是否允许付费补签
+	MakeUpBeforeCheckin bool // 有漏签未补时是否禁止当天签到
+}
+
+// New 根据配置 supplement.* 创建补签规则
+func New() Policy {
+	return Policy{
+		MaxDaysPerCycle:     viper.GetInt(config.KeySupplementMaxDays),
+		HelperDailyLimit:    viper.GetInt(config.KeySupplementHelperDailyLimit),
+		HelpsPerTarget:      viper.GetInt(config.KeySupplementHelpsPerTarget),
+		WindowDays:          viper.GetInt(config.KeySupplementWindowDays),
+		PayEnabled:          viper.GetBool(config.KeySupplementPayEnable),
+		MakeUpBeforeCheckin: viper.GetBool(config.KeySupplementBeforeCheckin),
+	}
+}
+
+// Eligible 返回漏签日期中仍在补签期限内的日期，按时间正序
+// missed 为漏签记录需要签到的时间，today 为当前业务日的开始时刻
+func (p Policy) Eligible(missed []time.Time, today time.Time) []time.Time {
+	var earliest time.Time
+	if p.WindowDays > 0 {
+		earliest = today.AddDate(0, 0, -p.WindowDays)
+	}
+	res := make([]time.Time, 0, len(missed))
+	for _, day := range missed {
+		if day.Before(today) && !day.Before(earliest) {
+			res = append(res, day)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Before(res[j]) })
+	return res
+}
+
+// Remaining 本轮签到还可补签的天数，supplemented 为本轮已补签的天数，eligible 为可补签的漏签天数
+func (p Policy) Remaining(supplemented, eligible int) int {
+	if p.MaxDaysPerCycle > 0 && eligible > p.MaxDaysPerCycle-supplemented {
+		eligible = p.MaxDaysPerCycle - supplemented
+	}
+	if eligible < 0 {
+		return 0
+	}
+	return eligible
+}
+
+// CheckHelp 校验好友能否帮签一天
+// helperToday 为帮签人今天已帮签的次数，helperTarget 为帮签人本轮已帮该用户补签的次数
+func (p Policy) CheckHelp(supplemented, eligible, helperToday, helperTarget int) error {
+	if p.HelpsPerTarget > 0 && helperTarget >= p.HelpsPerTarget {
+		return ErrHelperTargetLimit
+	}
+	if p.HelperDailyLimit > 0 && helperToday >= p.HelperDailyLimit {
+		return ErrHelperDailyLimit
+	}
+	return p.checkRemaining(supplemented, eligible)
+}
+
+// PayDays 返回本次可付费补签的天数
+func (p Policy) PayDays(supplemented, eligible int) (int, error) {
+	if !p.PayEnabled {
+		return 0, ErrPayDisabled
+	}
+	if err := p.checkRemaining(supplemented, eligible); err != nil {
+		return 0, err
+	}
+	return p.Remaining(supplemented, eligible), nil
+}
+
+// CheckCredit 校验能否使用免费补签次数补签一天
+func (p Policy) CheckCredit(supplemented, eligible int) error {
+	return p.checkRemaining(supplemented, eligible)
+}
+
+// CheckCheckin 校验有漏签记录时能否进行当天签到，missed 为本轮未补签的漏签天数
+func (p Policy) CheckCheckin(missed int) error {
+	if p.MakeUpBeforeCheckin && missed > 0 {
+		return ErrMakeUpBeforeCheckin
+	}
+	return nil
+}
+
+func (p Policy) checkRemaining(supplemented, eligible int) error {
+	if eligible == 0 {
+		return ErrNothingToMakeUp
+	}
+	if p.Remaining(supplemented, eligible) == 0 {
+		return ErrCycleLimit
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package supplement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEligible(t *testing.T) {
+	today := time.Date(2019, 10, 10, 0, 0, 0, 0, time.UTC)
+	missed := []time.Time{today.AddDate(0, 0, -1), today.AddDate(0, 0, -5), today.AddDate(0, 0, -2), today}
+
+	if got := (Policy{}).Eligible(missed, today); len(got) != 3 || !got[0].Equal(today.AddDate(0, 0, -5)) {
+		t.Errorf("no window: got %v", got)
+	}
+	if got := (Policy{WindowDays: 2}).Eligible(missed, today); len(got) != 2 || !got[0].Equal(today.AddDate(0, 0, -2)) {
+		t.Errorf("window 2: got %v", got)
+	}
+}
+
+func TestCheckHelp(t *testing.T) {
+	p := Policy{MaxDaysPerCycle: 2, HelperDailyLimit: 3, HelpsPerTarget: 1}
+
+	cases := []struct {
+		supplemented, eligible, helperToday, helperTarget int
+		want                                              error
+	}{
+		{0, 1, 0, 0, nil},
+		{0, 0, 0, 0, ErrNothingToMakeUp},
+		{2, 1, 0, 0, ErrCycleLimit},
+		{0, 1, 3, 0, ErrHelperDailyLimit},
+		{0, 1, 0, 1, ErrHelperTargetLimit},
+	}
+	for _, c := range cases {
+		if err := p.CheckHelp(c.supplemented, c.eligible, c.helperToday, c.helperTarget); err != c.want {
+			t.Errorf("CheckHelp(%d, %d, %d, %d) = %v, want %v", c.supplemented, c.eligible, c.helperToday, c.helperTarget, err, c.want)
+		}
+	}
+	if err := (Policy{}).CheckHelp(10, 1, 100, 100); err != nil {
+		t.Errorf("unlimited policy: got %v", err)
+	}
+}
+
+func TestPayDays(t *testing.T) {
+	if _, err := (Policy{}).PayDays(0, 1); err != ErrPayDisabled {
+		t.Errorf("pay disabled: got %v", err)
+	}
+	p := Policy{PayEnabled: true, MaxDaysPerCycle: 3}
+	if days, err := p.PayDays(1, 5); err != nil || days != 2 {
+		t.Errorf("PayDays(1, 5) = %d, %v, want 2", days, err)
+	}
+	if _, err := p.PayDays(3, 1); err != ErrCycleLimit {
+		t.Errorf("PayDays(3, 1): got %v, want ErrCycleLimit", err)
+	}
+}
+
+func TestCheckCheckin(t *testing.T) {
+	if err := (Policy{MakeUpBeforeCheckin: true}).CheckCheckin(1); err != ErrMakeUpBeforeCheckin {
+		t.Errorf("got %v", err)
+	}
+	if err := (Policy{}).CheckCheckin(1); err != nil {
+		t.Errorf("got %v", err)
+	}
+}
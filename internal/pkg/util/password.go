@@ -0,0 +1,22 @@
+package util
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword 使用bcrypt生成带盐的密码哈希
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hashed), err
+}
+
+// IsPasswordHashed 判断存储的密码是否已经是bcrypt哈希值
+func IsPasswordHashed(stored string) bool {
+	_, err := bcrypt.Cost([]byte(stored))
+	return err == nil
+}
+
+// CheckPassword 校验明文密码与bcrypt哈希值是否匹配
+func CheckPassword(hashed, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil
+}
@@ -0,0 +1,26 @@
+package util
+
+import "testing"
+
+func TestHashPassword(t *testing.T) {
+	hashed, err := HashPassword("123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsPasswordHashed(hashed) {
+		t.Errorf("%s should be hashed", hashed)
+	}
+	if !CheckPassword(hashed, "123456") {
+		t.Error("password should match")
+	}
+	if CheckPassword(hashed, "1234567") {
+		t.Error("wrong password should not match")
+	}
+	if IsPasswordHashed("123456") {
+		t.Error("plaintext password should not be treated as hashed")
+	}
+	// 明文存储的密码不再允许登录
+	if CheckPassword("123456", "123456") {
+		t.Error("plaintext password should not match")
+	}
+}
@@ -14,6 +14,8 @@ func init() {
 	APICodeMapZH[APICodeInvalidParame] = "参数验证未通过"
 	APICodeMapZH[APICodeNoPermission] = "无权访问"
 	APICodeMapZH[APICodeDBError] = "数据库处理异常"
+	APICodeMapZH[APICodeIdempotencyConflict] = "请求正在处理中，请勿重复提交"
+	APICodeMapZH[APICodeIdempotencyMismatch] = "Idempotency-Key 已用于参数不同的请求"
 }
 
 // api code define
@@ -24,4 +26,7 @@ const (
 	APICodeInvalidParame APICode = "INVALID_PARAME"
 	APICodeNoPermission  APICode = "NO_PERMISSION"
 	APICodeDBError       APICode = "DB_ERROR"
+
+	APICodeIdempotencyConflict APICode = "IDEMPOTENCY_CONFLICT"
+	APICodeIdempotencyMismatch APICode = "IDEMPOTENCY_MISMATCH"
 )
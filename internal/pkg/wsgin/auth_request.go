@@ -48,8 +48,9 @@ func authFunc(c *gin.Context) (*jwt.TokenParames, APICode, error) {
 		return nil, APICodeSuccess, nil
 	}
 	tokenParams, err := jwt.ParseToken(token)
-	if err != nil {
+	if err != nil || tokenRevoked(c, tokenParams) {
 		return nil, APICodeSuccess, nil
 	}
+	c.Set(tokenParamsKey, tokenParams)
 	return tokenParams, APICodeSuccess, nil
 }
@@ -12,6 +12,11 @@ type BaseResponse struct {
 	Error   string  `json:"error,omitempty"` // Error信息
 }
 
+// IsSuccess 请求是否处理成功
+func (r BaseResponse) IsSuccess() bool {
+	return r.Status
+}
+
 // NewResponse 根据业务状态码和err信息创建新的结构返回
 func NewResponse(ctx context.Context, code APICode, err error) BaseResponse {
 	if err == nil && (code == APICodeSuccess || code == APICodeDefault) {
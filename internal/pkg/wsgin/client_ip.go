@@ -0,0 +1,78 @@
+package wsgin
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// HeaderForwardedFor 代理转发的客户端IP请求头
+const HeaderForwardedFor = "X-Forwarded-For"
+
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies 设置受信任的代理，支持IP及CIDR，只有来自这些代理的请求才读取 X-Forwarded-For
+func SetTrustedProxies(proxies []string) error {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return errors.Errorf("受信任的代理%s无效", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return errors.WithMessagef(err, "受信任的代理%s无效", proxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	trustedProxies = nets
+	return nil
+}
+
+// ClientIP 获取客户端IP，直连地址不是受信任的代理时忽略 X-Forwarded-For，
+// 否则从右往左取第一个不是受信任代理的地址
+func ClientIP(c *gin.Context) string {
+	remoteIP, _, err := net.SplitHostPort(strings.TrimSpace(c.Request.RemoteAddr))
+	if err != nil {
+		remoteIP = strings.TrimSpace(c.Request.RemoteAddr)
+	}
+	if !isTrustedProxy(remoteIP) {
+		return remoteIP
+	}
+	hops := strings.Split(c.GetHeader(HeaderForwardedFor), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		if !isTrustedProxy(hop) {
+			return hop
+		}
+	}
+	return remoteIP
+}
+
+// isTrustedProxy 是否是受信任的代理
+func isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
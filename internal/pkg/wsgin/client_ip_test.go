@@ -0,0 +1,52 @@
+package wsgin
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestClientIP(t *testing.T) {
+	if err := SetTrustedProxies([]string{"10.0.0.1", "172.16.0.0/12"}); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTrustedProxies(nil)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"direct", "1.2.3.4:5678", "", "1.2.3.4"},
+		{"untrusted proxy is ignored", "1.2.3.4:5678", "9.9.9.9", "1.2.3.4"},
+		{"trusted proxy", "10.0.0.1:80", "9.9.9.9", "9.9.9.9"},
+		{"spoofed leftmost hop", "10.0.0.1:80", "8.8.8.8, 9.9.9.9", "9.9.9.9"},
+		{"trusted hops are skipped", "10.0.0.1:80", "9.9.9.9, 172.16.3.4", "9.9.9.9"},
+		{"invalid hop", "10.0.0.1:80", "bad", "10.0.0.1"},
+		{"no forwarded header", "10.0.0.1:80", "", "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			c.Request.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				c.Request.Header.Set(HeaderForwardedFor, tt.forwarded)
+			}
+			if got := ClientIP(c); got != tt.want {
+				t.Errorf("ClientIP() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTrustedProxiesInvalid(t *testing.T) {
+	defer SetTrustedProxies(nil)
+	for _, proxy := range []string{"bad", "10.0.0.0/33"} {
+		if err := SetTrustedProxies([]string{proxy}); err == nil {
+			t.Errorf("SetTrustedProxies(%s) want error", proxy)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package wsgin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// 幂等相关请求头
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotency-Replayed"
+
+	idempotencyKeyMaxLen = 128
+	idempotencyLockTTL   = time.Minute // 请求处理中的占位有效期，防止处理异常时键一直被占用
+)
+
+// Idempotent 嵌入到请求结构体中，表示该接口支持 Idempotency-Key 请求头
+// 相同的键在有效期内重复请求时直接返回第一次成功的响应，第一次请求尚未完成时返回 APICodeIdempotencyConflict
+// 键与请求参数绑定，相同的键用于参数不同的请求时返回 APICodeIdempotencyMismatch
+type Idempotent struct{}
+
+func (Idempotent) idempotent() {}
+
+type idempotentProcess interface {
+	idempotent()
+}
+
+// IdempotencyStore 幂等键存储
+type IdempotencyStore interface {
+	// AcquireIdempotencyKey 占用幂等键并记录请求参数摘要，已被占用时返回false及占用时的参数摘要，已有保存的响应时一并返回
+	AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (bool, string, []byte, error)
+	// SaveIdempotencyResponse 保存请求成功的响应及请求参数摘要
+	SaveIdempotencyResponse(ctx context.Context, key, fingerprint string, data []byte, ttl time.Duration) error
+	// ReleaseIdempotencyKey 释放幂等键，请求失败时调用，允许客户端使用相同的键重试
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+}
+
+var (
+	idempotencyStore IdempotencyStore
+	idempotencyTTL   time.Duration
+)
+
+// SetIdempotencyStore 设置幂等键存储及成功响应的保存时间，未设置时忽略 Idempotency-Key
+func SetIdempotencyStore(store IdempotencyStore, ttl time.Duration) {
+	idempotencyStore = store
+	idempotencyTTL = ttl
+}
+
+// idempotentExec 支持幂等键的请求处理，返回false表示不需要幂等处理
+func idempotentExec(c *gin.Context, ctx context.Context, req Process) bool {
+	if _, ok := req.(idempotentProcess); !ok || idempotencyStore == nil {
+		return false
+	}
+	key := c.GetHeader(HeaderIdempotencyKey)
+	if key == "" {
+		return false
+	}
+	if len(key) > idempotencyKeyMaxLen {
+		response(c, NewResponse(ctx, APICodeInvalidParame, errors.New("Idempotency-Key too long")))
+		return true
+	}
+
+	// 幂等键按用户和接口隔离
+	scope := c.Request.Method + ":" + c.Request.URL.Path + ":"
+	if token := TokenFromContext(ctx); token != nil {
+		scope += token.Role + ":" + strconv.FormatUint(token.UID, 10) + ":"
+	}
+	key = scope + key
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		response(c, NewResponse(ctx, APICodeServerError, err))
+		return true
+	}
+
+	acquired, stored, cached, err := idempotencyStore.AcquireIdempotencyKey(ctx, key, fingerprint, idempotencyLockTTL)
+	if err != nil {
+		response(c, NewResponse(ctx, APICodeServerError, err))
+		return true
+	}
+	if !acquired {
+		if stored != fingerprint {
+			response(c, NewResponse(ctx, APICodeIdempotencyMismatch, errors.New("Idempotency-Key was used with different request parameters")))
+			return true
+		}
+		if cached == nil {
+			response(c, NewResponse(ctx, APICodeIdempotencyConflict, errors.New("request with the same Idempotency-Key is in progress")))
+			return true
+		}
+		c.Header(HeaderIdempotencyReplayed, "true")
+		c.Data(http.StatusOK, "application/json; charset=utf-8", cached)
+		return true
+	}
+
+	data := req.Exec(ctx)
+	body, err := json.Marshal(data)
+	if err != nil {
+		idempotencyStore.ReleaseIdempotencyKey(ctx, key)
+		response(c, NewResponse(ctx, APICodeServerError, err))
+		return true
+	}
+	if r, ok := data.(interface{ IsSuccess() bool }); ok && r.IsSuccess() {
+		err = idempotencyStore.SaveIdempotencyResponse(ctx, key, fingerprint, body, idempotencyTTL)
+	} else {
+		err = idempotencyStore.ReleaseIdempotencyKey(ctx, key)
+	}
+	if err != nil {
+		// 保存失败不影响本次响应，占位会在 idempotencyLockTTL 后过期
+		c.Error(err)
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	return true
+}
+
+// requestFingerprint 请求参数摘要，为绑定后的请求参数JSON的sha256，需在 Exec 修改请求参数前计算
+func requestFingerprint(req Process) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
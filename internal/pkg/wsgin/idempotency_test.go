@@ -0,0 +1,76 @@
+package wsgin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type idempotencyEntry struct {
+	fingerprint string
+	data        []byte
+}
+
+type memoryIdempotencyStore map[string]idempotencyEntry
+
+func (m memoryIdempotencyStore) AcquireIdempotencyKey(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (bool, string, []byte, error) {
+	if v, ok := m[key]; ok {
+		return false, v.fingerprint, v.data, nil
+	}
+	m[key] = idempotencyEntry{fingerprint: fingerprint}
+	return true, fingerprint, nil, nil
+}
+
+func (m memoryIdempotencyStore) SaveIdempotencyResponse(ctx context.Context, key, fingerprint string, data []byte, ttl time.Duration) error {
+	m[key] = idempotencyEntry{fingerprint, data}
+	return nil
+}
+
+func (m memoryIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+type idempotentRequest struct {
+	BaseRequest
+	Idempotent
+
+	Num int `json:"num"`
+}
+
+func (r *idempotentRequest) New() Process { return &idempotentRequest{} }
+
+func (r *idempotentRequest) Exec(ctx context.Context) interface{} {
+	return NewResponse(ctx, APICodeSuccess, nil)
+}
+
+func TestIdempotentExec(t *testing.T) {
+	SetIdempotencyStore(memoryIdempotencyStore{}, time.Hour)
+	defer SetIdempotencyStore(nil, 0)
+
+	exec := func(num int) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/orders", nil)
+		c.Request.Header.Set(HeaderIdempotencyKey, "key")
+		if !idempotentExec(c, c, &idempotentRequest{Num: num}) {
+			t.Fatal("want idempotent handling")
+		}
+		return w
+	}
+
+	if w := exec(1); w.Code != http.StatusOK || w.Header().Get(HeaderIdempotencyReplayed) != "" {
+		t.Fatalf("first request: got %d replayed=%q", w.Code, w.Header().Get(HeaderIdempotencyReplayed))
+	}
+	if w := exec(1); w.Code != http.StatusOK || w.Header().Get(HeaderIdempotencyReplayed) != "true" {
+		t.Errorf("same parameters: got %d replayed=%q, want replay", w.Code, w.Header().Get(HeaderIdempotencyReplayed))
+	}
+	if w := exec(2); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), string(APICodeIdempotencyMismatch)) {
+		t.Errorf("different parameters: got %d %s, want %s", w.Code, w.Body.String(), APICodeIdempotencyMismatch)
+	}
+}
@@ -52,5 +52,9 @@ func mustAuthFunc(c *gin.Context) (*jwt.TokenParames, APICode, error) {
 	if err != nil {
 		return nil, APICodeNoPermission, err
 	}
+	if tokenRevoked(c, tokenParams) {
+		return nil, APICodeNoPermission, errors.New("token revoked")
+	}
+	c.Set(tokenParamsKey, tokenParams)
 	return tokenParams, APICodeSuccess, nil
 }
@@ -0,0 +1,41 @@
+package wsgin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/pkg/jwt"
+)
+
+// PermissionFunc 判断后台用户是否拥有某项权限
+type PermissionFunc func(ctx context.Context, uid uint64, permission string) bool
+
+var hasPermission PermissionFunc = func(ctx context.Context, uid uint64, permission string) bool {
+	return false
+}
+
+// SetPermissionFunc 设置权限校验方法，RequirePermission中间件调用
+func SetPermissionFunc(f PermissionFunc) {
+	hasPermission = f
+}
+
+// RequirePermission 路由级权限校验，必须为后台用户且拥有permission权限
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := ExtractContext(c)
+		tokenParams, code, err := mustRoleAuthFunc(c, jwt.RoleAdmin)
+		if err != nil {
+			c.Abort()
+			response(c, NewResponse(ctx, code, err))
+			return
+		}
+		if !hasPermission(ctx, tokenParams.UID, permission) {
+			c.Abort()
+			response(c, NewResponse(ctx, APICodeNoPermission, errors.Errorf("permission %s required", permission)))
+			return
+		}
+		c.Next()
+	}
+}
@@ -34,6 +34,9 @@ func ProcessExec(p Process) gin.HandlerFunc {
 		if !status {
 			return
 		}
+		if idempotentExec(c, ctx, req) {
+			return
+		}
 		data := req.Exec(ctx)
 		response(c, data)
 	}
@@ -0,0 +1,51 @@
+package wsgin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/jwt"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/util"
+)
+
+// 请求上下文中的键
+const (
+	HeaderRequestID = "X-Request-Id"
+	tokenParamsKey  = "token_params"
+)
+
+// RequestContext 为每个请求设置请求ID及客户端IP，供日志与审计使用
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqID := c.GetHeader(HeaderRequestID)
+		if reqID == "" {
+			if uid, err := util.NewV4(); err == nil {
+				reqID = uid.String()
+			}
+		}
+		c.Set(log.RequestIDKey, reqID)
+		c.Set(log.RequestClientIPKey, ClientIP(c))
+		c.Header(HeaderRequestID, reqID)
+		c.Next()
+	}
+}
+
+// TokenFromContext 获取当前请求已校验通过的token，未登录时返回nil
+func TokenFromContext(ctx context.Context) *jwt.TokenParames {
+	params, _ := ctx.Value(tokenParamsKey).(*jwt.TokenParames)
+	return params
+}
+
+// RequestIDFromContext 获取当前请求ID
+func RequestIDFromContext(ctx context.Context) string {
+	reqID, _ := ctx.Value(log.RequestIDKey).(string)
+	return reqID
+}
+
+// ClientIPFromContext 获取当前请求的客户端IP
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(log.RequestClientIPKey).(string)
+	return ip
+}
@@ -0,0 +1,123 @@
+package wsgin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/pkg/jwt"
+)
+
+// roleChecker 校验已登录的token是否允许访问接口，由各角色的请求基类实现
+type roleChecker interface {
+	checkRole(params *jwt.TokenParames) error
+}
+
+// roleAuthRequest 必须以指定角色登录才可访问接口，角色由嵌入它的请求基类的 checkRole 决定
+type roleAuthRequest struct {
+	BaseRequest
+
+	TokenParames *jwt.TokenParames `json:"-"`
+}
+
+// Extract .
+func (r *roleAuthRequest) Extract(c *gin.Context) (code APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// DefaultExtract default extract
+func (r *roleAuthRequest) DefaultExtract(data interface{}, c *gin.Context) (code APICode, err error) {
+	return r.ExtractWithBindFunc(data, c, c.ShouldBind)
+}
+
+// ExtractWithBindFunc default ExtractWithBindFunc
+// data 需嵌入某个角色的请求基类，否则无法确定角色，直接拒绝访问
+func (r *roleAuthRequest) ExtractWithBindFunc(data interface{}, c *gin.Context, bindFunc BindFunc) (code APICode, err error) {
+	code, err = r.BaseRequest.ExtractWithBindFunc(data, c, bindFunc)
+	if err != nil {
+		return
+	}
+	checker, ok := data.(roleChecker)
+	if !ok {
+		return APICodeNoPermission, errors.New("request role not specified")
+	}
+	params, code, err := mustAuthFunc(c)
+	if err != nil {
+		return
+	}
+	if err = checker.checkRole(params); err != nil {
+		return APICodeNoPermission, err
+	}
+	r.TokenParames = params
+	return
+}
+
+// MustCustomerAuthRequest 客户必须登录才可访问接口
+type MustCustomerAuthRequest struct {
+	roleAuthRequest
+}
+
+func (*MustCustomerAuthRequest) checkRole(params *jwt.TokenParames) error {
+	return requireRole(params, jwt.RoleCustomer)
+}
+
+// MustMerchantAuthRequest 商户必须登录才可访问接口
+type MustMerchantAuthRequest struct {
+	roleAuthRequest
+}
+
+func (*MustMerchantAuthRequest) checkRole(params *jwt.TokenParames) error {
+	return requireRole(params, jwt.RoleMerchant)
+}
+
+// MustMerchantOwnerAuthRequest 商户店主必须登录才可访问接口
+type MustMerchantOwnerAuthRequest struct {
+	roleAuthRequest
+}
+
+func (*MustMerchantOwnerAuthRequest) checkRole(params *jwt.TokenParames) error {
+	if err := requireRole(params, jwt.RoleMerchant); err != nil {
+		return err
+	}
+	if params.StaffRole != global.StaffRoleOwner {
+		return errors.New("merchant owner required")
+	}
+	return nil
+}
+
+// MustAdminAuthRequest 后台管理员必须登录才可访问接口
+type MustAdminAuthRequest struct {
+	roleAuthRequest
+}
+
+func (*MustAdminAuthRequest) checkRole(params *jwt.TokenParames) error {
+	return requireRole(params, jwt.RoleAdmin)
+}
+
+// MustAdminAuthPagingRequest 后台管理员必须登录才可访问的分页请求基类
+type MustAdminAuthPagingRequest struct {
+	MustAdminAuthRequest
+
+	PageNo   int `form:"page_no" json:"page_no" example:"1" minimum:"1"`
+	PageSize int `form:"page_size" json:"page_size" example:"10" minimum:"1" maximum:"20" binding:"gte=1,lte=20"`
+}
+
+// requireRole token角色需为roles之一
+func requireRole(params *jwt.TokenParames, roles ...string) error {
+	if !params.HasRole(roles...) {
+		return errors.New("token role not allowed")
+	}
+	return nil
+}
+
+// mustRoleAuthFunc 必须登录，且token角色为roles之一
+func mustRoleAuthFunc(c *gin.Context, roles ...string) (*jwt.TokenParames, APICode, error) {
+	tokenParams, code, err := mustAuthFunc(c)
+	if err != nil {
+		return nil, code, err
+	}
+	if err := requireRole(tokenParams, roles...); err != nil {
+		return nil, APICodeNoPermission, err
+	}
+	return tokenParams, APICodeSuccess, nil
+}
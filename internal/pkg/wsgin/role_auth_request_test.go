@@ -0,0 +1,81 @@
+package wsgin
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/pkg/jwt"
+)
+
+type adminRequest struct {
+	MustAdminAuthRequest
+}
+
+func (r *adminRequest) Extract(c *gin.Context) (APICode, error) { return r.DefaultExtract(r, c) }
+
+type adminPagingRequest struct {
+	MustAdminAuthPagingRequest
+}
+
+func (r *adminPagingRequest) Extract(c *gin.Context) (APICode, error) { return r.DefaultExtract(r, c) }
+
+type ownerRequest struct {
+	MustMerchantOwnerAuthRequest
+}
+
+func (r *ownerRequest) Extract(c *gin.Context) (APICode, error) { return r.DefaultExtract(r, c) }
+
+func TestRoleAuthRequest(t *testing.T) {
+	customer, err := jwt.CreateToken(1, "customer", "", jwt.RoleCustomer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, err := jwt.CreateToken(2, "admin", "", jwt.RoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	staff, err := jwt.CreateTokenWithParams(jwt.TokenParames{UID: 3, Role: jwt.RoleMerchant, StaffRole: "clerk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := jwt.CreateTokenWithParams(jwt.TokenParames{UID: 3, Role: jwt.RoleMerchant, StaffRole: global.StaffRoleOwner})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  interface {
+			Extract(c *gin.Context) (APICode, error)
+		}
+		query string
+		token string
+		want  bool
+	}{
+		{"no token", &adminRequest{}, "", "", false},
+		{"wrong role", &adminRequest{}, "", customer, false},
+		{"admin", &adminRequest{}, "", admin, true},
+		{"paging wrong role", &adminPagingRequest{}, "?page_no=1&page_size=10", customer, false},
+		{"paging admin", &adminPagingRequest{}, "?page_no=1&page_size=10", admin, true},
+		{"paging out of range", &adminPagingRequest{}, "?page_no=1&page_size=100", admin, false},
+		{"merchant staff", &ownerRequest{}, "", staff, false},
+		{"merchant owner", &ownerRequest{}, "", owner, true},
+		{"embedded request without role", &roleAuthRequest{}, "", admin, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/"+tt.query, nil)
+			if tt.token != "" {
+				c.Request.Header.Set("Authorization", tt.token)
+			}
+			_, err := tt.req.Extract(c)
+			if got := err == nil; got != tt.want {
+				t.Errorf("Extract() error = %v, want success %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package wsgin
+
+import (
+	"context"
+
+	"welfare-sign/internal/pkg/jwt"
+)
+
+// TokenRevokedFunc 判断token是否已被注销
+type TokenRevokedFunc func(ctx context.Context, params *jwt.TokenParames) bool
+
+var tokenRevoked TokenRevokedFunc = func(ctx context.Context, params *jwt.TokenParames) bool {
+	return false
+}
+
+// SetTokenRevokedFunc 设置token注销校验方法，登录校验时调用
+func SetTokenRevokedFunc(f TokenRevokedFunc) {
+	tokenRevoked = f
+}
@@ -13,6 +13,7 @@ func New() *gin.Engine {
 	if sharpMode == devCode {
 		engine.Use(gin.Logger(), gin.Recovery())
 	}
+	engine.Use(RequestContext())
 	// upgrade validator.v8 to v9
 	binding.Validator = new(validator.DefaultValidator)
 	return engine
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// AuditLogListRequest 查询审计日志
+type AuditLogListRequest struct {
+	wsgin.MustAdminAuthPagingRequest
+
+	ActorID    uint64 `json:"actor_id" form:"actor_id"`       // 操作人ID
+	Action     string `json:"action" form:"action"`           // 操作，如 merchant.edit
+	EntityType string `json:"entity_type" form:"entity_type"` // 操作对象类型，如 merchant
+	EntityID   uint64 `json:"entity_id" form:"entity_id"`     // 操作对象ID
+	BeginDate  string `json:"begin_date" form:"begin_date"`   // 开始日期
+	EndDate    string `json:"end_date" form:"end_date"`       // 结束日期
+}
+
+// AuditLogListResponse .
+type AuditLogListResponse struct {
+	wsgin.BasePagingResponse
+
+	Data []*model.AuditLog `json:"data"`
+}
+
+// New .
+func (r *AuditLogListRequest) New() wsgin.Process {
+	return &AuditLogListRequest{}
+}
+
+// Extract .
+func (r *AuditLogListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 查询审计日志
+// @Summary 查询审计日志
+// @Description get audit log list
+// @Security ApiKeyAuth
+// @Tags 审计日志
+// @Accept json
+// @Produce json
+// @Param actor_id query int false "操作人ID"
+// @Param action query string false "操作"
+// @Param entity_type query string false "操作对象类型"
+// @Param entity_id query int false "操作对象ID"
+// @Param begin_date query string false "开始日期"
+// @Param end_date query string false "结束日期"
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.AuditLogListResponse "{"status":true}"
+// @Router /audit_logs [get]
+func (r *AuditLogListRequest) Exec(ctx context.Context) interface{} {
+	resp := AuditLogListResponse{}
+
+	data, total, code, err := svc.GetAuditLogList(ctx, &model.AuditLogListVO{
+		ActorID:    r.ActorID,
+		Action:     r.Action,
+		EntityType: r.EntityType,
+		EntityID:   r.EntityID,
+		BeginDate:  r.BeginDate,
+		EndDate:    r.EndDate,
+		PageNo:     r.PageNo,
+		PageSize:   r.PageSize,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Total = total
+	resp.Current = r.PageNo
+	return resp
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CampaignAddRequest 新增签到活动
+type CampaignAddRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	Campaign *model.CampaignVO `json:"campaign" binding:"required,dive"` // 签到活动
+}
+
+// CampaignAddResponse .
+type CampaignAddResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *CampaignAddRequest) New() wsgin.Process {
+	return &CampaignAddRequest{}
+}
+
+// Extract .
+func (r *CampaignAddRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 新增签到活动
+// @Summary 新增签到活动
+// @Description create check-in campaign
+// @Security ApiKeyAuth
+// @Tags 签到活动
+// @Accept json
+// @Produce json
+// @Param args body server.CampaignAddRequest true "参数"
+// @Success 200 {object} server.CampaignAddResponse "{"status":true}"
+// @Router /campaigns [post]
+func (r *CampaignAddRequest) Exec(ctx context.Context) interface{} {
+	resp := CampaignAddResponse{}
+
+	code, err := svc.AddCampaign(ctx, r.Campaign)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
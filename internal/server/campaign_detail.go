@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CampaignDetailRequest 获取签到活动详情
+type CampaignDetailRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	CampaignID uint64 `json:"campaign_id" form:"campaign_id" binding:"required" example:"签到活动ID"`
+}
+
+// CampaignDetailResponse .
+type CampaignDetailResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.Campaign `json:"data"`
+}
+
+// New .
+func (r *CampaignDetailRequest) New() wsgin.Process {
+	return &CampaignDetailRequest{}
+}
+
+// Extract .
+func (r *CampaignDetailRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取签到活动详情
+// @Summary 获取签到活动详情
+// @Description get check-in campaign detail
+// @Security ApiKeyAuth
+// @Tags 签到活动
+// @Accept json
+// @Produce json
+// @Param campaign_id query int true "签到活动ID"
+// @Success 200 {object} server.CampaignDetailResponse "{"status":true}"
+// @Router /campaigns/detail [get]
+func (r *CampaignDetailRequest) Exec(ctx context.Context) interface{} {
+	resp := CampaignDetailResponse{}
+
+	data, code, err := svc.GetCampaignDetail(ctx, r.CampaignID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CampaignDisableRequest 停用签到活动
+type CampaignDisableRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	CampaignID uint64 `form:"campaign_id" json:"campaign_id" binding:"required"` // 签到活动ID
+}
+
+// CampaignDisableResponse .
+type CampaignDisableResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *CampaignDisableRequest) New() wsgin.Process {
+	return &CampaignDisableRequest{}
+}
+
+// Extract .
+func (r *CampaignDisableRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 停用签到活动
+// @Summary 停用签到活动
+// @Description disable check-in campaign
+// @Security ApiKeyAuth
+// @Tags 签到活动
+// @Accept json
+// @Produce json
+// @Param args body server.CampaignDisableRequest true "参数"
+// @Success 200 {object} server.CampaignDisableResponse "{"status":true}"
+// @Router /campaigns/disable [post]
+func (r *CampaignDisableRequest) Exec(ctx context.Context) interface{} {
+	resp := CampaignDisableResponse{}
+
+	code, err := svc.DisableCampaign(ctx, r.CampaignID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CampaignEditRequest 编辑签到活动
+type CampaignEditRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	CampaignID uint64            `json:"campaign_id" binding:"required"`   // 签到活动ID
+	Campaign   *model.CampaignVO `json:"campaign" binding:"required,dive"` // 签到活动
+}
+
+// CampaignEditResponse .
+type CampaignEditResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *CampaignEditRequest) New() wsgin.Process {
+	return &CampaignEditRequest{}
+}
+
+// Extract .
+func (r *CampaignEditRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 编辑签到活动
+// @Summary 编辑签到活动
+// @Description edit check-in campaign
+// @Security ApiKeyAuth
+// @Tags 签到活动
+// @Accept json
+// @Produce json
+// @Param args body server.CampaignEditRequest true "参数"
+// @Success 200 {object} server.CampaignEditResponse "{"status":true}"
+// @Router /campaigns [put]
+func (r *CampaignEditRequest) Exec(ctx context.Context) interface{} {
+	resp := CampaignEditResponse{}
+
+	code, err := svc.EditCampaign(ctx, r.CampaignID, r.Campaign)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
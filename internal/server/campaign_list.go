@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CampaignListRequest 获取签到活动列表
+type CampaignListRequest struct {
+	wsgin.MustAdminAuthPagingRequest
+
+	Name   string `json:"name" form:"name" example:"活动名称"`
+	Status string `json:"status" form:"status"` // 活动状态：A(正常状态)，X(已停用)，不传代表全部状态
+}
+
+// CampaignListResponse .
+type CampaignListResponse struct {
+	wsgin.BasePagingResponse
+
+	Data []*model.Campaign `json:"data"`
+}
+
+// New .
+func (r *CampaignListRequest) New() wsgin.Process {
+	return &CampaignListRequest{}
+}
+
+// Extract .
+func (r *CampaignListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取签到活动列表
+// @Summary 获取签到活动列表
+// @Description get check-in campaign list
+// @Security ApiKeyAuth
+// @Tags 签到活动
+// @Accept json
+// @Produce json
+// @Param name query string false "活动名称"
+// @Param status query string false "活动状态"
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.CampaignListResponse "{"status":true}"
+// @Router /campaigns [get]
+func (r *CampaignListRequest) Exec(ctx context.Context) interface{} {
+	resp := CampaignListResponse{}
+
+	data, total, code, err := svc.GetCampaignList(ctx, &model.CampaignListVO{
+		Name:     r.Name,
+		Status:   r.Status,
+		PageNo:   r.PageNo,
+		PageSize: r.PageSize,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Total = total
+	resp.Current = r.PageNo
+	return resp
+}
@@ -10,7 +10,7 @@ import (
 
 // CanPartLuckyNumberActivityRequest .
 type CanPartLuckyNumberActivityRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
 }
 
 // CanPartLuckyNumberActivityResponse .
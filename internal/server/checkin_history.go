@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CheckinHistoryRequest 获取签到历史
+type CheckinHistoryRequest struct {
+	wsgin.MustCustomerAuthRequest
+
+	Month    string `form:"month" json:"month" example:"2019-10"`                                                              // 日历月份，不传时为当前月
+	PageNo   int    `form:"page_no" json:"page_no" example:"1" minimum:"1" binding:"omitempty,gte=1"`                          // 领取福利记录页码
+	PageSize int    `form:"page_size" json:"page_size" example:"10" minimum:"1" maximum:"20" binding:"omitempty,gte=1,lte=20"` // 领取福利记录每页数量
+}
+
+// CheckinHistoryResponse .
+type CheckinHistoryResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.CheckinHistory `json:"data"`
+}
+
+// New .
+func (r *CheckinHistoryRequest) New() wsgin.Process {
+	return &CheckinHistoryRequest{}
+}
+
+// Extract .
+func (r *CheckinHistoryRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取签到历史
+// @Summary 获取签到历史
+// @Description get customer checkin calendar, streaks and claimed gifts
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param month query string false "日历月份，格式为2019-10，不传时为当前月"
+// @Param page_no query int false "领取福利记录页码，默认为1"
+// @Param page_size query int false "领取福利记录每页数量，默认为10"
+// @Success 200 {object} server.CheckinHistoryResponse "{"status":true}"
+// @Router /customers/checkin_history [get]
+func (r *CheckinHistoryRequest) Exec(ctx context.Context) interface{} {
+	resp := CheckinHistoryResponse{}
+
+	data, code, err := svc.GetCheckinHistory(ctx, r.TokenParames.UID, r.Month, r.PageNo, r.PageSize)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -10,7 +10,7 @@ import (
 
 // CompositeIndexAddRequest .
 type CompositeIndexAddRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustAdminAuthRequest
 
 	CompositeDate string  `json:"composite_date" binding:"required"` // 上证指数日期
 	Points        float64 `json:"points" binding:"required"`         // 指数
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CreditCheckinRequest 使用免费补签次数补签
+type CreditCheckinRequest struct {
+	wsgin.MustCustomerAuthRequest
+	wsgin.Idempotent
+}
+
+// CreditCheckinResponse .
+type CreditCheckinResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *CreditCheckinRequest) New() wsgin.Process {
+	return &CreditCheckinRequest{}
+}
+
+// Extract .
+func (r *CreditCheckinRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 使用免费补签次数补签
+// @Summary 使用免费补签次数补签
+// @Description use a referral reward credit to make up the earliest missed day
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
+// @Success 200 {object} server.CreditCheckinResponse "{"status":true}"
+// @Router /customers/checkin_record/credit [post]
+func (r *CreditCheckinRequest) Exec(ctx context.Context) interface{} {
+	resp := CreditCheckinResponse{}
+
+	code, err := svc.CreditCheckinRecord(ctx, r.TokenParames.UID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
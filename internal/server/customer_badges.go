@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// CustomerBadgesRequest 获取客户徽章
+type CustomerBadgesRequest struct {
+	wsgin.MustCustomerAuthRequest
+}
+
+// CustomerBadgesResponse .
+type CustomerBadgesResponse struct {
+	wsgin.BaseResponse
+
+	Data []*model.BadgeVO `json:"data"`
+}
+
+// New .
+func (r *CustomerBadgesRequest) New() wsgin.Process {
+	return &CustomerBadgesRequest{}
+}
+
+// Extract .
+func (r *CustomerBadgesRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取客户徽章
+// @Summary 获取客户徽章
+// @Description get customer earned and locked badges
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Success 200 {object} server.CustomerBadgesResponse "{"status":true}"
+// @Router /customers/badges [get]
+func (r *CustomerBadgesRequest) Exec(ctx context.Context) interface{} {
+	resp := CustomerBadgesResponse{}
+
+	data, code, err := svc.GetCustomerBadges(ctx, r.TokenParames.UID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/jwt"
 	"welfare-sign/internal/pkg/wsgin"
 )
 
@@ -46,7 +47,8 @@ func (r *CustomerDetailRequest) Extract(c *gin.Context) (code wsgin.APICode, err
 func (r *CustomerDetailRequest) Exec(ctx context.Context) interface{} {
 	resp := CustomerDetailResponse{}
 	uid := uint64(0)
-	if r.TokenParames != nil {
+	// 只有客户登录时才默认查询自己，其他角色的UID不是客户ID
+	if r.TokenParames != nil && r.TokenParames.HasRole(jwt.RoleCustomer) {
 		uid = r.TokenParames.UID
 	}
 	data, code, err := svc.GetCustomerDetail(ctx, uid, r.CustomerID, r.IsHelpCheckinPage)
@@ -11,7 +11,7 @@ import (
 
 // CustomerListRequest .
 type CustomerListRequest struct {
-	wsgin.AuthPagingRequest
+	wsgin.MustAdminAuthPagingRequest
 
 	Name   string `json:"name" form:"name" example:"用户名"`
 	Mobile string `json:"mobile" form:"mobile" binding:"omitempty,mobile" example:"联系电话"`
@@ -3,23 +3,25 @@ package server
 import (
 	"context"
 
-	"welfare-sign/internal/pkg/wsgin"
-
 	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
 )
 
 // CustomerLoginRequest .
 type CustomerLoginRequest struct {
 	wsgin.BaseRequest
 
-	Code string `json:"code" form:"code" binding:"required"`
+	Code  string `json:"code" form:"code" binding:"required"`
+	State string `json:"state" form:"state"` // 微信网页授权回传的state，携带邀请码
 }
 
 // CustomerLoginResponse .
 type CustomerLoginResponse struct {
 	wsgin.BaseResponse
 
-	Data string `json:"data"`
+	Data *model.TokenResp `json:"data"`
 }
 
 // New .
@@ -39,12 +41,13 @@ func (r *CustomerLoginRequest) Extract(c *gin.Context) (code wsgin.APICode, err
 // @Accept json
 // @Produce json
 // @Param code query string true "微信回调code"
+// @Param state query string false "微信回调state，新用户通过邀请码注册时传入邀请码"
 // @Success 200 {object} server.CustomerLoginResponse "{"status":true}"
 // @Router /customers/login [post]
 func (r *CustomerLoginRequest) Exec(ctx context.Context) interface{} {
 	resp := CustomerLoginResponse{}
 
-	data, code, err := svc.CustomerLogin(ctx, r.Code)
+	data, code, err := svc.CustomerLogin(ctx, r.Code, r.State)
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
 	resp.Data = data
 	return resp
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"welfare-sign/internal/model"
 	"welfare-sign/internal/pkg/wsgin"
 
 	"github.com/gin-gonic/gin"
@@ -9,7 +10,11 @@ import (
 
 // ExecCheckinRecordRequest .
 type ExecCheckinRecordRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
+	wsgin.Idempotent
+
+	Lon float64 `form:"lon" json:"lon" example:"116.397128"` // 经度，到店签到时必传
+	Lat float64 `form:"lat" json:"lat" example:"39.916527"`  // 纬度，到店签到时必传
 }
 
 // ExecCheckinRecordResponse .
@@ -34,12 +39,17 @@ func (r *ExecCheckinRecordRequest) Extract(c *gin.Context) (code wsgin.APICode,
 // @Tags 客户
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
+// @Param args body server.ExecCheckinRecordRequest false "参数"
 // @Success 200 {object} server.ExecCheckinRecordResponse	"{"status":true}"
 // @Router /customers/checkin_record [post]
 func (r *ExecCheckinRecordRequest) Exec(ctx context.Context) interface{} {
 	resp := ExecCheckinRecordResponse{}
 
-	code, err := svc.ExecCheckinRecord(ctx, r.TokenParames.UID)
+	code, err := svc.ExecCheckinRecord(ctx, r.TokenParames.UID, &model.CheckinLocation{
+		Lon: r.Lon,
+		Lat: r.Lat,
+	})
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
 	return resp
 }
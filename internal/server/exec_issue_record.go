@@ -9,9 +9,11 @@ import (
 
 // ExecIssueRecordRequest .
 type ExecIssueRecordRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
+	wsgin.Idempotent
 
 	MerchantID uint64 `json:"merchant_id" binding:"required"` // 店铺ID
+	GiftItemID uint64 `json:"gift_item_id"`                   // 礼品ID，商户提供礼品时必填
 	Mobile     string `json:"mobile"`                         // 手机号
 	Code       string `json:"code"`                           // 验证码
 }
@@ -39,12 +41,13 @@ func (r *ExecIssueRecordRequest) Extract(c *gin.Context) (code wsgin.APICode, er
 // @Accept json
 // @Produce json
 // @Param args body server.ExecIssueRecordRequest true "参数"
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
 // @Success 200 {object} server.ExecIssueRecordResponse	"{"status":true}"
 // @Router /customers/issue_records [post]
 func (r *ExecIssueRecordRequest) Exec(ctx context.Context) interface{} {
 	resp := ExecIssueRecordResponse{}
 
-	code, err := svc.ExecIssueRecords(ctx, r.TokenParames.UID, r.MerchantID, r.Mobile, r.Code)
+	code, err := svc.ExecIssueRecords(ctx, r.TokenParames.UID, r.MerchantID, r.GiftItemID, r.Mobile, r.Code)
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
 	return resp
 }
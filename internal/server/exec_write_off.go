@@ -11,10 +11,13 @@ import (
 
 // ExecWriteOffRequest .
 type ExecWriteOffRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustMerchantAuthRequest
+	wsgin.Idempotent
 
-	CustomerID uint64 `form:"customer_id" json:"customer_id" binding:"required"` // 客户ID
-	Num        uint64 `form:"num" json:"num" binding:"required"`                 // 核销数目
+	Code       string `form:"code" json:"code"`                  // 客户二维码中的核销码
+	CustomerID uint64 `form:"customer_id" json:"customer_id"`    // 客户ID，仅在允许使用客户ID核销时有效
+	GiftItemID uint64 `form:"gift_item_id" json:"gift_item_id"`  // 礼品ID，客户在该商户有多份福利时必填
+	Num        uint64 `form:"num" json:"num" binding:"required"` // 核销数目
 }
 
 // ExecWriteOffResponse .
@@ -42,6 +45,7 @@ func (r *ExecWriteOffRequest) Extract(c *gin.Context) (code wsgin.APICode, err e
 // @Accept json
 // @Produce json
 // @Param args body server.ExecWriteOffRequest true "参数"
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
 // @Success 200 {object} server.ExecWriteOffResponse	"{"status":true}"
 // @Router /merchants/writeoff [post]
 func (r *ExecWriteOffRequest) Exec(ctx context.Context) interface{} {
@@ -49,7 +53,10 @@ func (r *ExecWriteOffRequest) Exec(ctx context.Context) interface{} {
 
 	data, code, err := svc.ExecWriteOff(ctx, &model.MerchantExecWriteOffVO{
 		MerchantID: r.TokenParames.UID,
+		Code:       r.Code,
 		CustomerID: r.CustomerID,
+		StaffID:    r.TokenParames.StaffID,
+		GiftItemID: r.GiftItemID,
 		Num:        r.Num,
 	})
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
@@ -20,7 +20,7 @@ import (
 // @Accept mpfd
 // @Produce json
 // @Param file formData file true "upload file"
-// @Param type query string true "file type" default("avatar", "poster")
+// @Param type query string true "file type" default("avatar", "poster", "review")
 // @Success 200 {object} server.BaseResponse	"{"status":true}"
 // @Router /files/upload [post]
 func uploadFile(c *gin.Context) {
@@ -36,7 +36,7 @@ func uploadFile(c *gin.Context) {
 		return
 	}
 	spec := c.Query("type")
-	if spec != "avatar" && spec != "poster" {
+	if spec != "avatar" && spec != "poster" && spec != "review" {
 		c.JSON(http.StatusOK, BaseResponse{
 			Status:  false,
 			Code:    wsgin.APICodeInvalidParame,
@@ -46,7 +46,7 @@ func uploadFile(c *gin.Context) {
 		return
 	}
 	filePrefix := "upload/" + spec + "/"
-	filename := strconv.FormatInt(time.Now().Unix(), 10) + ".png"
+	filename := strconv.FormatInt(time.Now().UnixNano(), 10) + ".png"
 	if err := c.SaveUploadedFile(file, filePrefix+filename); err != nil {
 		c.JSON(http.StatusOK, BaseResponse{
 			Status:  false,
@@ -71,13 +71,13 @@ func uploadFile(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param filename query string true "filename"
-// @Param type query string true "file type" default("avatar", "poster")
+// @Param type query string true "file type" default("avatar", "poster", "review")
 // @Success 200 {object} server.BaseResponse	"{"status":true}"
 // @Router /files/download [get]
 func downloadFile(c *gin.Context) {
 	filename := c.Query("filename")
 	spec := c.Query("type")
-	if spec != "avatar" && spec != "poster" {
+	if spec != "avatar" && spec != "poster" && spec != "review" {
 		c.JSON(http.StatusOK, BaseResponse{
 			Status:  false,
 			Code:    wsgin.APICodeInvalidParame,
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// GiftItemAddRequest 新增礼品
+type GiftItemAddRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	GiftItem *model.GiftItemVO `json:"gift_item" binding:"required,dive"` // 礼品
+}
+
+// GiftItemAddResponse .
+type GiftItemAddResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.GiftItem `json:"data"`
+}
+
+// New .
+func (r *GiftItemAddRequest) New() wsgin.Process {
+	return &GiftItemAddRequest{}
+}
+
+// Extract .
+func (r *GiftItemAddRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 新增礼品
+// @Summary 新增礼品
+// @Description add merchant gift item, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.GiftItemAddRequest true "参数"
+// @Success 200 {object} server.GiftItemAddResponse "{"status":true}"
+// @Router /merchants/gift_items [post]
+func (r *GiftItemAddRequest) Exec(ctx context.Context) interface{} {
+	resp := GiftItemAddResponse{}
+
+	data, code, err := svc.AddGiftItem(ctx, r.TokenParames.UID, r.GiftItem)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// GiftItemDisableRequest 停用礼品
+type GiftItemDisableRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	GiftItemID uint64 `form:"gift_item_id" json:"gift_item_id" binding:"required"` // 礼品ID
+}
+
+// GiftItemDisableResponse .
+type GiftItemDisableResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *GiftItemDisableRequest) New() wsgin.Process {
+	return &GiftItemDisableRequest{}
+}
+
+// Extract .
+func (r *GiftItemDisableRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 停用礼品
+// @Summary 停用礼品
+// @Description disable merchant gift item, claimed welfare can still be written off, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.GiftItemDisableRequest true "参数"
+// @Success 200 {object} server.GiftItemDisableResponse "{"status":true}"
+// @Router /merchants/gift_items/disable [post]
+func (r *GiftItemDisableRequest) Exec(ctx context.Context) interface{} {
+	resp := GiftItemDisableResponse{}
+
+	code, err := svc.DisableGiftItem(ctx, r.TokenParames.UID, r.GiftItemID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// GiftItemEditRequest 编辑礼品
+type GiftItemEditRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	GiftItemID uint64            `json:"gift_item_id" binding:"required"`   // 礼品ID
+	GiftItem   *model.GiftItemVO `json:"gift_item" binding:"required,dive"` // 礼品
+}
+
+// GiftItemEditResponse .
+type GiftItemEditResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *GiftItemEditRequest) New() wsgin.Process {
+	return &GiftItemEditRequest{}
+}
+
+// Extract .
+func (r *GiftItemEditRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 编辑礼品
+// @Summary 编辑礼品
+// @Description edit merchant gift item, stock can not be less than received, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.GiftItemEditRequest true "参数"
+// @Success 200 {object} server.GiftItemEditResponse "{"status":true}"
+// @Router /merchants/gift_items [put]
+func (r *GiftItemEditRequest) Exec(ctx context.Context) interface{} {
+	resp := GiftItemEditResponse{}
+
+	code, err := svc.EditGiftItem(ctx, r.TokenParames.UID, r.GiftItemID, r.GiftItem)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
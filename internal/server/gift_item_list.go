@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/jwt"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// GiftItemListRequest 获取商户礼品列表
+type GiftItemListRequest struct {
+	wsgin.MustAuthRequest
+
+	MerchantID uint64 `form:"merchant_id" json:"merchant_id"` // 商户ID，商户登录时默认为当前商户
+}
+
+// GiftItemListResponse .
+type GiftItemListResponse struct {
+	wsgin.BaseResponse
+
+	Data []*model.GiftItem `json:"data"`
+}
+
+// New .
+func (r *GiftItemListRequest) New() wsgin.Process {
+	return &GiftItemListRequest{}
+}
+
+// Extract .
+func (r *GiftItemListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取商户礼品列表
+// @Summary 获取商户礼品列表
+// @Description get merchant gift items available to claim
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param merchant_id query int false "商户ID，商户登录时默认为当前商户"
+// @Success 200 {object} server.GiftItemListResponse "{"status":true}"
+// @Router /merchants/gift_items [get]
+func (r *GiftItemListRequest) Exec(ctx context.Context) interface{} {
+	resp := GiftItemListResponse{}
+
+	if r.MerchantID == 0 && r.TokenParames.HasRole(jwt.RoleMerchant) {
+		r.MerchantID = r.TokenParames.UID
+	}
+	data, code, err := svc.GetGiftItemList(ctx, r.MerchantID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
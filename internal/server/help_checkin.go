@@ -9,9 +9,11 @@ import (
 
 // HelpCheckinRequest .
 type HelpCheckinRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
+	wsgin.Idempotent
 
-	CustomerID uint64 `form:"customer_id" json:"customer_id" binding:"required"` // 补签客户ID
+	Token      string `form:"token" json:"token"`             // 补签邀请token
+	CustomerID uint64 `form:"customer_id" json:"customer_id"` // 补签客户ID，传入token时以邀请人为准
 }
 
 // HelpCheckinResponse .
@@ -37,12 +39,13 @@ func (r *HelpCheckinRequest) Extract(c *gin.Context) (code wsgin.APICode, err er
 // @Accept json
 // @Produce json
 // @Param args body server.HelpCheckinRequest true "参数"
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
 // @Success 200 {object} server.HelpCheckinResponse	"{"status":true}"
 // @Router /customers/checkin_record/help [post]
 func (r *HelpCheckinRequest) Exec(ctx context.Context) interface{} {
 	resp := HelpCheckinResponse{}
 
-	code, err := svc.HelpCheckinRecord(ctx, r.TokenParames.UID, r.CustomerID)
+	code, err := svc.HelpCheckinRecord(ctx, r.TokenParames.UID, r.CustomerID, r.Token)
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
 	return resp
 }
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// HelpInvitationAddRequest 生成补签邀请
+type HelpInvitationAddRequest struct {
+	wsgin.MustCustomerAuthRequest
+}
+
+// HelpInvitationAddResponse .
+type HelpInvitationAddResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.HelpInvitationResp `json:"data"`
+}
+
+// New .
+func (r *HelpInvitationAddRequest) New() wsgin.Process {
+	return &HelpInvitationAddRequest{}
+}
+
+// Extract .
+func (r *HelpInvitationAddRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 生成补签邀请
+// @Summary 生成补签邀请
+// @Description create help checkin invitation, valid until the end of current checkin cycle
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Success 200 {object} server.HelpInvitationAddResponse "{"status":true}"
+// @Router /customers/help_invitations [post]
+func (r *HelpInvitationAddRequest) Exec(ctx context.Context) interface{} {
+	resp := HelpInvitationAddResponse{}
+
+	data, code, err := svc.CreateHelpInvitation(ctx, r.TokenParames.UID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
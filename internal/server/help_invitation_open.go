@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// HelpInvitationOpenRequest 打开补签邀请
+type HelpInvitationOpenRequest struct {
+	wsgin.MustCustomerAuthRequest
+
+	Token string `form:"token" json:"token" binding:"required"` // 补签邀请token
+}
+
+// HelpInvitationOpenResponse .
+type HelpInvitationOpenResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.HelpInvitationDetail `json:"data"`
+}
+
+// New .
+func (r *HelpInvitationOpenRequest) New() wsgin.Process {
+	return &HelpInvitationOpenRequest{}
+}
+
+// Extract .
+func (r *HelpInvitationOpenRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 打开补签邀请
+// @Summary 打开补签邀请
+// @Description open help checkin invitation, return inviter info
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param token query string true "补签邀请token"
+// @Success 200 {object} server.HelpInvitationOpenResponse "{"status":true}"
+// @Router /customers/help_invitations/open [get]
+func (r *HelpInvitationOpenRequest) Exec(ctx context.Context) interface{} {
+	resp := HelpInvitationOpenResponse{}
+
+	data, code, err := svc.OpenHelpInvitation(ctx, r.TokenParames.UID, r.Token)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
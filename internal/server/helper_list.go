@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// HelperListRequest 获取帮我补签的好友
+type HelperListRequest struct {
+	wsgin.MustCustomerAuthRequest
+
+	PageNo   int `form:"page_no" json:"page_no" example:"1" minimum:"1"`
+	PageSize int `form:"page_size" json:"page_size" example:"10" minimum:"1" maximum:"20" binding:"gte=0,lte=20"`
+}
+
+// HelperListResponse .
+type HelperListResponse struct {
+	wsgin.BasePagingResponse
+
+	Data []*model.Helper `json:"data"`
+}
+
+// New .
+func (r *HelperListRequest) New() wsgin.Process {
+	return &HelperListRequest{}
+}
+
+// Extract .
+func (r *HelperListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取帮我补签的好友
+// @Summary 获取帮我补签的好友
+// @Description get friends who helped customer checkin
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.HelperListResponse "{"status":true}"
+// @Router /customers/helpers [get]
+func (r *HelperListRequest) Exec(ctx context.Context) interface{} {
+	resp := HelperListResponse{}
+
+	if r.PageNo == 0 {
+		r.PageNo = 1
+	}
+	data, total, code, err := svc.GetHelperList(ctx, r.TokenParames.UID, r.PageNo, r.PageSize)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Total = total
+	resp.Current = r.PageNo
+	return resp
+}
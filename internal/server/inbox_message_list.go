@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// InboxMessageListRequest 获取站内信
+type InboxMessageListRequest struct {
+	wsgin.MustCustomerAuthRequest
+
+	PageNo   int `form:"page_no" json:"page_no" example:"1" minimum:"1"`
+	PageSize int `form:"page_size" json:"page_size" example:"10" minimum:"1" maximum:"20" binding:"gte=0,lte=20"`
+}
+
+// InboxMessageListResponse .
+type InboxMessageListResponse struct {
+	wsgin.BasePagingResponse
+
+	Unread int                   `json:"unread"` // 未读消息数
+	Data   []*model.InboxMessage `json:"data"`
+}
+
+// New .
+func (r *InboxMessageListRequest) New() wsgin.Process {
+	return &InboxMessageListRequest{}
+}
+
+// Extract .
+func (r *InboxMessageListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取站内信
+// @Summary 获取站内信
+// @Description get customer inbox messages and unread count
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.InboxMessageListResponse "{"status":true}"
+// @Router /customers/messages [get]
+func (r *InboxMessageListRequest) Exec(ctx context.Context) interface{} {
+	resp := InboxMessageListResponse{}
+
+	data, total, unread, code, err := svc.GetInboxMessageList(ctx, r.TokenParames.UID, r.PageNo, r.PageSize)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Unread = unread
+	resp.Total = total
+	resp.Current = r.PageNo
+	return resp
+}
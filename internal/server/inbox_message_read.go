@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// InboxMessageReadRequest 站内信全部标记为已读
+type InboxMessageReadRequest struct {
+	wsgin.MustCustomerAuthRequest
+}
+
+// InboxMessageReadResponse .
+type InboxMessageReadResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *InboxMessageReadRequest) New() wsgin.Process {
+	return &InboxMessageReadRequest{}
+}
+
+// Extract .
+func (r *InboxMessageReadRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 站内信全部标记为已读
+// @Summary 站内信全部标记为已读
+// @Description mark all customer inbox messages as read
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Success 200 {object} server.InboxMessageReadResponse "{"status":true}"
+// @Router /customers/messages/read [post]
+func (r *InboxMessageReadRequest) Exec(ctx context.Context) interface{} {
+	resp := InboxMessageReadResponse{}
+
+	code, err := svc.ReadInboxMessage(ctx, r.TokenParames.UID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
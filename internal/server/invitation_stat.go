@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// InvitationStatRequest .
+type InvitationStatRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	BeginDate string `form:"begin_date" json:"begin_date" binding:"required"` // 开始日期
+	EndDate   string `form:"end_date" json:"end_date" binding:"required"`     // 结束日期
+}
+
+// InvitationStatResponse .
+type InvitationStatResponse struct {
+	wsgin.BaseResponse
+
+	Data []*model.InvitationStat `json:"data"`
+}
+
+// New .
+func (r *InvitationStatRequest) New() wsgin.Process {
+	return &InvitationStatRequest{}
+}
+
+// Extract .
+func (r *InvitationStatRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 统计补签邀请转化率
+// @Summary 统计补签邀请转化率
+// @Description stat help checkin invitation opens, accepts and conversion rate
+// @Tags 统计
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param begin_date query string true "开始日期"
+// @Param end_date query string true "结束日期，与开始日期最多相隔92天"
+// @Success 200 {object} server.InvitationStatResponse "{"status":true}"
+// @Router /stat/invitation [get]
+func (r *InvitationStatRequest) Exec(ctx context.Context) interface{} {
+	resp := InvitationStatResponse{}
+
+	data, code, err := svc.GetInvitationStat(ctx, r.BeginDate, r.EndDate)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// LogoutRequest .
+type LogoutRequest struct {
+	wsgin.MustAuthRequest
+
+	RefreshToken string `json:"refresh_token" form:"refresh_token"` // 同时注销的refresh_token
+}
+
+// LogoutResponse .
+type LogoutResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *LogoutRequest) New() wsgin.Process {
+	return &LogoutRequest{}
+}
+
+// Extract .
+func (r *LogoutRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 退出登录
+// @Summary 退出登录
+// @Description logout, revoke current access token and refresh token
+// @Security ApiKeyAuth
+// @Tags 登录
+// @Accept json
+// @Produce json
+// @Param args body server.LogoutRequest true "参数"
+// @Success 200 {object} server.LogoutResponse "{"status":true}"
+// @Router /logout [post]
+func (r *LogoutRequest) Exec(ctx context.Context) interface{} {
+	resp := LogoutResponse{}
+
+	code, err := svc.Logout(ctx, r.TokenParames, r.RefreshToken)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
@@ -10,7 +10,7 @@ import (
 
 // LuckyNumberAddRequest .
 type LuckyNumberAddRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
 
 	Num int64 `json:"num" binding:"required"` // 猜的数字
 }
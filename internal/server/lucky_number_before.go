@@ -11,7 +11,7 @@ import (
 
 // LuckyNumberBeforeRequest .
 type LuckyNumberBeforeRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
 }
 
 // LuckyNumberBeforeResponse .
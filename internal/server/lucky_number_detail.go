@@ -11,7 +11,7 @@ import (
 
 // LuckyNumberDetailRequest .
 type LuckyNumberDetailRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
 }
 
 // LuckyNumberDetailResponse .
@@ -11,7 +11,7 @@ import (
 
 // MerchantAddRequest .
 type MerchantAddRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustAdminAuthRequest
 
 	Merchant *model.MerchantVO `json:"merchant" binding:"required,dive"`
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 
 	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/jwt"
 	"welfare-sign/internal/pkg/wsgin"
 )
 
@@ -46,9 +48,14 @@ func (r *MerchantDetailRequest) Extract(c *gin.Context) (code wsgin.APICode, err
 func (r *MerchantDetailRequest) Exec(ctx context.Context) interface{} {
 	resp := MerchantDetailResponse{}
 
-	merchantID := r.TokenParames.UID
-	if r.MerchantID != 0 {
-		merchantID = r.MerchantID
+	// 只有商户token可以省略merchant_id查看自己的详情
+	merchantID := r.MerchantID
+	if merchantID == 0 {
+		if !r.TokenParames.HasRole(jwt.RoleMerchant) {
+			resp.BaseResponse = wsgin.NewResponse(ctx, wsgin.APICodeInvalidParame, errors.New("merchant_id is required"))
+			return resp
+		}
+		merchantID = r.TokenParames.UID
 	}
 	data, code, err := svc.GetMerchantDetailBySelfAccessToken(ctx, merchantID)
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
@@ -11,7 +11,7 @@ import (
 
 // MerchantListRequest .
 type MerchantListRequest struct {
-	wsgin.AuthPagingRequest
+	wsgin.MustAdminAuthPagingRequest
 
 	StoreName    string `json:"store_name" form:"store_name" example:"商户名"`
 	ContactName  string `json:"contact_name" form:"contact_name" example:"联系人"`
@@ -21,7 +21,7 @@ type MerchantLoginRequest struct {
 type MerchantLoginResponse struct {
 	wsgin.BaseResponse
 
-	Data string `json:"data"` // access token
+	Data *model.TokenResp `json:"data"`
 }
 
 // New .
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// MerchantReviewAddRequest 客户评价商户
+type MerchantReviewAddRequest struct {
+	wsgin.MustCustomerAuthRequest
+
+	WriteOffLogID uint64   `json:"write_off_log_id" form:"write_off_log_id"`            // 核销记录ID
+	MerchantID    uint64   `json:"merchant_id" form:"merchant_id"`                      // 商户ID，未指定核销记录时评价在该商户最近一次未评价的核销
+	Rating        uint64   `json:"rating" form:"rating" binding:"required,min=1,max=5"` // 评分，1-5
+	Content       string   `json:"content" form:"content" binding:"max=500"`            // 评价内容
+	Photos        []string `json:"photos" form:"photos" binding:"max=9"`                // 评价图片，通过上传文件接口以 review 类型上传后的文件名
+}
+
+// MerchantReviewAddResponse .
+type MerchantReviewAddResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.MerchantReview `json:"data"`
+}
+
+// New .
+func (r *MerchantReviewAddRequest) New() wsgin.Process {
+	return &MerchantReviewAddRequest{}
+}
+
+// Extract .
+func (r *MerchantReviewAddRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 客户评价商户
+// @Summary 客户评价商户
+// @Description customer rate and review a write off, one review per write off
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param args body server.MerchantReviewAddRequest true "参数"
+// @Success 200 {object} server.MerchantReviewAddResponse "{"status":true}"
+// @Router /customers/reviews [post]
+func (r *MerchantReviewAddRequest) Exec(ctx context.Context) interface{} {
+	resp := MerchantReviewAddResponse{}
+
+	data, code, err := svc.AddMerchantReview(ctx, &model.MerchantReviewVO{
+		CustomerID:    r.TokenParames.UID,
+		MerchantID:    r.MerchantID,
+		WriteOffLogID: r.WriteOffLogID,
+		Rating:        r.Rating,
+		Content:       r.Content,
+		Photos:        r.Photos,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/jwt"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// MerchantReviewListRequest 获取商户评价
+type MerchantReviewListRequest struct {
+	wsgin.MustAuthRequest
+
+	MerchantID uint64 `form:"merchant_id" json:"merchant_id"` // 商户ID，商户登录时默认为当前商户
+	PageNo     int    `form:"page_no" json:"page_no" example:"1" minimum:"1"`
+	PageSize   int    `form:"page_size" json:"page_size" example:"10" minimum:"1" maximum:"20" binding:"gte=0,lte=20"`
+}
+
+// MerchantReviewListResponse .
+type MerchantReviewListResponse struct {
+	wsgin.BasePagingResponse
+
+	Data []*model.MerchantReviewItem `json:"data"`
+}
+
+// New .
+func (r *MerchantReviewListRequest) New() wsgin.Process {
+	return &MerchantReviewListRequest{}
+}
+
+// Extract .
+func (r *MerchantReviewListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取商户评价
+// @Summary 获取商户评价
+// @Description get visible merchant reviews with merchant replies
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param merchant_id query int false "商户ID，商户登录时默认为当前商户"
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.MerchantReviewListResponse "{"status":true}"
+// @Router /merchants/reviews [get]
+func (r *MerchantReviewListRequest) Exec(ctx context.Context) interface{} {
+	resp := MerchantReviewListResponse{}
+
+	if r.MerchantID == 0 && r.TokenParames.HasRole(jwt.RoleMerchant) {
+		r.MerchantID = r.TokenParames.UID
+	}
+	vo := &model.MerchantReviewListVO{
+		MerchantID: r.MerchantID,
+		PageNo:     r.PageNo,
+		PageSize:   r.PageSize,
+	}
+	data, total, code, err := svc.GetMerchantReviewList(ctx, vo)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Total = total
+	resp.Current = vo.PageNo
+	return resp
+}
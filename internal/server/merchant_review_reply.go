@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// MerchantReviewReplyRequest 商户回复评价
+type MerchantReviewReplyRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	ID    uint64 `form:"id" json:"id" binding:"required"`               // 评价ID
+	Reply string `form:"reply" json:"reply" binding:"required,max=500"` // 回复内容
+}
+
+// MerchantReviewReplyResponse .
+type MerchantReviewReplyResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *MerchantReviewReplyRequest) New() wsgin.Process {
+	return &MerchantReviewReplyRequest{}
+}
+
+// Extract .
+func (r *MerchantReviewReplyRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 商户回复评价
+// @Summary 商户回复评价
+// @Description merchant reply a review, replying again overwrites the previous reply, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.MerchantReviewReplyRequest true "参数"
+// @Success 200 {object} server.MerchantReviewReplyResponse "{"status":true}"
+// @Router /merchants/reviews/reply [post]
+func (r *MerchantReviewReplyRequest) Exec(ctx context.Context) interface{} {
+	resp := MerchantReviewReplyResponse{}
+
+	code, err := svc.ReplyMerchantReview(ctx, r.TokenParames.UID, r.TokenParames.StaffID, r.ID, r.Reply)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// MerchantStaffAddRequest 新增商户员工
+type MerchantStaffAddRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	Name   string `json:"name" form:"name" binding:"required"`                   // 姓名
+	Mobile string `json:"mobile" form:"mobile" binding:"required,mobile"`        // 手机号
+	Role   string `json:"role" form:"role" binding:"required,oneof=owner clerk"` // 角色：owner(店主)，clerk(店员)
+}
+
+// MerchantStaffAddResponse .
+type MerchantStaffAddResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *MerchantStaffAddRequest) New() wsgin.Process {
+	return &MerchantStaffAddRequest{}
+}
+
+// Extract .
+func (r *MerchantStaffAddRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 新增商户员工
+// @Summary 新增商户员工
+// @Description add merchant staff, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.MerchantStaffAddRequest true "参数"
+// @Success 200 {object} server.MerchantStaffAddResponse "{"status":true}"
+// @Router /merchants/staff [post]
+func (r *MerchantStaffAddRequest) Exec(ctx context.Context) interface{} {
+	resp := MerchantStaffAddResponse{}
+
+	code, err := svc.AddMerchantStaff(ctx, &model.MerchantStaffVO{
+		MerchantID: r.TokenParames.UID,
+		Name:       r.Name,
+		Mobile:     r.Mobile,
+		Role:       r.Role,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
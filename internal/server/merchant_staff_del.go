@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// MerchantStaffDelRequest 删除商户员工
+type MerchantStaffDelRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	StaffID uint64 `form:"staff_id" json:"staff_id" binding:"required"` // 员工ID
+}
+
+// MerchantStaffDelResponse .
+type MerchantStaffDelResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *MerchantStaffDelRequest) New() wsgin.Process {
+	return &MerchantStaffDelRequest{}
+}
+
+// Extract .
+func (r *MerchantStaffDelRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 删除商户员工
+// @Summary 删除商户员工
+// @Description delete merchant staff, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.MerchantStaffDelRequest true "参数"
+// @Success 200 {object} server.MerchantStaffDelResponse "{"status":true}"
+// @Router /merchants/staff [delete]
+func (r *MerchantStaffDelRequest) Exec(ctx context.Context) interface{} {
+	resp := MerchantStaffDelResponse{}
+
+	code, err := svc.RemoveMerchantStaff(ctx, r.TokenParames.UID, r.TokenParames.StaffID, r.StaffID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
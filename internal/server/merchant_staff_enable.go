@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// MerchantStaffEnableRequest 启用或停用商户员工
+type MerchantStaffEnableRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	StaffID uint64 `form:"staff_id" json:"staff_id" binding:"required"` // 员工ID
+	Enabled bool   `form:"enabled" json:"enabled"`                      // 是否启用
+}
+
+// MerchantStaffEnableResponse .
+type MerchantStaffEnableResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *MerchantStaffEnableRequest) New() wsgin.Process {
+	return &MerchantStaffEnableRequest{}
+}
+
+// Extract .
+func (r *MerchantStaffEnableRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 启用或停用商户员工
+// @Summary 启用或停用商户员工
+// @Description enable or disable merchant staff, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.MerchantStaffEnableRequest true "参数"
+// @Success 200 {object} server.MerchantStaffEnableResponse "{"status":true}"
+// @Router /merchants/staff/enable [post]
+func (r *MerchantStaffEnableRequest) Exec(ctx context.Context) interface{} {
+	resp := MerchantStaffEnableResponse{}
+
+	code, err := svc.EnableMerchantStaff(ctx, r.TokenParames.UID, r.TokenParames.StaffID, r.StaffID, r.Enabled)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
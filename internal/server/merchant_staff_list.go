@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// MerchantStaffListRequest 获取商户员工列表
+type MerchantStaffListRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+}
+
+// MerchantStaffListResponse .
+type MerchantStaffListResponse struct {
+	wsgin.BaseResponse
+
+	Data []*model.MerchantStaff `json:"data"`
+}
+
+// New .
+func (r *MerchantStaffListRequest) New() wsgin.Process {
+	return &MerchantStaffListRequest{}
+}
+
+// Extract .
+func (r *MerchantStaffListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取商户员工列表
+// @Summary 获取商户员工列表
+// @Description get merchant staff list, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Success 200 {object} server.MerchantStaffListResponse "{"status":true}"
+// @Router /merchants/staff [get]
+func (r *MerchantStaffListRequest) Exec(ctx context.Context) interface{} {
+	resp := MerchantStaffListResponse{}
+
+	data, code, err := svc.GetMerchantStaffList(ctx, r.TokenParames.UID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// PointsAdjustRequest 后台调整客户积分
+type PointsAdjustRequest struct {
+	wsgin.MustAdminAuthRequest
+	wsgin.Idempotent
+
+	CustomerID uint64 `json:"customer_id" form:"customer_id" binding:"required"` // 客户ID
+	Amount     int64  `json:"amount" form:"amount" binding:"required"`           // 调整的积分，正数为增加，负数为扣减
+	Reason     string `json:"reason" form:"reason" binding:"required"`           // 调整原因
+}
+
+// PointsAdjustResponse .
+type PointsAdjustResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *PointsAdjustRequest) New() wsgin.Process {
+	return &PointsAdjustRequest{}
+}
+
+// Extract .
+func (r *PointsAdjustRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 调整客户积分
+// @Summary 调整客户积分
+// @Description adjust customer points with a reason
+// @Security ApiKeyAuth
+// @Tags 积分
+// @Accept json
+// @Produce json
+// @Param args body server.PointsAdjustRequest true "参数"
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
+// @Success 200 {object} server.PointsAdjustResponse "{"status":true}"
+// @Router /points/adjust [post]
+func (r *PointsAdjustRequest) Exec(ctx context.Context) interface{} {
+	resp := PointsAdjustResponse{}
+
+	code, err := svc.AdjustPoints(ctx, &model.PointsAdjustVO{
+		CustomerID: r.CustomerID,
+		Amount:     r.Amount,
+		Reason:     r.Reason,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// PointsBalanceRequest 获取积分余额
+type PointsBalanceRequest struct {
+	wsgin.MustCustomerAuthRequest
+}
+
+// PointsBalanceResponse .
+type PointsBalanceResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.PointsBalance `json:"data"`
+}
+
+// New .
+func (r *PointsBalanceRequest) New() wsgin.Process {
+	return &PointsBalanceRequest{}
+}
+
+// Extract .
+func (r *PointsBalanceRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取积分余额
+// @Summary 获取积分余额
+// @Description get customer points balance
+// @Security ApiKeyAuth
+// @Tags 积分
+// @Accept json
+// @Produce json
+// @Success 200 {object} server.PointsBalanceResponse "{"status":true}"
+// @Router /customers/points [get]
+func (r *PointsBalanceRequest) Exec(ctx context.Context) interface{} {
+	resp := PointsBalanceResponse{}
+
+	data, code, err := svc.GetPointsBalance(ctx, r.TokenParames.UID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
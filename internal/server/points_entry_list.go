@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// PointsEntryListRequest 获取积分流水
+type PointsEntryListRequest struct {
+	wsgin.MustCustomerAuthRequest
+
+	PageNo   int `form:"page_no" json:"page_no" example:"1" minimum:"1"`
+	PageSize int `form:"page_size" json:"page_size" example:"10" minimum:"1" maximum:"20" binding:"gte=0,lte=20"`
+}
+
+// PointsEntryListResponse .
+type PointsEntryListResponse struct {
+	wsgin.BasePagingResponse
+
+	Data []*model.PointsEntry `json:"data"`
+}
+
+// New .
+func (r *PointsEntryListRequest) New() wsgin.Process {
+	return &PointsEntryListRequest{}
+}
+
+// Extract .
+func (r *PointsEntryListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取积分流水
+// @Summary 获取积分流水
+// @Description get customer points history
+// @Security ApiKeyAuth
+// @Tags 积分
+// @Accept json
+// @Produce json
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.PointsEntryListResponse "{"status":true}"
+// @Router /customers/points/entries [get]
+func (r *PointsEntryListRequest) Exec(ctx context.Context) interface{} {
+	resp := PointsEntryListResponse{}
+
+	data, total, code, err := svc.GetPointsEntryList(ctx, r.TokenParames.UID, r.PageNo, r.PageSize)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Total = total
+	resp.Current = r.PageNo
+	return resp
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// PointsRedeemRequest 积分兑换
+type PointsRedeemRequest struct {
+	wsgin.MustCustomerAuthRequest
+	wsgin.Idempotent
+
+	Item string `json:"item" form:"item" binding:"required,oneof=supplement gift"` // 兑换项目：supplement(免费补签次数)，gift(下次领取福利时的额外福利)
+	Num  uint64 `json:"num" form:"num" binding:"required,gte=1,lte=100"`           // 兑换数量，单次最多100
+}
+
+// PointsRedeemResponse .
+type PointsRedeemResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *PointsRedeemRequest) New() wsgin.Process {
+	return &PointsRedeemRequest{}
+}
+
+// Extract .
+func (r *PointsRedeemRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 积分兑换
+// @Summary 积分兑换
+// @Description redeem points for free make-up days or extra gifts
+// @Security ApiKeyAuth
+// @Tags 积分
+// @Accept json
+// @Produce json
+// @Param args body server.PointsRedeemRequest true "参数"
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
+// @Success 200 {object} server.PointsRedeemResponse "{"status":true}"
+// @Router /customers/points/redeem [post]
+func (r *PointsRedeemRequest) Exec(ctx context.Context) interface{} {
+	resp := PointsRedeemResponse{}
+
+	code, err := svc.RedeemPoints(ctx, r.TokenParames.UID, &model.PointsRedeemVO{
+		Item: r.Item,
+		Num:  r.Num,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
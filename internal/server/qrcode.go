@@ -9,7 +9,7 @@ import (
 
 // QRCodeRequest .
 type QRCodeRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
 
 	Response gin.ResponseWriter
 }
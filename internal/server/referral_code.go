@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// ReferralCodeRequest 获取邀请码
+type ReferralCodeRequest struct {
+	wsgin.MustCustomerAuthRequest
+}
+
+// ReferralCodeResponse .
+type ReferralCodeResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.ReferralCodeResp `json:"data"`
+}
+
+// New .
+func (r *ReferralCodeRequest) New() wsgin.Process {
+	return &ReferralCodeRequest{}
+}
+
+// Extract .
+func (r *ReferralCodeRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取邀请码
+// @Summary 获取邀请码
+// @Description get referral code, pass it as the state of wechat oauth url to invite new customers
+// @Security ApiKeyAuth
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Success 200 {object} server.ReferralCodeResponse "{"status":true}"
+// @Router /customers/referral_code [get]
+func (r *ReferralCodeRequest) Exec(ctx context.Context) interface{} {
+	resp := ReferralCodeResponse{}
+
+	data, code, err := svc.GetReferralCode(ctx, r.TokenParames.UID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
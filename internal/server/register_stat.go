@@ -11,7 +11,7 @@ import (
 
 // RegisterStatRequest .
 type RegisterStatRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustAdminAuthRequest
 
 	BeginDate string `form:"begin_date" json:"begin_date" binding:"required"` // 开始日期
 	EndDate   string `form:"end_date" json:"end_date" binding:"required"`     // 结束日期
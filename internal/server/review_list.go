@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// ReviewListRequest 后台查询评价
+type ReviewListRequest struct {
+	wsgin.MustAdminAuthPagingRequest
+
+	MerchantID uint64 `json:"merchant_id" form:"merchant_id"` // 商户ID
+	Status     string `json:"status" form:"status"`           // 状态：A(展示中)，U(待审核或已隐藏)
+}
+
+// ReviewListResponse .
+type ReviewListResponse struct {
+	wsgin.BasePagingResponse
+
+	Data []*model.MerchantReviewItem `json:"data"`
+}
+
+// New .
+func (r *ReviewListRequest) New() wsgin.Process {
+	return &ReviewListRequest{}
+}
+
+// Extract .
+func (r *ReviewListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 后台查询评价
+// @Summary 后台查询评价
+// @Description get review list for moderation
+// @Security ApiKeyAuth
+// @Tags 评价
+// @Accept json
+// @Produce json
+// @Param merchant_id query int false "商户ID"
+// @Param status query string false "状态：A(展示中)，U(待审核或已隐藏)"
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.ReviewListResponse "{"status":true}"
+// @Router /reviews [get]
+func (r *ReviewListRequest) Exec(ctx context.Context) interface{} {
+	resp := ReviewListResponse{}
+
+	data, total, code, err := svc.GetReviewList(ctx, &model.MerchantReviewListVO{
+		MerchantID: r.MerchantID,
+		Status:     r.Status,
+		PageNo:     r.PageNo,
+		PageSize:   r.PageSize,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Total = total
+	resp.Current = r.PageNo
+	return resp
+}
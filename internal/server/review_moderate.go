@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// ReviewModerateRequest 后台审核评价
+type ReviewModerateRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	ID     uint64 `json:"id" form:"id" binding:"required"`                            // 评价ID
+	Action string `json:"action" form:"action" binding:"required,oneof=approve hide"` // 操作：approve(审核通过)，hide(隐藏)
+}
+
+// ReviewModerateResponse .
+type ReviewModerateResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *ReviewModerateRequest) New() wsgin.Process {
+	return &ReviewModerateRequest{}
+}
+
+// Extract .
+func (r *ReviewModerateRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 后台审核评价
+// @Summary 后台审核评价
+// @Description approve or hide a review, merchant rating is updated accordingly
+// @Security ApiKeyAuth
+// @Tags 评价
+// @Accept json
+// @Produce json
+// @Param args body server.ReviewModerateRequest true "参数"
+// @Success 200 {object} server.ReviewModerateResponse "{"status":true}"
+// @Router /reviews/moderate [post]
+func (r *ReviewModerateRequest) Exec(ctx context.Context) interface{} {
+	resp := ReviewModerateResponse{}
+
+	code, err := svc.ModerateMerchantReview(ctx, r.ID, r.Action)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
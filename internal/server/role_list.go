@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// RoleListRequest 获取后台角色列表
+type RoleListRequest struct {
+	wsgin.MustAdminAuthRequest
+}
+
+// RoleListResponse .
+type RoleListResponse struct {
+	wsgin.BaseResponse
+
+	Data []*model.Role `json:"data"`
+}
+
+// New .
+func (r *RoleListRequest) New() wsgin.Process {
+	return &RoleListRequest{}
+}
+
+// Extract .
+func (r *RoleListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取后台角色列表
+// @Summary 获取后台角色列表
+// @Description get role list with permissions
+// @Security ApiKeyAuth
+// @Tags 后台用户
+// @Accept json
+// @Produce json
+// @Success 200 {object} server.RoleListResponse "{"status":true}"
+// @Router /users/roles [get]
+func (r *RoleListRequest) Exec(ctx context.Context) interface{} {
+	resp := RoleListResponse{}
+
+	data, code, err := svc.GetRoleList(ctx)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
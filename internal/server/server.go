@@ -3,10 +3,12 @@ package server
 import (
 	"log"
 	"net/http"
+	"time"
 
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
 
+	"welfare-sign/internal/global"
 	"welfare-sign/internal/pkg/config"
 	"welfare-sign/internal/pkg/wsgin"
 	"welfare-sign/internal/service"
@@ -25,6 +27,12 @@ var (
 // New new server.
 func New(s *service.Service) (srv *http.Server) {
 	svc = s
+	wsgin.SetTokenRevokedFunc(svc.IsTokenRevoked)
+	wsgin.SetPermissionFunc(svc.HasPermission)
+	wsgin.SetIdempotencyStore(svc.IdempotencyStore(), viper.GetDuration(config.KeyIdempotencyTTL)*time.Second)
+	if err := wsgin.SetTrustedProxies(viper.GetStringSlice(config.KeyHTTPTrustedProxies)); err != nil {
+		panic(err)
+	}
 	router := wsgin.New()
 	initRouter(router)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -43,28 +51,51 @@ func initRouter(e *gin.Engine) {
 	e.StaticFile("/MP_verify_6IOxVtGiF56arfjR.txt", "./public/MP_verify_6IOxVtGiF56arfjR.txt")
 
 	v1 := e.Group("/v1")
+	perm := wsgin.RequirePermission
+
+	// 登录状态
+	v1.POST("/token/refresh", wsgin.ProcessExec(&TokenRefreshRequest{}))
+	v1.POST("/logout", wsgin.ProcessExec(&LogoutRequest{}))
 
 	// 商户
 	merchants := v1.Group("/merchants")
 	{
 		merchants.POST("/login", wsgin.ProcessExec(&MerchantLoginRequest{}))
-		merchants.POST("", wsgin.ProcessExec(&MerchantAddRequest{}))
-		merchants.GET("", wsgin.ProcessExec(&MerchantListRequest{}))
+		merchants.POST("", perm(global.PermMerchantWrite), wsgin.ProcessExec(&MerchantAddRequest{}))
+		merchants.GET("", perm(global.PermMerchantRead), wsgin.ProcessExec(&MerchantListRequest{}))
 		merchants.GET("/detail", wsgin.ProcessExec(&MerchantDetailRequest{}))
 		merchants.GET("/writeoff", wsgin.ProcessExec(&WriteOffRequest{}))
 		merchants.POST("/writeoff", wsgin.ProcessExec(&ExecWriteOffRequest{}))
-		merchants.PUT("", wsgin.ProcessExec(&MerchantEditRequest{}))
-		merchants.POST("/disable", wsgin.ProcessExec(&MerchantDisableRequest{}))
-		merchants.DELETE("", wsgin.ProcessExec(&MerchantDelRequest{}))
+		merchants.GET("/writeoff/logs", wsgin.ProcessExec(&WriteOffLogListRequest{}))
+		merchants.GET("/writeoff/summary", wsgin.ProcessExec(&WriteOffSummaryRequest{}))
+		merchants.GET("/writeoff/export", wsgin.ProcessExec(&WriteOffExportRequest{}))
+		merchants.POST("/writeoff/reverse", wsgin.ProcessExec(&WriteOffReverseRequest{}))
+		merchants.PUT("", perm(global.PermMerchantWrite), wsgin.ProcessExec(&MerchantEditRequest{}))
+		merchants.POST("/disable", perm(global.PermMerchantWrite), wsgin.ProcessExec(&MerchantDisableRequest{}))
+		merchants.DELETE("", perm(global.PermMerchantDelete), wsgin.ProcessExec(&MerchantDelRequest{}))
 		merchants.GET("/poster", wsgin.ProcessExec(&MerchantPosterRequest{}))
+		merchants.GET("/staff", wsgin.ProcessExec(&MerchantStaffListRequest{}))
+		merchants.POST("/staff", wsgin.ProcessExec(&MerchantStaffAddRequest{}))
+		merchants.DELETE("/staff", wsgin.ProcessExec(&MerchantStaffDelRequest{}))
+		merchants.POST("/staff/enable", wsgin.ProcessExec(&MerchantStaffEnableRequest{}))
+		merchants.GET("/gift_items", wsgin.ProcessExec(&GiftItemListRequest{}))
+		merchants.POST("/gift_items", wsgin.ProcessExec(&GiftItemAddRequest{}))
+		merchants.PUT("/gift_items", wsgin.ProcessExec(&GiftItemEditRequest{}))
+		merchants.POST("/gift_items/disable", wsgin.ProcessExec(&GiftItemDisableRequest{}))
+		merchants.GET("/reviews", wsgin.ProcessExec(&MerchantReviewListRequest{}))
+		merchants.POST("/reviews/reply", wsgin.ProcessExec(&MerchantReviewReplyRequest{}))
 	}
 
 	// 后台用户
 	users := v1.Group("/users")
 	{
 		users.POST("/login", wsgin.ProcessExec(&UserLoginRequest{}))
-		users.GET("/checkin_record_list", wsgin.ProcessExec(&CheckinRecordListRequest{}))
-		users.POST("/checkin_record_list/modify", wsgin.ProcessExec(&ModifyCheckinRecordRequest{}))
+		users.POST("/password", wsgin.ProcessExec(&UserPasswordRequest{}))
+		users.POST("", perm(global.PermUserManage), wsgin.ProcessExec(&UserAddRequest{}))
+		users.PUT("/role", perm(global.PermUserManage), wsgin.ProcessExec(&UserRoleRequest{}))
+		users.GET("/roles", perm(global.PermUserManage), wsgin.ProcessExec(&RoleListRequest{}))
+		users.GET("/checkin_record_list", perm(global.PermCheckinRead), wsgin.ProcessExec(&CheckinRecordListRequest{}))
+		users.POST("/checkin_record_list/modify", perm(global.PermCheckinWrite), wsgin.ProcessExec(&ModifyCheckinRecordRequest{}))
 	}
 
 	// 文件
@@ -77,10 +108,11 @@ func initRouter(e *gin.Engine) {
 	// 客户
 	customers := v1.Group("/customers")
 	{
-		customers.GET("", wsgin.ProcessExec(&CustomerListRequest{}))
+		customers.GET("", perm(global.PermCustomerRead), wsgin.ProcessExec(&CustomerListRequest{}))
 		customers.GET("/detail", wsgin.ProcessExec(&CustomerDetailRequest{}))
 		customers.GET("/checkin_record", wsgin.ProcessExec(&CheckinRecordRequest{}))      // 获取签到记录
 		customers.POST("/checkin_record", wsgin.ProcessExec(&ExecCheckinRecordRequest{})) // 签到
+		customers.GET("/checkin_history", wsgin.ProcessExec(&CheckinHistoryRequest{}))    // 签到历史
 		customers.GET("/qrcode", wsgin.ProcessExec(&QRCodeRequest{}))                     // 获取二维码
 		customers.POST("/login", wsgin.ProcessExec(&CustomerLoginRequest{}))
 		customers.GET("/near_merchant", wsgin.ProcessExec(&NearMerchantRequest{}))                      // 获取附近商家
@@ -89,8 +121,20 @@ func initRouter(e *gin.Engine) {
 		customers.POST("/checkin_record/refresh", wsgin.ProcessExec(&RefreshCheckinRecordRequest{}))    // 用户重新签到
 		customers.POST("/checkin_record/help", wsgin.ProcessExec(&HelpCheckinRequest{}))                // 帮助他人签到
 		customers.GET("/issue_records/is_supplement", wsgin.ProcessExec(&IsSupplementCheckinRequest{})) // 是否是补签
-		customers.POST("/disable", wsgin.ProcessExec(&CustomerDisableRequest{}))
-		customers.DELETE("", wsgin.ProcessExec(&CustomerDelRequest{}))
+		customers.POST("/help_invitations", wsgin.ProcessExec(&HelpInvitationAddRequest{}))             // 生成补签邀请
+		customers.GET("/help_invitations/open", wsgin.ProcessExec(&HelpInvitationOpenRequest{}))        // 打开补签邀请
+		customers.GET("/helpers", wsgin.ProcessExec(&HelperListRequest{}))                              // 帮我补签的好友
+		customers.GET("/referral_code", wsgin.ProcessExec(&ReferralCodeRequest{}))                      // 获取邀请码
+		customers.POST("/checkin_record/credit", wsgin.ProcessExec(&CreditCheckinRequest{}))            // 使用免费补签次数补签
+		customers.GET("/points", wsgin.ProcessExec(&PointsBalanceRequest{}))                            // 积分余额
+		customers.GET("/points/entries", wsgin.ProcessExec(&PointsEntryListRequest{}))                  // 积分流水
+		customers.POST("/points/redeem", wsgin.ProcessExec(&PointsRedeemRequest{}))                     // 积分兑换
+		customers.GET("/badges", wsgin.ProcessExec(&CustomerBadgesRequest{}))                           // 我的徽章
+		customers.GET("/messages", wsgin.ProcessExec(&InboxMessageListRequest{}))                       // 站内信
+		customers.POST("/messages/read", wsgin.ProcessExec(&InboxMessageReadRequest{}))                 // 站内信全部已读
+		customers.POST("/reviews", wsgin.ProcessExec(&MerchantReviewAddRequest{}))                      // 评价商户
+		customers.POST("/disable", perm(global.PermCustomerWrite), wsgin.ProcessExec(&CustomerDisableRequest{}))
+		customers.DELETE("", perm(global.PermCustomerDelete), wsgin.ProcessExec(&CustomerDelRequest{}))
 		customers.GET("/can_part_lucky_number_activity", wsgin.ProcessExec(&CanPartLuckyNumberActivityRequest{}))
 		customers.POST("/lucky_number", wsgin.ProcessExec(&LuckyNumberAddRequest{}))
 		customers.GET("/lucky_number/before", wsgin.ProcessExec(&LuckyNumberBeforeRequest{}))
@@ -114,14 +158,38 @@ func initRouter(e *gin.Engine) {
 
 	composite := v1.Group("/composite_index")
 	{
-		composite.POST("", wsgin.ProcessExec(&CompositeIndexAddRequest{}))
+		composite.POST("", perm(global.PermCompositeIndex), wsgin.ProcessExec(&CompositeIndexAddRequest{}))
 		composite.GET("", wsgin.ProcessExec(&CompositeIndexDetailRequest{}))
 	}
 
+	// 签到活动
+	campaigns := v1.Group("/campaigns")
+	{
+		campaigns.GET("", perm(global.PermCampaignRead), wsgin.ProcessExec(&CampaignListRequest{}))
+		campaigns.GET("/detail", perm(global.PermCampaignRead), wsgin.ProcessExec(&CampaignDetailRequest{}))
+		campaigns.POST("", perm(global.PermCampaignWrite), wsgin.ProcessExec(&CampaignAddRequest{}))
+		campaigns.PUT("", perm(global.PermCampaignWrite), wsgin.ProcessExec(&CampaignEditRequest{}))
+		campaigns.POST("/disable", perm(global.PermCampaignWrite), wsgin.ProcessExec(&CampaignDisableRequest{}))
+	}
+
+	// 积分
+	v1.POST("/points/adjust", perm(global.PermPointsWrite), wsgin.ProcessExec(&PointsAdjustRequest{}))
+
+	// 评价
+	reviews := v1.Group("/reviews")
+	{
+		reviews.GET("", perm(global.PermReviewRead), wsgin.ProcessExec(&ReviewListRequest{}))
+		reviews.POST("/moderate", perm(global.PermReviewModerate), wsgin.ProcessExec(&ReviewModerateRequest{}))
+	}
+
+	// 审计日志
+	v1.GET("/audit_logs", perm(global.PermAuditRead), wsgin.ProcessExec(&AuditLogListRequest{}))
+
 	stat := v1.Group("/stat")
 	{
-		stat.GET("/register", wsgin.ProcessExec(&RegisterStatRequest{}))
-		stat.GET("/checkin", wsgin.ProcessExec(&CheckinStatRequest{}))
+		stat.GET("/register", perm(global.PermStatRead), wsgin.ProcessExec(&RegisterStatRequest{}))
+		stat.GET("/checkin", perm(global.PermStatRead), wsgin.ProcessExec(&CheckinStatRequest{}))
+		stat.GET("/invitation", perm(global.PermStatRead), wsgin.ProcessExec(&InvitationStatRequest{}))
 	}
 }
 
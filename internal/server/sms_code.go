@@ -42,7 +42,7 @@ func (r *CodeRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
 func (r *CodeRequest) Exec(ctx context.Context) interface{} {
 	resp := CodeResponse{}
 
-	code, err := svc.SendVerifyCode(ctx, r.Mobile)
+	code, err := svc.SendVerifyCode(ctx, r.Mobile, wsgin.ClientIPFromContext(ctx))
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
 	return resp
 }
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// TokenRefreshRequest .
+type TokenRefreshRequest struct {
+	wsgin.BaseRequest
+
+	RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"` // 登录时返回的refresh_token
+}
+
+// TokenRefreshResponse .
+type TokenRefreshResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.TokenResp `json:"data"`
+}
+
+// New .
+func (r *TokenRefreshRequest) New() wsgin.Process {
+	return &TokenRefreshRequest{}
+}
+
+// Extract .
+func (r *TokenRefreshRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 刷新token
+// @Summary 刷新token
+// @Description refresh access token, the old refresh token becomes invalid
+// @Tags 登录
+// @Accept json
+// @Produce json
+// @Param args body server.TokenRefreshRequest true "参数"
+// @Success 200 {object} server.TokenRefreshResponse "{"status":true}"
+// @Router /token/refresh [post]
+func (r *TokenRefreshRequest) Exec(ctx context.Context) interface{} {
+	resp := TokenRefreshResponse{}
+
+	data, code, err := svc.RefreshToken(ctx, r.RefreshToken)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
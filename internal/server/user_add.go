@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// UserAddRequest 新增后台用户
+type UserAddRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	Name     string `json:"name" form:"name" binding:"required"`         // 用户名
+	Password string `json:"password" form:"password" binding:"required"` // 密码
+	RoleID   uint64 `json:"role_id" form:"role_id" binding:"required"`   // 角色ID
+}
+
+// UserAddResponse .
+type UserAddResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *UserAddRequest) New() wsgin.Process {
+	return &UserAddRequest{}
+}
+
+// Extract .
+func (r *UserAddRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 新增后台用户
+// @Summary 新增后台用户
+// @Description add user
+// @Security ApiKeyAuth
+// @Tags 后台用户
+// @Accept json
+// @Produce json
+// @Param args body server.UserAddRequest true "参数"
+// @Success 200 {object} server.UserAddResponse "{"status":true}"
+// @Router /users [post]
+func (r *UserAddRequest) Exec(ctx context.Context) interface{} {
+	resp := UserAddResponse{}
+
+	code, err := svc.AddUser(ctx, &model.UserAddVO{
+		Name:     r.Name,
+		Password: r.Password,
+		RoleID:   r.RoleID,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
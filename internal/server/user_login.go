@@ -21,7 +21,7 @@ type UserLoginRequest struct {
 type UserLoginResponse struct {
 	wsgin.BaseResponse
 
-	Data string `json:"data"` // access token
+	Data *model.TokenResp `json:"data"`
 }
 
 // New .
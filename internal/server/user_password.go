@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// UserPasswordRequest 后台用户修改密码
+type UserPasswordRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	OldPassword string `json:"old_password" form:"old_password" binding:"required"` // 原密码
+	NewPassword string `json:"new_password" form:"new_password" binding:"required"` // 新密码
+}
+
+// UserPasswordResponse .
+type UserPasswordResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *UserPasswordRequest) New() wsgin.Process {
+	return &UserPasswordRequest{}
+}
+
+// Extract .
+func (r *UserPasswordRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 后台用户修改密码
+// @Summary 后台用户修改密码
+// @Description user change password, all sessions of the user will be revoked
+// @Security ApiKeyAuth
+// @Tags 后台用户
+// @Accept json
+// @Produce json
+// @Param args body server.UserPasswordRequest true "参数"
+// @Success 200 {object} server.UserPasswordResponse "{"status":true}"
+// @Router /users/password [post]
+func (r *UserPasswordRequest) Exec(ctx context.Context) interface{} {
+	resp := UserPasswordResponse{}
+
+	code, err := svc.ChangePassword(ctx, r.TokenParames.UID, &model.UserPasswordVO{
+		OldPassword: r.OldPassword,
+		NewPassword: r.NewPassword,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
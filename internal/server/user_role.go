@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// UserRoleRequest 修改后台用户角色
+type UserRoleRequest struct {
+	wsgin.MustAdminAuthRequest
+
+	UserID uint64 `json:"user_id" form:"user_id" binding:"required"` // 用户ID
+	RoleID uint64 `json:"role_id" form:"role_id" binding:"required"` // 角色ID
+}
+
+// UserRoleResponse .
+type UserRoleResponse struct {
+	wsgin.BaseResponse
+}
+
+// New .
+func (r *UserRoleRequest) New() wsgin.Process {
+	return &UserRoleRequest{}
+}
+
+// Extract .
+func (r *UserRoleRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 修改后台用户角色
+// @Summary 修改后台用户角色
+// @Description update user role
+// @Security ApiKeyAuth
+// @Tags 后台用户
+// @Accept json
+// @Produce json
+// @Param args body server.UserRoleRequest true "参数"
+// @Success 200 {object} server.UserRoleResponse "{"status":true}"
+// @Router /users/role [put]
+func (r *UserRoleRequest) Exec(ctx context.Context) interface{} {
+	resp := UserRoleResponse{}
+
+	code, err := svc.UpdateUserRole(ctx, r.UserID, r.RoleID)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	return resp
+}
@@ -11,9 +11,11 @@ import (
 
 // WriteOffRequest .
 type WriteOffRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustMerchantAuthRequest
 
-	CustomerID uint64 `json:"customer_id" form:"customer_id" binding:"required" example:"客户ID"`
+	Code       string `json:"code" form:"code" example:"核销码"`                // 客户二维码中的核销码
+	CustomerID uint64 `json:"customer_id" form:"customer_id" example:"客户ID"` // 客户ID，仅在允许使用客户ID核销时有效
+	GiftItemID uint64 `json:"gift_item_id" form:"gift_item_id"`              // 礼品ID，客户在该商户有多份福利时指定
 }
 
 // WriteOffResponse .
@@ -40,13 +42,15 @@ func (r *WriteOffRequest) Extract(c *gin.Context) (code wsgin.APICode, err error
 // @Tags 商户
 // @Accept json
 // @Produce json
-// @Param customer_id query int true "客户ID"
+// @Param code query string false "核销码"
+// @Param customer_id query int false "客户ID，仅在允许使用客户ID核销时有效"
+// @Param gift_item_id query int false "礼品ID，客户在该商户有多份福利时指定"
 // @Success 200 {object} server.WriteOffResponse	"{"status":true}"
 // @Router /merchants/writeoff [get]
 func (r *WriteOffRequest) Exec(ctx context.Context) interface{} {
 	resp := WriteOffResponse{}
 
-	data, code, err := svc.GetWriteOff(ctx, r.TokenParames.UID, r.CustomerID)
+	data, code, err := svc.GetWriteOffByCode(ctx, r.TokenParames.UID, r.Code, r.CustomerID, r.GiftItemID)
 	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
 	resp.Data = data
 	return resp
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// WriteOffExportRequest 导出商户核销记录
+type WriteOffExportRequest struct {
+	wsgin.MustMerchantOwnerAuthRequest
+
+	BeginDate string `form:"begin_date" json:"begin_date" binding:"required" example:"2019-10-01"`
+	EndDate   string `form:"end_date" json:"end_date" binding:"required" example:"2019-10-31"`
+
+	Response gin.ResponseWriter
+}
+
+// New .
+func (r *WriteOffExportRequest) New() wsgin.Process {
+	return &WriteOffExportRequest{}
+}
+
+// Extract .
+func (r *WriteOffExportRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	r.Response = c.Writer
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 导出商户核销记录
+// @Summary 导出商户核销记录
+// @Description export merchant write off history as csv, merchant owner only
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce octet-stream
+// @Param begin_date query string true "开始日期"
+// @Param end_date query string true "结束日期"
+// @Success 200 {string} string "csv"
+// @Router /merchants/writeoff/export [get]
+func (r *WriteOffExportRequest) Exec(ctx context.Context) interface{} {
+	data, code, err := svc.ExportWriteOffLog(ctx, &model.WriteOffLogListVO{
+		MerchantID: r.TokenParames.UID,
+		BeginDate:  r.BeginDate,
+		EndDate:    r.EndDate,
+	})
+	if err != nil {
+		return wsgin.NewResponse(ctx, code, err)
+	}
+	filename := "writeoff_" + r.BeginDate + "_" + r.EndDate + ".csv"
+	r.Response.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	r.Response.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	r.Response.Write(data)
+	return nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// WriteOffLogListRequest 获取商户核销记录
+type WriteOffLogListRequest struct {
+	wsgin.MustMerchantAuthRequest
+
+	BeginDate string `form:"begin_date" json:"begin_date" example:"2019-10-01"`
+	EndDate   string `form:"end_date" json:"end_date" example:"2019-10-31"`
+	PageNo    int    `form:"page_no" json:"page_no" example:"1" minimum:"1"`
+	PageSize  int    `form:"page_size" json:"page_size" example:"10" minimum:"1" maximum:"50" binding:"gte=0,lte=50"`
+}
+
+// WriteOffLogListResponse .
+type WriteOffLogListResponse struct {
+	wsgin.BasePagingResponse
+
+	Data []*model.WriteOffLogItem `json:"data"`
+}
+
+// New .
+func (r *WriteOffLogListRequest) New() wsgin.Process {
+	return &WriteOffLogListRequest{}
+}
+
+// Extract .
+func (r *WriteOffLogListRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取商户核销记录
+// @Summary 获取商户核销记录
+// @Description get merchant write off history, default today
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param begin_date query string false "开始日期"
+// @Param end_date query string false "结束日期"
+// @Param page_no query int false "页码" default(1)
+// @Param page_size query int false "页数" default(10)
+// @Success 200 {object} server.WriteOffLogListResponse "{"status":true}"
+// @Router /merchants/writeoff/logs [get]
+func (r *WriteOffLogListRequest) Exec(ctx context.Context) interface{} {
+	resp := WriteOffLogListResponse{}
+
+	vo := &model.WriteOffLogListVO{
+		MerchantID: r.TokenParames.UID,
+		BeginDate:  r.BeginDate,
+		EndDate:    r.EndDate,
+		PageNo:     r.PageNo,
+		PageSize:   r.PageSize,
+	}
+	data, total, code, err := svc.GetWriteOffLogList(ctx, vo)
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	resp.Total = total
+	resp.Current = vo.PageNo
+	return resp
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// WriteOffReverseRequest .
+type WriteOffReverseRequest struct {
+	wsgin.MustMerchantAuthRequest
+	wsgin.Idempotent
+
+	ID     uint64 `form:"id" json:"id" binding:"required"`                 // 核销记录ID
+	Reason string `form:"reason" json:"reason" binding:"required,max=200"` // 撤销原因
+}
+
+// WriteOffReverseResponse .
+type WriteOffReverseResponse struct {
+	wsgin.BaseResponse
+
+	Data *model.WriteOffLog `json:"data"`
+}
+
+// New .
+func (r *WriteOffReverseRequest) New() wsgin.Process {
+	return &WriteOffReverseRequest{}
+}
+
+// Extract .
+func (r *WriteOffReverseRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 撤销核销
+// @Summary 撤销核销
+// @Description merchant reverse a write off within the reverse window
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param args body server.WriteOffReverseRequest true "参数"
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
+// @Success 200 {object} server.WriteOffReverseResponse "{"status":true}"
+// @Router /merchants/writeoff/reverse [post]
+func (r *WriteOffReverseRequest) Exec(ctx context.Context) interface{} {
+	resp := WriteOffReverseResponse{}
+
+	data, code, err := svc.ReverseWriteOff(ctx, &model.WriteOffReverseVO{
+		ID:         r.ID,
+		MerchantID: r.TokenParames.UID,
+		StaffID:    r.TokenParames.StaffID,
+		Reason:     r.Reason,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
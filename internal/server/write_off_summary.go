@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// WriteOffSummaryRequest 获取商户每日核销汇总
+type WriteOffSummaryRequest struct {
+	wsgin.MustMerchantAuthRequest
+
+	BeginDate string `form:"begin_date" json:"begin_date" example:"2019-10-01"`
+	EndDate   string `form:"end_date" json:"end_date" example:"2019-10-31"`
+}
+
+// WriteOffSummaryResponse .
+type WriteOffSummaryResponse struct {
+	wsgin.BaseResponse
+
+	Data []*model.WriteOffSummary `json:"data"`
+}
+
+// New .
+func (r *WriteOffSummaryRequest) New() wsgin.Process {
+	return &WriteOffSummaryRequest{}
+}
+
+// Extract .
+func (r *WriteOffSummaryRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
+	return r.DefaultExtract(r, c)
+}
+
+// Exec 获取商户每日核销汇总
+// @Summary 获取商户每日核销汇总
+// @Description get merchant daily write off summary, default today
+// @Security ApiKeyAuth
+// @Tags 商户
+// @Accept json
+// @Produce json
+// @Param begin_date query string false "开始日期"
+// @Param end_date query string false "结束日期"
+// @Success 200 {object} server.WriteOffSummaryResponse "{"status":true}"
+// @Router /merchants/writeoff/summary [get]
+func (r *WriteOffSummaryRequest) Exec(ctx context.Context) interface{} {
+	resp := WriteOffSummaryResponse{}
+
+	data, code, err := svc.GetWriteOffSummary(ctx, &model.WriteOffLogListVO{
+		MerchantID: r.TokenParames.UID,
+		BeginDate:  r.BeginDate,
+		EndDate:    r.EndDate,
+	})
+	resp.BaseResponse = wsgin.NewResponse(ctx, code, err)
+	resp.Data = data
+	return resp
+}
@@ -10,7 +10,8 @@ import (
 
 // WXPayRequest .
 type WXPayRequest struct {
-	wsgin.MustAuthRequest
+	wsgin.MustCustomerAuthRequest
+	wsgin.Idempotent
 }
 
 // WXPayResponse .
@@ -37,6 +38,7 @@ func (r *WXPayRequest) Extract(c *gin.Context) (code wsgin.APICode, err error) {
 // @Tags 微信
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "幂等键，重复提交时返回第一次成功的结果"
 // @Success 200 {object} server.WXPayResponse	"{"status":true}"
 // @Router /wx/pay [post]
 func (r *WXPayRequest) Exec(ctx context.Context) interface{} {
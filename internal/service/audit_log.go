@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// audit 记录后台操作审计日志，before、after为操作前后的数据，新增或删除时可传nil
+// 审计日志写入失败不影响业务操作，仅记录错误日志
+func (s *Service) audit(ctx context.Context, action, entityType string, entityID uint64, before, after interface{}) {
+	data := &model.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		ClientIP:   wsgin.ClientIPFromContext(ctx),
+		RequestID:  wsgin.RequestIDFromContext(ctx),
+	}
+	if token := wsgin.TokenFromContext(ctx); token != nil {
+		data.ActorID = token.UID
+		data.ActorName = token.Name
+		data.ActorRole = token.Role
+	}
+
+	beforeMap, err := auditSnapshot(before)
+	if err != nil {
+		log.Error(ctx, "audit.auditSnapshot() error", zap.String("action", action), zap.Error(err))
+		return
+	}
+	afterMap, err := auditSnapshot(after)
+	if err != nil {
+		log.Error(ctx, "audit.auditSnapshot() error", zap.String("action", action), zap.Error(err))
+		return
+	}
+	data.Before = auditJSON(beforeMap)
+	data.After = auditJSON(afterMap)
+	data.Diff = auditJSON(auditDiff(beforeMap, afterMap))
+
+	if err := s.dao.CreateAuditLog(ctx, data); err != nil {
+		log.Error(ctx, "audit.CreateAuditLog() error", zap.String("action", action), zap.Uint64("entity_id", entityID), zap.Error(err))
+	}
+}
+
+// auditSnapshot 将数据转换为字段map
+func auditSnapshot(v interface{}) (map[string]interface{}, error) {
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// auditDiff 对比前后数据，返回发生变化的字段，忽略updated_at
+func auditDiff(before, after map[string]interface{}) map[string]interface{} {
+	diff := make(map[string]interface{})
+	keys := make(map[string]struct{})
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+	for k := range keys {
+		if k == "updated_at" {
+			continue
+		}
+		b, a := before[k], after[k]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		diff[k] = map[string]interface{}{"before": b, "after": a}
+	}
+	return diff
+}
+
+func auditJSON(m map[string]interface{}) string {
+	if len(m) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+// GetAuditLogList 分页查询审计日志
+func (s *Service) GetAuditLogList(ctx context.Context, vo *model.AuditLogListVO) ([]*model.AuditLog, int, wsgin.APICode, error) {
+	if vo.PageNo == 0 {
+		vo.PageNo = 1
+	}
+	if vo.PageSize == 0 {
+		vo.PageSize = 10
+	}
+	logs, total, err := s.dao.ListAuditLog(ctx, vo)
+	if err != nil {
+		return nil, total, apicode.ErrGetListData, err
+	}
+	return logs, total, wsgin.APICodeSuccess, nil
+}
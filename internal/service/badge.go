@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/badge"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// GetCustomerBadges 获取客户的徽章，包含已获得和未获得的徽章
+// 已达到条件但还未发放的徽章(如新增的徽章)在此时补发
+func (s *Service) GetCustomerBadges(ctx context.Context, customerID uint64) ([]*model.BadgeVO, wsgin.APICode, error) {
+	stats, earned, err := s.evaluateBadges(ctx, customerID)
+	if err != nil {
+		return nil, apicode.ErrGetListData, err
+	}
+	data := make([]*model.BadgeVO, 0, len(s.badges))
+	for _, def := range s.badges {
+		vo := &model.BadgeVO{
+			Code:        def.Code,
+			Name:        def.Name,
+			Description: def.Description,
+			Threshold:   def.Threshold,
+			Progress:    stats[def.Metric],
+		}
+		if vo.Progress > def.Threshold {
+			vo.Progress = def.Threshold
+		}
+		if at, ok := earned[def.Code]; ok {
+			vo.Earned = true
+			vo.EarnedAt = &at
+		}
+		data = append(data, vo)
+	}
+	return data, wsgin.APICodeSuccess, nil
+}
+
+// awardBadges 发放客户已达到条件的徽章，在签到、帮签、领取福利等操作之后调用，发放失败只记录日志
+func (s *Service) awardBadges(ctx context.Context, customerID uint64) {
+	if _, _, err := s.evaluateBadges(ctx, customerID); err != nil {
+		log.Warn(ctx, "awardBadges.evaluateBadges() error", zap.Uint64("customer_id", customerID), zap.Error(err))
+	}
+}
+
+// evaluateBadges 计算客户的统计指标并发放已达到条件的徽章，返回统计指标及已获得徽章的获得时间
+func (s *Service) evaluateBadges(ctx context.Context, customerID uint64) (badge.Stats, map[string]time.Time, error) {
+	stats, err := s.badgeStats(ctx, customerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	badges, err := s.dao.ListCustomerBadge(ctx, customerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	earned := make(map[string]time.Time, len(badges))
+	for _, b := range badges {
+		earned[b.Code] = b.CreatedAt
+	}
+	for _, def := range s.badges {
+		if _, ok := earned[def.Code]; ok || !def.Reached(stats) {
+			continue
+		}
+		data := &model.CustomerBadge{CustomerID: customerID, Code: def.Code}
+		if err := s.dao.CreateCustomerBadge(ctx, data); err != nil {
+			return nil, nil, err
+		}
+		earned[def.Code] = data.CreatedAt
+	}
+	return stats, earned, nil
+}
+
+// badgeStats 计算客户各项徽章统计指标
+func (s *Service) badgeStats(ctx context.Context, customerID uint64) (badge.Stats, error) {
+	total, err := s.dao.CountCheckin(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	_, longest, err := s.checkinStreaks(ctx, customerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	stat, err := s.dao.GetBadgeStat(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	return badge.Stats{
+		badge.MetricCheckinDays:   int64(total),
+		badge.MetricCheckinStreak: int64(longest),
+		badge.MetricHelpFriends:   stat.HelpFriends,
+		badge.MetricMerchants:     stat.Merchants,
+		badge.MetricLuckyWins:     stat.LuckyWins,
+	}, nil
+}
+
+// awardLuckyWinnerBadges 上证指数录入后，为本轮幸运数字第一名发放徽章
+func (s *Service) awardLuckyWinnerBadges(ctx context.Context) {
+	records, err := s.dao.GetRoundLuckyNumberRecordSQL(ctx)
+	if err != nil {
+		log.Warn(ctx, "awardLuckyWinnerBadges.GetRoundLuckyNumberRecordSQL() error", zap.Error(err))
+		return
+	}
+	for _, record := range records {
+		if record.Ranking == 1 && record.Status == global.ActiveStatus {
+			s.awardBadges(ctx, record.CustomerID)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// AddCampaign 新增签到活动
+func (s *Service) AddCampaign(ctx context.Context, vo *model.CampaignVO) (wsgin.APICode, error) {
+	campaign := &model.Campaign{}
+	if code, err := s.fillCampaign(ctx, campaign, vo); err != nil {
+		return code, err
+	}
+	if err := s.dao.CreateCampaign(ctx, campaign); err != nil {
+		return apicode.ErrModelCreate, err
+	}
+	s.audit(ctx, global.AuditActionCampaignAdd, global.AuditEntityCampaign, campaign.ID, nil, campaign)
+	return wsgin.APICodeSuccess, nil
+}
+
+// EditCampaign 编辑签到活动，已参加活动的客户仍按原来的签到周期签到
+func (s *Service) EditCampaign(ctx context.Context, campaignID uint64, vo *model.CampaignVO) (wsgin.APICode, error) {
+	campaign, err := s.dao.FindCampaign(ctx, map[string]interface{}{"id": campaignID})
+	if err != nil {
+		return apicode.ErrEditCampaign, err
+	}
+	if campaign.ID == 0 {
+		return apicode.ErrCampaignNotExists, errors.New("签到活动不存在")
+	}
+	before := *campaign
+	if code, err := s.fillCampaign(ctx, campaign, vo); err != nil {
+		return code, err
+	}
+	if err := s.dao.UpdateCampaign(ctx, campaign); err != nil {
+		return apicode.ErrEditCampaign, err
+	}
+	s.audit(ctx, global.AuditActionCampaignEdit, global.AuditEntityCampaign, campaign.ID, &before, campaign)
+	return wsgin.APICodeSuccess, nil
+}
+
+// DisableCampaign 停用签到活动，停用后新一轮签到不再参加该活动
+func (s *Service) DisableCampaign(ctx context.Context, campaignID uint64) (wsgin.APICode, error) {
+	campaign, err := s.dao.FindCampaign(ctx, map[string]interface{}{"id": campaignID})
+	if err != nil {
+		return apicode.ErrDisable, err
+	}
+	if campaign.ID == 0 {
+		return apicode.ErrCampaignNotExists, errors.New("签到活动不存在")
+	}
+	if campaign.Status != global.ActiveStatus {
+		return wsgin.APICodeSuccess, nil
+	}
+	before := *campaign
+	campaign.Status = global.DeleteStatus
+	if err := s.dao.UpdateCampaign(ctx, campaign); err != nil {
+		return apicode.ErrDisable, err
+	}
+	s.audit(ctx, global.AuditActionCampaignDisable, global.AuditEntityCampaign, campaign.ID, &before, campaign)
+	return wsgin.APICodeSuccess, nil
+}
+
+// GetCampaignList 获取签到活动列表
+func (s *Service) GetCampaignList(ctx context.Context, vo *model.CampaignListVO) ([]*model.Campaign, int, wsgin.APICode, error) {
+	query := make(map[string]interface{})
+	if vo.Name != "" {
+		query["name"] = vo.Name
+	}
+	if vo.Status != "" {
+		query["status"] = vo.Status
+	}
+	if vo.PageNo == 0 {
+		vo.PageNo = 1
+	}
+	if vo.PageSize == 0 {
+		vo.PageSize = 10
+	}
+
+	campaigns, total, err := s.dao.ListCampaign(ctx, query, vo.PageNo, vo.PageSize)
+	if err != nil {
+		return nil, total, apicode.ErrGetListData, err
+	}
+	return campaigns, total, wsgin.APICodeSuccess, nil
+}
+
+// GetCampaignDetail 获取签到活动详情
+func (s *Service) GetCampaignDetail(ctx context.Context, campaignID uint64) (*model.Campaign, wsgin.APICode, error) {
+	campaign, err := s.dao.FindCampaign(ctx, map[string]interface{}{"id": campaignID})
+	if err != nil {
+		return nil, apicode.ErrDetail, err
+	}
+	if campaign.ID == 0 {
+		return nil, apicode.ErrCampaignNotExists, errors.New("签到活动不存在")
+	}
+	return campaign, wsgin.APICodeSuccess, nil
+}
+
+// fillCampaign 校验参数并填充签到活动
+func (s *Service) fillCampaign(ctx context.Context, campaign *model.Campaign, vo *model.CampaignVO) (wsgin.APICode, error) {
+	startDate, err := s.cal.ParseDate(vo.StartDate)
+	if err != nil {
+		return apicode.ErrCampaignDate, err
+	}
+	endDate, err := s.cal.ParseDate(vo.EndDate)
+	if err != nil {
+		return apicode.ErrCampaignDate, err
+	}
+	if endDate.Before(startDate) {
+		return apicode.ErrCampaignDate, errors.New("结束日期不能早于开始日期")
+	}
+
+	merchants := make([]uint64, 0, len(vo.Merchants))
+	seen := make(map[uint64]bool, len(vo.Merchants))
+	for _, merchantID := range vo.Merchants {
+		if seen[merchantID] {
+			continue
+		}
+		seen[merchantID] = true
+		merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{"id": merchantID})
+		if err != nil {
+			return apicode.ErrEditCampaign, err
+		}
+		if merchant.ID == 0 {
+			return wsgin.APICodeInvalidParame, errors.Errorf("商户%d不存在", merchantID)
+		}
+		merchants = append(merchants, merchantID)
+	}
+
+	campaign.Name = vo.Name
+	campaign.CycleDays = vo.CycleDays
+	campaign.StartDate = startDate
+	campaign.EndDate = endDate
+	campaign.RewardNum = vo.RewardNum
+	campaign.Remark = vo.Remark
+	campaign.GeofenceRadius = vo.GeofenceRadius
+	campaign.Merchants = merchants
+	return wsgin.APICodeSuccess, nil
+}
+
+// currentCampaign 获取开始新一轮签到时应参加的签到活动
+// 没有进行中的活动时返回ID为0、签到周期为默认天数的活动
+func (s *Service) currentCampaign(ctx context.Context) (*model.Campaign, error) {
+	campaign, err := s.dao.GetCurrentCampaign(ctx, s.cal.Date(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	if campaign.ID == 0 {
+		campaign.CycleDays = viper.GetUint64(config.KeyCheckinCycleDays)
+		campaign.GeofenceRadius = viper.GetFloat64(config.KeyCheckinGeofenceRadius)
+	}
+	return campaign, nil
+}
+
+// checkinCampaign 获取客户本轮签到所参加的签到活动，活动停用或结束后已参加的客户仍可完成本轮签到
+// 签到周期以本轮初始化的签到天数为准，活动修改周期不影响已参加的客户
+func (s *Service) checkinCampaign(ctx context.Context, records []*model.CheckinRecord) (*model.Campaign, error) {
+	campaign := &model.Campaign{}
+	if len(records) > 0 && records[0].CampaignID != 0 {
+		var err error
+		if campaign, err = s.dao.FindCampaign(ctx, map[string]interface{}{"id": records[0].CampaignID}); err != nil {
+			return nil, err
+		}
+	}
+	if campaign.ID == 0 {
+		campaign.GeofenceRadius = viper.GetFloat64(config.KeyCheckinGeofenceRadius)
+	}
+	campaign.CycleDays = uint64(len(records))
+	return campaign, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/bizday"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+const checkinMonthLayout = "2006-01"
+
+// checkinStreakDays 计算连续签到天数时向前查询的业务日数，超过的部分不计入最长连续签到
+const checkinStreakDays = 366
+
+// GetCheckinHistory 获取客户签到历史，month 为日历月份，为空时为当前月，领取福利记录分页返回
+func (s *Service) GetCheckinHistory(ctx context.Context, customerID uint64, month string, pageNo, pageSize int) (*model.CheckinHistory, wsgin.APICode, error) {
+	now := time.Now()
+	if month == "" {
+		month = s.cal.Date(now)[:len(checkinMonthLayout)]
+	}
+	first, err := time.Parse(checkinMonthLayout, month)
+	if err != nil {
+		return nil, wsgin.APICodeInvalidParame, err
+	}
+	if pageNo == 0 {
+		pageNo = 1
+	}
+	if pageSize == 0 {
+		pageSize = 10
+	}
+
+	monthStart, monthEnd, err := s.cal.DateRange(first.Format(bizday.DateLayout), first.AddDate(0, 1, -1).Format(bizday.DateLayout))
+	if err != nil {
+		return nil, wsgin.APICodeInvalidParame, err
+	}
+	monthTimes, err := s.dao.ListCheckinTime(ctx, customerID, monthStart, monthEnd)
+	if err != nil {
+		return nil, apicode.ErrGetCheckinHistory, err
+	}
+	current, longest, err := s.checkinStreaks(ctx, customerID, now)
+	if err != nil {
+		return nil, apicode.ErrGetCheckinHistory, err
+	}
+	total, err := s.dao.CountCheckin(ctx, customerID)
+	if err != nil {
+		return nil, apicode.ErrGetCheckinHistory, err
+	}
+	gifts, err := s.dao.ListClaimedGift(ctx, customerID, pageNo, pageSize)
+	if err != nil {
+		return nil, apicode.ErrGetCheckinHistory, err
+	}
+	cycles, claimed, err := s.dao.SummaryClaimedGift(ctx, customerID)
+	if err != nil {
+		return nil, apicode.ErrGetCheckinHistory, err
+	}
+
+	data := &model.CheckinHistory{
+		Month:           month,
+		CheckinDates:    s.checkinDates(monthTimes),
+		CurrentStreak:   current,
+		LongestStreak:   longest,
+		TotalCheckins:   uint64(total),
+		CompletedCycles: cycles,
+		ClaimedGifts:    claimed,
+		Gifts:           gifts,
+	}
+	if data.CheckinDates == nil {
+		data.CheckinDates = make([]string, 0)
+	}
+	return data, wsgin.APICodeSuccess, nil
+}
+
+// checkinStreaks 计算客户截止到 now 的当前及最近 checkinStreakDays 天内最长连续签到天数
+func (s *Service) checkinStreaks(ctx context.Context, customerID uint64, now time.Time) (current, longest uint64, err error) {
+	dayStart, dayEnd := s.cal.Range(now)
+	times, err := s.dao.ListCheckinTime(ctx, customerID, s.cal.AddDays(dayStart, -checkinStreakDays), dayEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	current, longest = bizday.Streaks(s.checkinDates(times), s.cal.Date(now))
+	return current, longest, nil
+}
+
+// checkinDates 将按时间正序排列的签到时间转换为业务日期，同一天多次签到只算一次
+func (s *Service) checkinDates(times []time.Time) []string {
+	var dates []string
+	for _, t := range times {
+		date := s.cal.Date(t)
+		if len(dates) == 0 || dates[len(dates)-1] != date {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
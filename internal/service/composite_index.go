@@ -15,9 +15,26 @@ func (s *Service) AddCompositeIndex(ctx context.Context, compositeDate string, p
 	if points < 1000 {
 		return apicode.ErrSave, errors.New("上证指数不正确")
 	}
+	query := map[string]interface{}{
+		"status":         global.ActiveStatus,
+		"composite_date": compositeDate,
+	}
+	before, err := s.dao.GetCompositeIndexByQuery(ctx, query)
+	if err != nil {
+		return apicode.ErrSave, err
+	}
 	if err := s.dao.StoreCompositeIndex(ctx, compositeDate, points); err != nil {
 		return apicode.ErrSave, err
 	}
+	after, err := s.dao.GetCompositeIndexByQuery(ctx, query)
+	if err != nil {
+		return apicode.ErrSave, err
+	}
+	if before.ID == 0 {
+		before = nil
+	}
+	s.audit(ctx, global.AuditActionCompositeIndexSave, global.AuditEntityCompositeIndex, after.ID, before, after)
+	s.awardLuckyWinnerBadges(ctx)
 	return wsgin.APICodeSuccess, nil
 }
 
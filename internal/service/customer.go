@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/skip2/go-qrcode"
@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/dao"
 	"welfare-sign/internal/global"
 	"welfare-sign/internal/model"
 	"welfare-sign/internal/pkg/config"
@@ -81,9 +82,13 @@ func (s *Service) GetCustomerCheckinRecord(ctx context.Context, customerID, inCu
 		if err != nil {
 			return nil, apicode.ErrGetCheckinRecord, err
 		}
-		// 用户无签到记录时，自动创建5条信息并返回
+		// 用户无签到记录时，按当前签到活动的签到周期创建记录并返回
 		if len(records) == 0 {
-			records, err = s.dao.InitCheckinRecords(ctx, customerID)
+			campaign, err := s.currentCampaign(ctx)
+			if err != nil {
+				return nil, apicode.ErrGetCheckinRecord, err
+			}
+			records, err = s.dao.InitCheckinRecords(ctx, customerID, campaign.ID, s.cal.Days(time.Now(), int(campaign.CycleDays)))
 			if err != nil {
 				return nil, apicode.ErrGetCheckinRecord, err
 			}
@@ -92,8 +97,8 @@ func (s *Service) GetCustomerCheckinRecord(ctx context.Context, customerID, inCu
 	}
 }
 
-// CustomerLogin 客户登录
-func (s *Service) CustomerLogin(ctx context.Context, c string) (string, wsgin.APICode, error) {
+// CustomerLogin 客户登录，state 为微信网页授权回传的邀请码
+func (s *Service) CustomerLogin(ctx context.Context, c, state string) (*model.TokenResp, wsgin.APICode, error) {
 	var (
 		successResp  model.WxSuccessResp
 		errResp      model.WxErrResp
@@ -102,22 +107,22 @@ func (s *Service) CustomerLogin(ctx context.Context, c string) (string, wsgin.AP
 	// 使用code获取access_token
 	resp, err := http.Get(fmt.Sprintf("https://api.weixin.qq.com/sns/oauth2/access_token?appid=%s&secret=%s&code=%s&grant_type=authorization_code", viper.GetString(config.KeyWxAppID), viper.GetString(config.KeyWxAppSecret), c))
 	if err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	defer resp.Body.Close()
 	bytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	if err := json.Unmarshal(bytes, &errResp); err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	if errResp.Errcode != 0 {
 		log.Warn(ctx, "(CustomerLogin)get access_token error", zap.Error(err))
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	if err := json.Unmarshal(bytes, &successResp); err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 
 	// 查看该用户是否被禁用
@@ -126,45 +131,50 @@ func (s *Service) CustomerLogin(ctx context.Context, c string) (string, wsgin.AP
 		"status":  global.DeleteStatus,
 	})
 	if err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	if disableCustomer.ID != 0 {
-		return "", apicode.ErrLogin, errors.New("用户已被禁用")
+		return nil, apicode.ErrLogin, errors.New("用户已被禁用")
 	}
 
 	// 使用access_token + openid获取用户信息
 	resp, err = http.Get(fmt.Sprintf("https://api.weixin.qq.com/sns/userinfo?access_token=%s&openid=%s&lang=zh_CN", successResp.AccessToken, successResp.OpenID))
 	if err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	defer resp.Body.Close()
 	bytes, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	if err := json.Unmarshal(bytes, &errResp); err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	if errResp.Errcode != 0 {
 		log.Warn(ctx, "(CustomerLogin)get userinfo error", zap.Error(err))
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	if err := json.Unmarshal(bytes, &userinfoResp); err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	customer := &model.Customer{}
 	if err := util.StructCopy(customer, &userinfoResp); err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 
-	customer, err = s.dao.UpsertCustomer(ctx, &userinfoResp)
+	customer, err = s.dao.UpsertCustomer(ctx, &userinfoResp, s.referrerFromState(ctx, state, userinfoResp.OpenID))
 	if err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
-	token, err := jwt.CreateToken(customer.ID, customer.Name, customer.Mobile)
+	token, err := s.createTokenPair(ctx, &model.TokenSession{
+		UID:    customer.ID,
+		Name:   customer.Name,
+		Mobile: customer.Mobile,
+		Role:   jwt.RoleCustomer,
+	})
 	if err != nil {
 		log.Info(ctx, "CustomerLogin.CreateToken() error", zap.Error(err))
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	return token, wsgin.APICodeSuccess, nil
 }
@@ -178,9 +188,10 @@ func (s *Service) CustomerNearMerchant(ctx context.Context, data *model.NearMerc
 	return merchants, wsgin.APICodeSuccess, nil
 }
 
-// ExecCheckinRecord 用户签到
-func (s *Service) ExecCheckinRecord(ctx context.Context, customerID uint64) (wsgin.APICode, error) {
-	hasChecked, err := s.dao.HasChecked(ctx, customerID)
+// ExecCheckinRecord 用户签到，签到活动开启到店签到时需传入客户所在位置
+func (s *Service) ExecCheckinRecord(ctx context.Context, customerID uint64, location *model.CheckinLocation) (wsgin.APICode, error) {
+	dayStart, dayEnd := s.cal.Range(time.Now())
+	hasChecked, err := s.dao.HasChecked(ctx, customerID, dayStart, dayEnd)
 	if err != nil {
 		return apicode.ErrExecCheckinRecord, err
 	}
@@ -188,24 +199,64 @@ func (s *Service) ExecCheckinRecord(ctx context.Context, customerID uint64) (wsg
 		return apicode.ErrHasCheckin, errors.New("has checkin")
 	}
 
-	unchecked, err := s.dao.GetUnchecked(ctx, customerID)
+	// 还有可以补签的漏签记录时，按补签规则决定是否需要先补签
+	eligible, supplemented, err := s.supplementRecords(ctx, customerID, dayStart)
 	if err != nil {
 		return apicode.ErrExecCheckinRecord, err
 	}
-	if unchecked.ID != 0 {
+	if err := s.policy.CheckCheckin(s.policy.Remaining(supplemented, len(eligible))); err != nil {
 		return apicode.ErrExecCheckinRecord, errors.New("请先完成补签后再来签到")
 	}
 
-	if err = s.dao.ExecCheckin(ctx, customerID); err != nil {
+	merchantID, code, err := s.checkinMerchant(ctx, customerID, location)
+	if err != nil {
+		return code, err
+	}
+	if err = s.dao.ExecCheckin(ctx, customerID, merchantID, dayStart, dayEnd); err != nil {
 		log.Warn(ctx, "用户签到发生错误: 更新记录时发生错误", zap.Error(err))
 		return wsgin.APICodeServerError, errors.New("用户签到发生错误")
 	}
+	s.awardCheckinPoints(ctx, customerID, dayStart)
+	s.awardCyclePoints(ctx, customerID)
+	s.awardBadges(ctx, customerID)
 	return wsgin.APICodeSuccess, nil
 }
 
-// GetQRCode 客户获取二维码
+// checkinMerchant 到店签到时校验客户是否在活动商户附近，返回签到所在的商户ID
+// 本轮签到的活动未开启到店签到时返回0
+func (s *Service) checkinMerchant(ctx context.Context, customerID uint64, location *model.CheckinLocation) (uint64, wsgin.APICode, error) {
+	records, err := s.dao.ListCheckinRecord(ctx, "status <> ? AND customer_id = ?", global.DeleteStatus, customerID)
+	if err != nil {
+		return 0, apicode.ErrExecCheckinRecord, err
+	}
+	campaign, err := s.checkinCampaign(ctx, records)
+	if err != nil {
+		return 0, apicode.ErrExecCheckinRecord, err
+	}
+	if campaign.GeofenceRadius <= 0 {
+		return 0, wsgin.APICodeSuccess, nil
+	}
+	if location == nil || (location.Lat == 0 && location.Lon == 0) {
+		return 0, apicode.ErrCheckinLocation, errors.New("到店签到需要传入客户位置")
+	}
+	merchant, err := s.dao.FindNearestMerchant(ctx, location.Lat, location.Lon, campaign.GeofenceRadius, campaign.Merchants)
+	if err != nil {
+		return 0, apicode.ErrExecCheckinRecord, err
+	}
+	if merchant.ID == 0 {
+		return 0, apicode.ErrNotNearMerchant, errors.New("客户不在活动商户附近")
+	}
+	return merchant.ID, wsgin.APICodeSuccess, nil
+}
+
+// GetQRCode 客户获取二维码，二维码中为短期有效的一次性核销码
 func (s *Service) GetQRCode(ctx context.Context, customerID uint64) (data []byte, err error) {
-	data, err = qrcode.Encode(fmt.Sprintf(viper.GetString(config.KeyQRCodeURL), strconv.FormatUint(customerID, 10)), qrcode.Medium, 256)
+	code, err := s.createWriteOffCode(ctx, customerID)
+	if err != nil {
+		log.Warn(ctx, "GetQRCode.createWriteOffCode() error", zap.Error(err))
+		return nil, err
+	}
+	data, err = qrcode.Encode(fmt.Sprintf(viper.GetString(config.KeyQRCodeURL), code), qrcode.Medium, 256)
 	if err != nil {
 		log.Warn(ctx, "客户获取二维码失败", zap.Error(err))
 	}
@@ -218,11 +269,14 @@ func (s *Service) GetIssueRecords(ctx context.Context, customerID uint64) ([]*mo
 	if err != nil {
 		return nil, apicode.ErrIssueRecord, err
 	}
+	if err := s.attachGiftItems(ctx, issueRecords); err != nil {
+		return nil, apicode.ErrIssueRecord, err
+	}
 	return issueRecords, wsgin.APICodeSuccess, nil
 }
 
-// ExecIssueRecords 客户领取福利
-func (s *Service) ExecIssueRecords(ctx context.Context, customerID, merchantID uint64, mobile, code string) (wsgin.APICode, error) {
+// ExecIssueRecords 客户领取福利，商户提供礼品时需选择其中一个礼品
+func (s *Service) ExecIssueRecords(ctx context.Context, customerID, merchantID, giftItemID uint64, mobile, code string) (wsgin.APICode, error) {
 	customer, err := s.dao.FindCustomer(ctx, map[string]interface{}{
 		"id":     customerID,
 		"status": global.ActiveStatus,
@@ -237,12 +291,9 @@ func (s *Service) ExecIssueRecords(ctx context.Context, customerID, merchantID u
 	}
 	if mobile != "" {
 		if viper.GetBool(config.KeySMSEnable) {
-			if err := s.ValidateCode(ctx, mobile, code); err != nil {
+			if apiCode, err := s.ValidateCode(ctx, mobile, code); err != nil {
 				log.Info(ctx, "ExecIssueRecords.ValidateCode() error", zap.Error(err))
-				return apicode.ErrExecIssueRecord, err
-			}
-			if err := s.dao.DelSMSCode(ctx, mobile); err != nil {
-				log.Info(ctx, "ExecIssueRecords.DelSMSCode() error", zap.Error(err))
+				return apiCode, err
 			}
 		}
 	}
@@ -250,16 +301,24 @@ func (s *Service) ExecIssueRecords(ctx context.Context, customerID, merchantID u
 		mobile = ""
 	}
 
-	checkinRecords, err := s.dao.ListCheckinRecord(ctx, map[string]interface{}{
-		"status":      global.ActiveStatus,
-		"customer_id": customerID,
-	})
+	checkinRecords, err := s.dao.ListCheckinRecord(ctx, "status <> ? AND customer_id = ?", global.DeleteStatus, customerID)
 	if err != nil {
 		log.Warn(ctx, "ExecIssueRecords.ListCheckinRecord() error", zap.Error(err))
 		return apicode.ErrExecIssueRecord, err
 	}
-	if len(checkinRecords) != 5 {
-		return apicode.ErrNoWelfare, errors.New("您还未签满5天")
+	campaign, err := s.checkinCampaign(ctx, checkinRecords)
+	if err != nil {
+		log.Warn(ctx, "ExecIssueRecords.checkinCampaign() error", zap.Error(err))
+		return apicode.ErrExecIssueRecord, err
+	}
+	var checkedDays uint64
+	for _, record := range checkinRecords {
+		if record.Status == global.ActiveStatus {
+			checkedDays++
+		}
+	}
+	if checkedDays == 0 || checkedDays != campaign.CycleDays {
+		return apicode.ErrNoWelfare, errors.Errorf("您还未签满%d天", campaign.CycleDays)
 	}
 
 	merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{
@@ -272,18 +331,59 @@ func (s *Service) ExecIssueRecords(ctx context.Context, customerID, merchantID u
 	if merchant.ID == 0 {
 		return apicode.ErrExecIssueRecord, errors.New("该商户已被禁用")
 	}
-	if merchant.Received >= merchant.TotalReceive {
-		return apicode.ErrExecIssueRecord, errors.New("该商家的福利已被领完了")
+	if !campaign.IsEligibleMerchant(merchantID) {
+		return apicode.ErrMerchantNotInCampaign, errors.New("该商户未参与签到活动")
+	}
+	giftItems, err := s.dao.ListGiftItem(ctx, map[string]interface{}{
+		"merchant_id": merchantID,
+		"status":      global.ActiveStatus,
+	})
+	if err != nil {
+		return apicode.ErrExecIssueRecord, err
+	}
+	checkinDays, received, total := merchant.CheckinDays, merchant.Received, merchant.TotalReceive
+	rewardNum := merchant.CheckinNum
+	if campaign.RewardNum != 0 {
+		rewardNum = campaign.RewardNum
+	}
+	if len(giftItems) > 0 || giftItemID != 0 {
+		// 礼品的领取数量以礼品设置为准，不受签到活动奖励数量影响
+		var giftItem *model.GiftItem
+		for _, item := range giftItems {
+			if item.ID == giftItemID {
+				giftItem = item
+			}
+		}
+		if giftItem == nil {
+			return apicode.ErrGiftItemRequired, errors.New("请选择该商家提供的礼品")
+		}
+		checkinDays, received, total, rewardNum = giftItem.CheckinDays, giftItem.Received, giftItem.TotalStock, giftItem.ClaimNum
+	}
+	if checkinDays > campaign.CycleDays {
+		return apicode.ErrNoWelfare, errors.Errorf("需签满%d天才可领取", checkinDays)
+	}
+	// 邀请奖励的额外福利随本次领取一并发放
+	rewardNum += customer.BonusGiftNum
+	// 此处只做提前判断，库存以 CreateIssueRecord 中的条件更新为准
+	if received+rewardNum > total {
+		return apicode.ErrExecIssueRecord, dao.ErrMerchantStockNotEnough
 	}
-	merchant.Received += merchant.CheckinNum
 	var issueRecord model.IssueRecord
 	issueRecord.MerchantID = merchantID
 	issueRecord.CustomerID = customerID
-	issueRecord.TotalReceive = merchant.CheckinNum
+	issueRecord.GiftItemID = giftItemID
+	issueRecord.TotalReceive = rewardNum
 	issueRecord.Received = 0
-	if err := s.dao.CreateIssueRecord(ctx, issueRecord, merchant, mobile); err != nil {
+	if err := s.dao.CreateIssueRecord(ctx, issueRecord, mobile, customer.BonusGiftNum); err != nil {
 		return apicode.ErrExecIssueRecord, err
 	}
+	// CreateIssueRecord 已为没有手机号的客户绑定本次传入的手机号
+	if customer.Mobile == "" {
+		customer.Mobile = mobile
+	}
+	s.notifyWelfareClaimed(ctx, customer, merchant, rewardNum)
+	s.rewardReferrer(ctx, customer)
+	s.awardBadges(ctx, customerID)
 	return wsgin.APICodeSuccess, nil
 }
 
@@ -295,8 +395,19 @@ func (s *Service) RefreshCheckinRecord(ctx context.Context, customerID uint64) (
 	return wsgin.APICodeSuccess, nil
 }
 
-// HelpCheckinRecord 帮助他人签到
-func (s *Service) HelpCheckinRecord(ctx context.Context, helpCustomerID, customerID uint64) (wsgin.APICode, error) {
+// HelpCheckinRecord 帮助他人签到，传入补签邀请token时以邀请人为准
+func (s *Service) HelpCheckinRecord(ctx context.Context, helpCustomerID, customerID uint64, token string) (wsgin.APICode, error) {
+	var invitationID uint64
+	if token != "" {
+		invitation, err := s.verifyHelpInvitation(ctx, token)
+		if err != nil {
+			return apicode.ErrHelpInvitation, err
+		}
+		customerID, invitationID = invitation.CustomerID, invitation.ID
+	}
+	if customerID == 0 {
+		return wsgin.APICodeInvalidParame, errors.New("缺少补签邀请或补签客户ID")
+	}
 	if helpCustomerID == customerID {
 		return apicode.ErrHelpCheckinLimit, errors.New("不能通过分享功能为自己补签")
 	}
@@ -311,7 +422,8 @@ func (s *Service) HelpCheckinRecord(ctx context.Context, helpCustomerID, custome
 		return apicode.ErrHelpCheckin, errors.New("帮签用户不存在")
 	}
 
-	hasHelpChecked, err := s.dao.FindCheckinRecord(ctx, map[string]interface{}{
+	// 本轮已帮该用户补签的次数
+	helped, err := s.dao.ListCheckinRecord(ctx, map[string]interface{}{
 		"customer_id":              customerID,
 		"help_checkin_customer_id": helpCustomerID,
 		"status":                   global.ActiveStatus,
@@ -319,22 +431,27 @@ func (s *Service) HelpCheckinRecord(ctx context.Context, helpCustomerID, custome
 	if err != nil {
 		return apicode.ErrHelpCheckin, err
 	}
-	if hasHelpChecked.ID != 0 {
-		return apicode.ErrHasHelpCheckin, errors.New("has help checkin")
+	dayStart, dayEnd := s.cal.Range(time.Now())
+	helpedToday, err := s.dao.CountHelpCheckinMessage(ctx, helpCustomerID, dayStart, dayEnd)
+	if err != nil {
+		return apicode.ErrHelpCheckin, err
 	}
-
-	unChecked, err := s.dao.GetUnchecked(ctx, customerID)
+	eligible, supplemented, err := s.supplementRecords(ctx, customerID, dayStart)
 	if err != nil {
 		return apicode.ErrHelpCheckin, err
 	}
-	if unChecked.ID == 0 {
-		return apicode.ErrHelpCheckin, errors.New("该用户没有需要补签的记录")
+	if err := s.policy.CheckHelp(supplemented, len(eligible), helpedToday, len(helped)); err != nil {
+		return supplementCode(err, apicode.ErrHelpCheckin), err
 	}
 
-	if err := s.dao.HelpCheckin(ctx, unChecked.ID, customerID, helpCustomerID); err != nil {
+	// 并发帮签时由 dao 在事务中再次校验每日帮签次数及签到记录状态
+	if err := s.dao.HelpCheckin(ctx, eligible[0].ID, customerID, helpCustomerID, invitationID, dayStart, dayEnd, s.policy.HelperDailyLimit); err != nil {
 		log.Warn(ctx, "帮签发生错误", zap.Error(err))
-		return apicode.ErrHelpCheckin, err
+		return supplementCode(err, apicode.ErrHelpCheckin), err
 	}
+	s.awardHelpCheckinPoints(ctx, helpCustomerID, eligible[0].ID)
+	s.awardBadges(ctx, helpCustomerID)
+	s.awardCyclePoints(ctx, customerID)
 	return wsgin.APICodeSuccess, nil
 }
 
@@ -368,15 +485,26 @@ func (s *Service) DisableCustomer(ctx context.Context, customerID uint64) (wsgin
 	if customer.Status == global.DeleteStatus {
 		return apicode.ErrHasDisable, errors.New("用户已经被禁用")
 	}
+	before := *customer
 	customer.Status = global.DeleteStatus
 	if err := s.dao.UpdateCustomer(ctx, customer); err != nil {
 		return apicode.ErrDisable, err
 	}
+	s.audit(ctx, global.AuditActionCustomerDisable, global.AuditEntityCustomer, customer.ID, &before, customer)
+	s.revokeAccount(ctx, jwt.RoleCustomer, customer.ID)
 	return wsgin.APICodeSuccess, nil
 }
 
 // DeleteCustomer 删除客户
 func (s *Service) DeleteCustomer(ctx context.Context, customerID uint64) (wsgin.APICode, error) {
+	before, err := s.dao.FindCustomer(ctx, map[string]interface{}{"id": customerID})
+	if err != nil {
+		log.Warn(ctx, "DeleteCustomer.FindCustomer() error", zap.Error(err))
+	}
 	s.dao.DeleteCustomer(ctx, customerID)
+	s.revokeAccount(ctx, jwt.RoleCustomer, customerID)
+	if before.ID != 0 {
+		s.audit(ctx, global.AuditActionCustomerDelete, global.AuditEntityCustomer, customerID, before, nil)
+	}
 	return wsgin.APICodeSuccess, nil
 }
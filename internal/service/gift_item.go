@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// AddGiftItem 商户新增礼品
+func (s *Service) AddGiftItem(ctx context.Context, merchantID uint64, vo *model.GiftItemVO) (*model.GiftItem, wsgin.APICode, error) {
+	giftItem := &model.GiftItem{MerchantID: merchantID}
+	fillGiftItem(giftItem, vo)
+	if err := s.dao.CreateGiftItem(ctx, giftItem); err != nil {
+		return nil, apicode.ErrModelCreate, err
+	}
+	return giftItem, wsgin.APICodeSuccess, nil
+}
+
+// EditGiftItem 商户编辑礼品
+func (s *Service) EditGiftItem(ctx context.Context, merchantID, giftItemID uint64, vo *model.GiftItemVO) (wsgin.APICode, error) {
+	giftItem, code, err := s.merchantGiftItem(ctx, merchantID, giftItemID)
+	if err != nil {
+		return code, err
+	}
+	fillGiftItem(giftItem, vo)
+	giftItem.UpdatedBy = merchantID
+	giftItem.UpdatedAt = time.Now()
+	// 库存总数小于已领取数量时返回 dao.ErrGiftItemStockTooLow
+	if err := s.dao.UpdateGiftItem(ctx, giftItem); err != nil {
+		return apicode.ErrEditGiftItem, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// DisableGiftItem 商户停用礼品，已领取的福利仍可核销
+func (s *Service) DisableGiftItem(ctx context.Context, merchantID, giftItemID uint64) (wsgin.APICode, error) {
+	if _, code, err := s.merchantGiftItem(ctx, merchantID, giftItemID); err != nil {
+		return code, err
+	}
+	if err := s.dao.DisableGiftItem(ctx, giftItemID); err != nil {
+		return apicode.ErrDisable, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// GetGiftItemList 获取商户可领取的礼品
+func (s *Service) GetGiftItemList(ctx context.Context, merchantID uint64) ([]*model.GiftItem, wsgin.APICode, error) {
+	giftItems, err := s.dao.ListGiftItem(ctx, map[string]interface{}{
+		"merchant_id": merchantID,
+		"status":      global.ActiveStatus,
+	})
+	if err != nil {
+		return nil, apicode.ErrGetListData, err
+	}
+	return giftItems, wsgin.APICodeSuccess, nil
+}
+
+// merchantGiftItem 获取属于该商户且未停用的礼品
+func (s *Service) merchantGiftItem(ctx context.Context, merchantID, giftItemID uint64) (*model.GiftItem, wsgin.APICode, error) {
+	giftItem, err := s.dao.FindGiftItem(ctx, map[string]interface{}{
+		"id":     giftItemID,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return nil, apicode.ErrDetail, err
+	}
+	if giftItem.ID == 0 || giftItem.MerchantID != merchantID {
+		return nil, apicode.ErrGiftItemNotExists, errors.New("礼品不存在")
+	}
+	return giftItem, wsgin.APICodeSuccess, nil
+}
+
+// attachGiftItems 为福利记录填充礼品信息
+func (s *Service) attachGiftItems(ctx context.Context, issueRecords []*model.IssueRecord) error {
+	var ids []uint64
+	for _, issueRecord := range issueRecords {
+		if issueRecord.GiftItemID != 0 {
+			ids = append(ids, issueRecord.GiftItemID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	giftItems, err := s.dao.ListGiftItem(ctx, "id IN (?)", ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint64]*model.GiftItem, len(giftItems))
+	for _, giftItem := range giftItems {
+		byID[giftItem.ID] = giftItem
+	}
+	for _, issueRecord := range issueRecords {
+		issueRecord.GiftItem = byID[issueRecord.GiftItemID]
+	}
+	return nil
+}
+
+// fillGiftItem 填充礼品
+func fillGiftItem(giftItem *model.GiftItem, vo *model.GiftItemVO) {
+	giftItem.Name = vo.Name
+	giftItem.Image = vo.Image
+	giftItem.Description = vo.Description
+	giftItem.TotalStock = vo.TotalStock
+	giftItem.ClaimNum = vo.ClaimNum
+	giftItem.CheckinDays = vo.CheckinDays
+	giftItem.Sort = vo.Sort
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/sigtoken"
+	"welfare-sign/internal/pkg/util"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// sigTokenTypeHelpInvite 补签邀请token用途，与核销码共用签名密钥
+const sigTokenTypeHelpInvite = "help_invite"
+
+// maxInvitationStatDays 补签邀请转化率单次最多可统计的天数
+const maxInvitationStatDays = 92
+
+// CreateHelpInvitation 生成补签邀请，有效期至本轮签到结束，本轮内重复生成时复用同一个邀请
+func (s *Service) CreateHelpInvitation(ctx context.Context, customerID uint64) (*model.HelpInvitationResp, wsgin.APICode, error) {
+	records, err := s.dao.ListCheckinRecord(ctx, "status <> ? AND customer_id = ?", global.DeleteStatus, customerID)
+	if err != nil {
+		return nil, apicode.ErrHelpInvitation, err
+	}
+	if len(records) == 0 {
+		return nil, apicode.ErrHelpInvitation, errors.New("还没有开始本轮签到")
+	}
+	cycleStart, lastDay := records[0].CreatedAt, records[0].NeedCheckinTime
+	for _, record := range records {
+		if record.CreatedAt.Before(cycleStart) {
+			cycleStart = record.CreatedAt
+		}
+		if record.NeedCheckinTime.After(lastDay) {
+			lastDay = record.NeedCheckinTime
+		}
+	}
+	now := time.Now()
+	expiresAt := s.cal.AddDays(lastDay, 1)
+	if !expiresAt.After(now) {
+		return nil, apicode.ErrHelpInvitation, errors.New("本轮签到已结束")
+	}
+
+	invitation, err := s.dao.FindHelpInvitation(ctx, "customer_id = ? AND status = ? AND created_at >= ? AND expires_at > ?", customerID, global.ActiveStatus, cycleStart, now)
+	if err != nil {
+		return nil, apicode.ErrHelpInvitation, err
+	}
+	if invitation.ID == 0 {
+		uid, err := util.NewV4()
+		if err != nil {
+			return nil, apicode.ErrHelpInvitation, err
+		}
+		invitation = &model.HelpInvitation{
+			CustomerID: customerID,
+			Nonce:      strings.Replace(uid.String(), "-", "", -1),
+			ExpiresAt:  expiresAt,
+		}
+		if err := s.dao.CreateHelpInvitation(ctx, invitation); err != nil {
+			return nil, apicode.ErrHelpInvitation, err
+		}
+	}
+
+	token, err := sigtoken.Sign(qrcodeSignKey(), sigtoken.Payload{
+		Type:      sigTokenTypeHelpInvite,
+		Subject:   customerID,
+		Nonce:     invitation.Nonce,
+		ExpiresAt: invitation.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, apicode.ErrHelpInvitation, err
+	}
+	return &model.HelpInvitationResp{
+		Token:     token,
+		ExpiresAt: invitation.ExpiresAt,
+	}, wsgin.APICodeSuccess, nil
+}
+
+// OpenHelpInvitation 好友打开补签邀请，返回邀请人信息并记录打开事件
+func (s *Service) OpenHelpInvitation(ctx context.Context, visitorID uint64, token string) (*model.HelpInvitationDetail, wsgin.APICode, error) {
+	invitation, err := s.verifyHelpInvitation(ctx, token)
+	if err != nil {
+		return nil, apicode.ErrHelpInvitation, err
+	}
+	customer, err := s.dao.FindCustomer(ctx, map[string]interface{}{
+		"id":     invitation.CustomerID,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return nil, apicode.ErrHelpInvitation, err
+	}
+	if customer.ID == 0 {
+		return nil, apicode.ErrHelpInvitation, errors.New("邀请人不存在")
+	}
+	// 邀请人自己打开不计入统计
+	if visitorID != invitation.CustomerID {
+		if err := s.dao.CreateHelpInvitationEvent(ctx, &model.HelpInvitationEvent{
+			InvitationID: invitation.ID,
+			CustomerID:   invitation.CustomerID,
+			VisitorID:    visitorID,
+			Event:        global.HelpInvitationOpen,
+		}); err != nil {
+			log.Error(ctx, "OpenHelpInvitation.CreateHelpInvitationEvent() error", zap.Error(err))
+		}
+	}
+	return &model.HelpInvitationDetail{
+		Customer:  customer,
+		ExpiresAt: invitation.ExpiresAt,
+	}, wsgin.APICodeSuccess, nil
+}
+
+// GetHelperList 获取帮自己补签过的好友
+func (s *Service) GetHelperList(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.Helper, int, wsgin.APICode, error) {
+	if pageNo == 0 {
+		pageNo = 1
+	}
+	if pageSize == 0 {
+		pageSize = 10
+	}
+	helpers, total, err := s.dao.ListHelper(ctx, customerID, pageNo, pageSize)
+	if err != nil {
+		return nil, total, apicode.ErrGetListData, err
+	}
+	return helpers, total, wsgin.APICodeSuccess, nil
+}
+
+// GetInvitationStat 按业务日统计补签邀请转化率，最多统计 maxInvitationStatDays 天
+func (s *Service) GetInvitationStat(ctx context.Context, beginDate, endDate string) ([]*model.InvitationStat, wsgin.APICode, error) {
+	begin, end, err := s.cal.DateRange(beginDate, endDate)
+	if err != nil {
+		return nil, wsgin.APICodeInvalidParame, err
+	}
+	if end.After(s.cal.AddDays(begin, maxInvitationStatDays)) {
+		return nil, wsgin.APICodeInvalidParame, errors.Errorf("最多可统计%d天", maxInvitationStatDays)
+	}
+	days := s.dayBoundaries(begin, end)
+	stats, err := s.dao.GetInvitationStat(ctx, days)
+	if err != nil {
+		return stats, apicode.ErrGetListData, err
+	}
+	for _, stat := range stats {
+		stat.Date = s.cal.Date(days[stat.Day])
+		if stat.Opens > 0 {
+			stat.ConversionRate = float64(stat.Accepts) / float64(stat.Opens)
+		}
+	}
+	return stats, wsgin.APICodeSuccess, nil
+}
+
+// verifyHelpInvitation 校验补签邀请token，返回有效的邀请
+func (s *Service) verifyHelpInvitation(ctx context.Context, token string) (*model.HelpInvitation, error) {
+	payload, err := sigtoken.Verify(qrcodeSignKey(), token, sigTokenTypeHelpInvite, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	invitation, err := s.dao.FindHelpInvitation(ctx, map[string]interface{}{
+		"nonce":  payload.Nonce,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if invitation.ID == 0 || invitation.CustomerID != payload.Subject {
+		return nil, errors.New("补签邀请不存在")
+	}
+	return invitation, nil
+}
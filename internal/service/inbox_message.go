@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// GetInboxMessageList 获取客户站内信及未读数
+func (s *Service) GetInboxMessageList(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.InboxMessage, int, int, wsgin.APICode, error) {
+	if pageNo == 0 {
+		pageNo = 1
+	}
+	if pageSize == 0 {
+		pageSize = 10
+	}
+	messages, total, err := s.dao.ListInboxMessage(ctx, customerID, pageNo, pageSize)
+	if err != nil {
+		return nil, total, 0, apicode.ErrGetListData, err
+	}
+	unread, err := s.dao.CountUnreadInboxMessage(ctx, customerID)
+	if err != nil {
+		return nil, total, 0, apicode.ErrGetListData, err
+	}
+	return messages, total, unread, wsgin.APICodeSuccess, nil
+}
+
+// ReadInboxMessage 将客户的站内信全部标记为已读
+func (s *Service) ReadInboxMessage(ctx context.Context, customerID uint64) (wsgin.APICode, error) {
+	if err := s.dao.ReadInboxMessage(ctx, customerID); err != nil {
+		return apicode.ErrReadInboxMessage, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
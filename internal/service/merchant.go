@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/dao"
 	"welfare-sign/internal/global"
 	"welfare-sign/internal/model"
 	"welfare-sign/internal/pkg/config"
@@ -31,6 +32,16 @@ func (s *Service) AddMerchant(ctx context.Context, vo *model.MerchantVO) (wsgin.
 	if merchant.ContactPhone != "" {
 		return apicode.ErrMobileExists, err
 	}
+	staff, err := s.dao.FindMerchantStaff(ctx, map[string]interface{}{
+		"mobile": vo.ContactPhone,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return apicode.ErrModelCreate, err
+	}
+	if staff.ID != 0 {
+		return apicode.ErrMobileExists, errors.New("手机号已被商户员工使用")
+	}
 	if err := util.StructCopy(&data, vo); err != nil {
 		return apicode.ErrModelCreate, err
 	}
@@ -69,32 +80,30 @@ func (s *Service) GetMerchantList(ctx context.Context, vo *model.MerchantListVO)
 	return merchants, total, wsgin.APICodeSuccess, nil
 }
 
-// MerchantLogin 商家登录
-func (s *Service) MerchantLogin(ctx context.Context, vo *model.MerchantLoginVO) (string, wsgin.APICode, error) {
-	merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{
-		"contact_phone": vo.ContactPhone,
-		"status":        global.ActiveStatus,
-	})
+// MerchantLogin 商家登录，商户联系人及员工均使用自己的手机号登录
+func (s *Service) MerchantLogin(ctx context.Context, vo *model.MerchantLoginVO) (*model.TokenResp, wsgin.APICode, error) {
+	merchant, staff, err := s.findMerchantStaffByMobile(ctx, vo.ContactPhone)
 	if err != nil {
-		log.Info(ctx, "MerchantLogin.FindMerchant() error", zap.Error(err))
-		return "", apicode.ErrLogin, err
-	}
-	if merchant.ID == 0 {
-		return "", apicode.ErrLogin, errors.New("用户不存在或被禁用")
+		log.Info(ctx, "MerchantLogin.findMerchantStaffByMobile() error", zap.Error(err))
+		return nil, apicode.ErrLogin, err
 	}
 	if viper.GetBool(config.KeySMSEnable) {
-		if err := s.ValidateCode(ctx, vo.ContactPhone, vo.Code); err != nil {
+		if code, err := s.ValidateCode(ctx, vo.ContactPhone, vo.Code); err != nil {
 			log.Info(ctx, "MerchantLogin.ValidateCode() error", zap.Error(err))
-			return "", apicode.ErrLogin, err
-		}
-		if err := s.dao.DelSMSCode(ctx, vo.ContactPhone); err != nil {
-			log.Info(ctx, "MerchantLogin.DelSMSCode() error", zap.Error(err))
+			return nil, code, err
 		}
 	}
-	token, err := jwt.CreateToken(merchant.ID, merchant.ContactName, merchant.ContactPhone)
+	token, err := s.createTokenPair(ctx, &model.TokenSession{
+		UID:       merchant.ID,
+		Name:      staff.Name,
+		Mobile:    staff.Mobile,
+		Role:      jwt.RoleMerchant,
+		StaffID:   staff.ID,
+		StaffRole: staff.Role,
+	})
 	if err != nil {
 		log.Info(ctx, "MerchantLogin.CreateToken() error", zap.Error(err))
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
 	return token, wsgin.APICodeSuccess, nil
 }
@@ -114,8 +123,18 @@ func (s *Service) GetMerchantDetailBySelfAccessToken(ctx context.Context, mercha
 	return merchant, wsgin.APICodeSuccess, nil
 }
 
-// GetWriteOff 获取核销页面数据
-func (s *Service) GetWriteOff(ctx context.Context, merchantID, customerID uint64) (*model.MerchantWriteOffRespVO, wsgin.APICode, error) {
+// GetWriteOffByCode 商户扫描客户二维码后获取核销页面数据，只校验核销码，不使用掉
+func (s *Service) GetWriteOffByCode(ctx context.Context, merchantID uint64, code string, customerID, giftItemID uint64) (*model.MerchantWriteOffRespVO, wsgin.APICode, error) {
+	customerID, _, apiCode, err := s.verifyWriteOffCode(ctx, code, customerID)
+	if err != nil {
+		return nil, apiCode, err
+	}
+	return s.GetWriteOff(ctx, merchantID, customerID, giftItemID)
+}
+
+// GetWriteOff 获取核销页面数据，IssueRecord 为 giftItemID 对应的福利
+// 客户在该商户只有一份福利时，未指定礼品也使用该福利
+func (s *Service) GetWriteOff(ctx context.Context, merchantID, customerID, giftItemID uint64) (*model.MerchantWriteOffRespVO, wsgin.APICode, error) {
 	var resp model.MerchantWriteOffRespVO
 
 	merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{
@@ -134,24 +153,43 @@ func (s *Service) GetWriteOff(ctx context.Context, merchantID, customerID uint64
 		log.Info(ctx, "GetWriteOff.FindCustomer() error", zap.Error(err))
 		return nil, apicode.ErrWriteOff, err
 	}
-	issueRecord, err := s.dao.FindIssueRecord(ctx, map[string]interface{}{
+	issueRecords, err := s.dao.ListIssueRecord(ctx, map[string]interface{}{
 		"merchant_id": merchantID,
 		"customer_id": customerID,
 		"status":      global.ActiveStatus,
 	})
 	if err != nil {
-		log.Info(ctx, "GetWriteOff.FindIssueRecord() error", zap.Error(err))
+		log.Info(ctx, "GetWriteOff.ListIssueRecord() error", zap.Error(err))
 		return nil, apicode.ErrWriteOff, err
 	}
+	if err := s.attachGiftItems(ctx, issueRecords); err != nil {
+		log.Info(ctx, "GetWriteOff.attachGiftItems() error", zap.Error(err))
+		return nil, apicode.ErrWriteOff, err
+	}
+	issueRecord := &model.IssueRecord{}
+	for _, record := range issueRecords {
+		if record.GiftItemID == giftItemID {
+			issueRecord = record
+		}
+	}
+	if issueRecord.ID == 0 && giftItemID == 0 && len(issueRecords) == 1 {
+		issueRecord = issueRecords[0]
+	}
 	resp.Merchant = merchant
 	resp.Customer = customer
 	resp.IssueRecord = issueRecord
+	resp.IssueRecords = issueRecords
 	return &resp, wsgin.APICodeSuccess, nil
 }
 
-// ExecWriteOff 执行核销
+// ExecWriteOff 执行核销，核销码在核销时被使用掉，核销失败时恢复核销码
 func (s *Service) ExecWriteOff(ctx context.Context, vo *model.MerchantExecWriteOffVO) (*model.MerchantWriteOffRespVO, wsgin.APICode, error) {
-	resp, code, err := s.GetWriteOff(ctx, vo.MerchantID, vo.CustomerID)
+	customerID, nonce, code, err := s.verifyWriteOffCode(ctx, vo.Code, vo.CustomerID)
+	if err != nil {
+		return nil, code, err
+	}
+	vo.CustomerID = customerID
+	resp, code, err := s.GetWriteOff(ctx, vo.MerchantID, vo.CustomerID, vo.GiftItemID)
 	if err != nil {
 		return nil, code, err
 	}
@@ -162,13 +200,32 @@ func (s *Service) ExecWriteOff(ctx context.Context, vo *model.MerchantExecWriteO
 		return nil, apicode.ErrExecWriteOff, errors.New("核销数目不正确")
 	}
 	hasRece := resp.IssueRecord.Received + vo.Num
-	writeOffNum := resp.Merchant.HasWriteOffNum + vo.Num
 	resp.IssueRecord.Received = resp.IssueRecord.TotalReceive - hasRece
-	err = s.dao.EcecWriteOff(ctx, resp.Merchant.ID, resp.Customer.ID, hasRece, writeOffNum)
-	if err != nil {
-		return nil, apicode.ErrExecWriteOff, errors.New("核销数目不正确")
+	if code, err := s.consumeWriteOffCode(ctx, nonce); err != nil {
+		return nil, code, err
+	}
+	writeOffLog := &model.WriteOffLog{
+		MerchantID:    resp.Merchant.ID,
+		CustomerID:    resp.Customer.ID,
+		IssueRecordID: resp.IssueRecord.ID,
+		GiftItemID:    resp.IssueRecord.GiftItemID,
+		StaffID:       vo.StaffID,
+		Num:           vo.Num,
+		ClientIP:      wsgin.ClientIPFromContext(ctx),
+	}
+	if token := wsgin.TokenFromContext(ctx); token != nil {
+		writeOffLog.StaffName = token.Name
+	}
+	if err := s.dao.EcecWriteOff(ctx, writeOffLog); err != nil {
+		s.restoreWriteOffCode(ctx, vo.Code)
+		if err == dao.ErrWriteOffNotEnough {
+			return nil, apicode.ErrExecWriteOff, err
+		}
+		log.Warn(ctx, "ExecWriteOff.EcecWriteOff() error", zap.Error(err))
+		return nil, apicode.ErrExecWriteOff, errors.New("核销失败")
 	}
 	resp.Num = vo.Num
+	resp.WriteOffID = writeOffLog.ID
 	return resp, wsgin.APICodeSuccess, nil
 }
 
@@ -181,6 +238,7 @@ func (s *Service) EditMerchant(ctx context.Context, merchantID uint64, vo *model
 	if merchant.ID == 0 {
 		return apicode.ErrEditMerchant, err
 	}
+	before := *merchant
 
 	if merchant.ContactPhone != vo.ContactPhone {
 		existsMerchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{
@@ -192,6 +250,16 @@ func (s *Service) EditMerchant(ctx context.Context, merchantID uint64, vo *model
 		if existsMerchant.ID != 0 {
 			return apicode.ErrMobileExists, err
 		}
+		existsStaff, err := s.dao.FindMerchantStaff(ctx, map[string]interface{}{
+			"mobile": vo.ContactPhone,
+			"status": global.ActiveStatus,
+		})
+		if err != nil {
+			return apicode.ErrEditMerchant, err
+		}
+		if existsStaff.ID != 0 {
+			return apicode.ErrMobileExists, errors.New("手机号已被商户员工使用")
+		}
 	}
 
 	if err := util.StructCopy(merchant, vo); err != nil {
@@ -201,6 +269,10 @@ func (s *Service) EditMerchant(ctx context.Context, merchantID uint64, vo *model
 	if err := s.dao.UpdateMerchant(ctx, merchant); err != nil {
 		return apicode.ErrEditMerchant, err
 	}
+	if before.ContactPhone != merchant.ContactPhone {
+		s.moveOwnerStaff(ctx, merchant, before.ContactPhone)
+	}
+	s.audit(ctx, global.AuditActionMerchantEdit, global.AuditEntityMerchant, merchant.ID, &before, merchant)
 	return wsgin.APICodeSuccess, nil
 }
 
@@ -215,16 +287,27 @@ func (s *Service) DisableMerchant(ctx context.Context, merchantID uint64) (wsgin
 	if merchant.Status == global.DeleteStatus {
 		return apicode.ErrHasDisable, errors.New("用户已经被禁用")
 	}
+	before := *merchant
 	merchant.Status = global.DeleteStatus
 	if err := s.dao.UpdateMerchant(ctx, merchant); err != nil {
 		return apicode.ErrDisable, err
 	}
+	s.audit(ctx, global.AuditActionMerchantDisable, global.AuditEntityMerchant, merchant.ID, &before, merchant)
+	s.revokeAccount(ctx, jwt.RoleMerchant, merchant.ID)
 	return wsgin.APICodeSuccess, nil
 }
 
 // DeleteMerchant 删除商户
 func (s *Service) DeleteMerchant(ctx context.Context, merchantID uint64) (wsgin.APICode, error) {
+	before, err := s.dao.FindMerchant(ctx, map[string]interface{}{"id": merchantID})
+	if err != nil {
+		log.Warn(ctx, "DeleteMerchant.FindMerchant() error", zap.Error(err))
+	}
 	s.dao.DeleteMerchant(ctx, merchantID)
+	s.revokeAccount(ctx, jwt.RoleMerchant, merchantID)
+	if before.ID != 0 {
+		s.audit(ctx, global.AuditActionMerchantDelete, global.AuditEntityMerchant, merchantID, before, nil)
+	}
 	return wsgin.APICodeSuccess, nil
 }
 
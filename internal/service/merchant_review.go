@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/dao"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// 评价审核操作
+const (
+	ReviewActionApprove = "approve" // 审核通过，展示并计入商户评分
+	ReviewActionHide    = "hide"    // 隐藏，不再展示也不计入商户评分
+)
+
+// maxReviewPhotos 每条评价最多上传的图片数
+const maxReviewPhotos = 9
+
+// reviewPhotoDir 评价图片的上传目录
+const reviewPhotoDir = "upload/review/"
+
+// AddMerchantReview 客户评价核销，每次核销只能评价一次，已撤销的核销不能评价
+func (s *Service) AddMerchantReview(ctx context.Context, vo *model.MerchantReviewVO) (*model.MerchantReview, wsgin.APICode, error) {
+	if len(vo.Photos) > maxReviewPhotos {
+		return nil, wsgin.APICodeInvalidParame, errors.Errorf("最多上传%d张图片", maxReviewPhotos)
+	}
+	for _, photo := range vo.Photos {
+		if photo == "" || filepath.Base(photo) != photo || strings.Contains(photo, ",") {
+			return nil, wsgin.APICodeInvalidParame, errors.Errorf("图片%s无效", photo)
+		}
+		if _, err := os.Stat(reviewPhotoDir + photo); err != nil {
+			return nil, wsgin.APICodeInvalidParame, errors.Errorf("图片%s不存在", photo)
+		}
+	}
+
+	var since time.Time
+	days := viper.GetInt(config.KeyReviewWindowDays)
+	if days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+	var writeOffLog *model.WriteOffLog
+	var err error
+	if vo.WriteOffLogID != 0 {
+		writeOffLog, err = s.dao.FindWriteOffLog(ctx, map[string]interface{}{"id": vo.WriteOffLogID})
+	} else if vo.MerchantID != 0 {
+		writeOffLog, err = s.dao.FindUnreviewedWriteOffLog(ctx, vo.CustomerID, vo.MerchantID, since)
+	} else {
+		return nil, wsgin.APICodeInvalidParame, errors.New("请指定核销记录或商户")
+	}
+	if err != nil {
+		return nil, apicode.ErrReview, err
+	}
+	if writeOffLog.ID == 0 || writeOffLog.CustomerID != vo.CustomerID {
+		return nil, apicode.ErrWriteOffLogNotExists, errors.New("没有可评价的核销记录")
+	}
+	if writeOffLog.ReversedAt != nil {
+		return nil, apicode.ErrWriteOffReversed, errors.New("核销记录已撤销")
+	}
+	if writeOffLog.CreatedAt.Before(since) {
+		return nil, apicode.ErrReviewWindowPassed, errors.Errorf("只能评价%d天内的核销", days)
+	}
+	exists, err := s.dao.FindMerchantReview(ctx, map[string]interface{}{"write_off_log_id": writeOffLog.ID})
+	if err != nil {
+		return nil, apicode.ErrReview, err
+	}
+	if exists.ID != 0 {
+		return nil, apicode.ErrReviewExists, errors.New("该次核销已评价")
+	}
+
+	review := &model.MerchantReview{
+		MerchantID:    writeOffLog.MerchantID,
+		CustomerID:    writeOffLog.CustomerID,
+		WriteOffLogID: writeOffLog.ID,
+		GiftItemID:    writeOffLog.GiftItemID,
+		Rating:        vo.Rating,
+		Content:       vo.Content,
+		Photos:        vo.Photos,
+	}
+	if review.Photos == nil {
+		review.Photos = []string{}
+	}
+	// 同一核销并发提交时由唯一索引保证只保存一条
+	if err := s.dao.CreateMerchantReview(ctx, review, viper.GetBool(config.KeyReviewPreModeration)); err != nil {
+		if err == dao.ErrReviewExists {
+			return nil, apicode.ErrReviewExists, err
+		}
+		return nil, apicode.ErrReview, err
+	}
+	return review, wsgin.APICodeSuccess, nil
+}
+
+// GetMerchantReviewList 获取商户展示中的评价
+func (s *Service) GetMerchantReviewList(ctx context.Context, vo *model.MerchantReviewListVO) ([]*model.MerchantReviewItem, int, wsgin.APICode, error) {
+	vo.Status = global.ActiveStatus
+	return s.listMerchantReview(ctx, vo)
+}
+
+// GetReviewList 后台查询评价，可按商户、状态筛选
+func (s *Service) GetReviewList(ctx context.Context, vo *model.MerchantReviewListVO) ([]*model.MerchantReviewItem, int, wsgin.APICode, error) {
+	return s.listMerchantReview(ctx, vo)
+}
+
+// ReplyMerchantReview 商户回复评价，再次回复时覆盖之前的回复
+func (s *Service) ReplyMerchantReview(ctx context.Context, merchantID, staffID, reviewID uint64, reply string) (wsgin.APICode, error) {
+	review, err := s.dao.FindMerchantReview(ctx, map[string]interface{}{"id": reviewID})
+	if err != nil {
+		return apicode.ErrReplyReview, err
+	}
+	if review.ID == 0 || review.MerchantID != merchantID {
+		return apicode.ErrReviewNotExists, errors.New("评价不存在")
+	}
+	review.Reply = reply
+	review.RepliedBy = staffID
+	if err := s.dao.ReplyMerchantReview(ctx, review); err != nil {
+		return apicode.ErrReplyReview, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// ModerateMerchantReview 后台审核评价，通过后展示并计入商户评分，隐藏后不再展示并从商户评分中扣除
+func (s *Service) ModerateMerchantReview(ctx context.Context, reviewID uint64, action string) (wsgin.APICode, error) {
+	status, auditAction := global.ActiveStatus, global.AuditActionReviewApprove
+	switch action {
+	case ReviewActionApprove:
+	case ReviewActionHide:
+		status, auditAction = global.InactiveStatus, global.AuditActionReviewHide
+	default:
+		return wsgin.APICodeInvalidParame, errors.Errorf("不支持的审核操作%s", action)
+	}
+
+	review, err := s.dao.FindMerchantReview(ctx, map[string]interface{}{"id": reviewID})
+	if err != nil {
+		return apicode.ErrModerateReview, err
+	}
+	if review.ID == 0 {
+		return apicode.ErrReviewNotExists, errors.New("评价不存在")
+	}
+	if review.Status == status {
+		return wsgin.APICodeSuccess, nil
+	}
+	if status == global.ActiveStatus {
+		writeOffLog, err := s.dao.FindWriteOffLog(ctx, map[string]interface{}{"id": review.WriteOffLogID})
+		if err != nil {
+			return apicode.ErrModerateReview, err
+		}
+		if writeOffLog.ReversedAt != nil {
+			return apicode.ErrWriteOffReversed, errors.New("核销记录已撤销，不能通过该评价")
+		}
+	}
+	before := *review
+	var operatorID uint64
+	if token := wsgin.TokenFromContext(ctx); token != nil {
+		operatorID = token.UID
+	}
+	// 评价状态已被其他人修改或核销在此期间被撤销时返回 dao.ErrReviewModerated
+	if err := s.dao.ModerateMerchantReview(ctx, review, status, operatorID); err != nil {
+		return apicode.ErrModerateReview, err
+	}
+	s.audit(ctx, auditAction, global.AuditEntityReview, review.ID, &before, review)
+	return wsgin.APICodeSuccess, nil
+}
+
+// hideReversedReview 核销撤销后隐藏该次核销的评价
+func (s *Service) hideReversedReview(ctx context.Context, writeOffLogID uint64) {
+	review, err := s.dao.FindMerchantReview(ctx, map[string]interface{}{
+		"write_off_log_id": writeOffLogID,
+		"status":           global.ActiveStatus,
+	})
+	if err == nil && review.ID != 0 {
+		err = s.dao.ModerateMerchantReview(ctx, review, global.InactiveStatus, 0)
+	}
+	if err != nil && err != dao.ErrReviewModerated {
+		log.Error(ctx, "hideReversedReview error", zap.Uint64("write_off_log_id", writeOffLogID), zap.Error(err))
+	}
+}
+
+// listMerchantReview 查询评价列表
+func (s *Service) listMerchantReview(ctx context.Context, vo *model.MerchantReviewListVO) ([]*model.MerchantReviewItem, int, wsgin.APICode, error) {
+	if vo.PageNo == 0 {
+		vo.PageNo = 1
+	}
+	if vo.PageSize == 0 {
+		vo.PageSize = 10
+	}
+	reviews, total, err := s.dao.ListMerchantReview(ctx, vo.MerchantID, vo.Status, vo.PageNo, vo.PageSize)
+	if err != nil {
+		return nil, total, apicode.ErrGetListData, err
+	}
+	return reviews, total, wsgin.APICodeSuccess, nil
+}
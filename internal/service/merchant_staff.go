@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/dao"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// staffAccountRole 注销员工会话时使用的账号类型，员工token的角色仍为商户
+const staffAccountRole = "merchant_staff"
+
+// findMerchantStaffByMobile 根据手机号获取可登录的员工及所属商户
+// 商户联系人手机号第一次登录时自动创建为店主
+func (s *Service) findMerchantStaffByMobile(ctx context.Context, mobile string) (*model.Merchant, *model.MerchantStaff, error) {
+	staff, err := s.dao.FindMerchantStaff(ctx, map[string]interface{}{
+		"mobile": mobile,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if staff.ID != 0 {
+		if !staff.Enabled {
+			return nil, nil, errors.New("员工已被停用")
+		}
+		merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{
+			"id":     staff.MerchantID,
+			"status": global.ActiveStatus,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if merchant.ID == 0 {
+			return nil, nil, errors.New("商户不存在或被禁用")
+		}
+		return merchant, staff, nil
+	}
+
+	merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{
+		"contact_phone": mobile,
+		"status":        global.ActiveStatus,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if merchant.ID == 0 {
+		return nil, nil, errors.New("用户不存在或被禁用")
+	}
+	staff = &model.MerchantStaff{
+		MerchantID: merchant.ID,
+		Name:       merchant.ContactName,
+		Mobile:     merchant.ContactPhone,
+		Role:       global.StaffRoleOwner,
+		Enabled:    true,
+	}
+	if err := s.dao.CreateMerchantStaff(ctx, staff); err != nil {
+		if err == dao.ErrStaffMobileExists {
+			// 并发登录或新增员工已为该手机号创建了员工，以已创建的员工为准
+			return s.findMerchantStaffByMobile(ctx, mobile)
+		}
+		return nil, nil, err
+	}
+	return merchant, staff, nil
+}
+
+// moveOwnerStaff 商户联系人手机号变更后，将原联系人的店主账号转给新手机号，原手机号不能再登录
+func (s *Service) moveOwnerStaff(ctx context.Context, merchant *model.Merchant, oldMobile string) {
+	staff, err := s.dao.FindMerchantStaff(ctx, map[string]interface{}{
+		"merchant_id": merchant.ID,
+		"mobile":      oldMobile,
+		"status":      global.ActiveStatus,
+	})
+	if err != nil || staff.ID == 0 {
+		return
+	}
+	if err := s.dao.UpdateMerchantStaff(ctx, map[string]interface{}{"id": staff.ID}, map[string]interface{}{
+		"mobile": merchant.ContactPhone,
+		"name":   merchant.ContactName,
+	}); err != nil {
+		log.Warn(ctx, "moveOwnerStaff.UpdateMerchantStaff() error", zap.Error(err))
+		return
+	}
+	s.revokeAccount(ctx, staffAccountRole, staff.ID)
+}
+
+// GetMerchantStaffList 获取商户员工列表
+func (s *Service) GetMerchantStaffList(ctx context.Context, merchantID uint64) ([]*model.MerchantStaff, wsgin.APICode, error) {
+	staffs, err := s.dao.ListMerchantStaff(ctx, map[string]interface{}{
+		"merchant_id": merchantID,
+		"status":      global.ActiveStatus,
+	})
+	if err != nil {
+		return nil, apicode.ErrGetListData, err
+	}
+	return staffs, wsgin.APICodeSuccess, nil
+}
+
+// AddMerchantStaff 店主新增员工
+func (s *Service) AddMerchantStaff(ctx context.Context, vo *model.MerchantStaffVO) (wsgin.APICode, error) {
+	staff, err := s.dao.FindMerchantStaff(ctx, map[string]interface{}{
+		"mobile": vo.Mobile,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return apicode.ErrModelCreate, err
+	}
+	if staff.ID != 0 {
+		return apicode.ErrStaffExists, errors.New("该手机号已是其他员工")
+	}
+	merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{
+		"contact_phone": vo.Mobile,
+	})
+	if err != nil {
+		return apicode.ErrModelCreate, err
+	}
+	if merchant.ID != 0 {
+		return apicode.ErrStaffExists, errors.New("该手机号已是商户联系人")
+	}
+	if err := s.dao.CreateMerchantStaff(ctx, &model.MerchantStaff{
+		MerchantID: vo.MerchantID,
+		Name:       vo.Name,
+		Mobile:     vo.Mobile,
+		Role:       vo.Role,
+		Enabled:    true,
+	}); err != nil {
+		if err == dao.ErrStaffMobileExists {
+			return apicode.ErrStaffExists, err
+		}
+		return apicode.ErrModelCreate, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// RemoveMerchantStaff 店主删除员工，不能删除自己和商户联系人
+func (s *Service) RemoveMerchantStaff(ctx context.Context, merchantID, operatorID, staffID uint64) (wsgin.APICode, error) {
+	if code, err := s.checkStaffEditable(ctx, merchantID, operatorID, staffID); err != nil {
+		return code, err
+	}
+	if err := s.dao.UpdateMerchantStaff(ctx, map[string]interface{}{"id": staffID}, map[string]interface{}{
+		"status":     global.DeleteStatus,
+		"enabled":    false,
+		"updated_by": operatorID,
+	}); err != nil {
+		return apicode.ErrUpdateStaff, err
+	}
+	s.revokeAccount(ctx, staffAccountRole, staffID)
+	return wsgin.APICodeSuccess, nil
+}
+
+// EnableMerchantStaff 店主启用或停用员工
+func (s *Service) EnableMerchantStaff(ctx context.Context, merchantID, operatorID, staffID uint64, enabled bool) (wsgin.APICode, error) {
+	if code, err := s.checkStaffEditable(ctx, merchantID, operatorID, staffID); err != nil {
+		return code, err
+	}
+	if err := s.dao.UpdateMerchantStaff(ctx, map[string]interface{}{"id": staffID}, map[string]interface{}{
+		"enabled":    enabled,
+		"updated_by": operatorID,
+	}); err != nil {
+		return apicode.ErrUpdateStaff, err
+	}
+	if !enabled {
+		s.revokeAccount(ctx, staffAccountRole, staffID)
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// checkStaffEditable 校验员工属于该商户，且不是操作人自己或商户联系人
+func (s *Service) checkStaffEditable(ctx context.Context, merchantID, operatorID, staffID uint64) (wsgin.APICode, error) {
+	if staffID == operatorID {
+		return apicode.ErrUpdateStaff, errors.New("不能修改自己")
+	}
+	staff, err := s.dao.FindMerchantStaff(ctx, map[string]interface{}{
+		"id":          staffID,
+		"merchant_id": merchantID,
+		"status":      global.ActiveStatus,
+	})
+	if err != nil {
+		return apicode.ErrUpdateStaff, err
+	}
+	if staff.ID == 0 {
+		return apicode.ErrStaffNotExists, errors.New("员工不存在")
+	}
+	merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{"id": merchantID})
+	if err != nil {
+		return apicode.ErrUpdateStaff, err
+	}
+	if merchant.ContactPhone == staff.Mobile {
+		return apicode.ErrUpdateStaff, errors.New("不能修改商户联系人")
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// isStaffActive 员工是否仍可使用
+func (s *Service) isStaffActive(ctx context.Context, merchantID, staffID uint64) (bool, error) {
+	staff, err := s.dao.FindMerchantStaff(ctx, map[string]interface{}{
+		"id":          staffID,
+		"merchant_id": merchantID,
+		"status":      global.ActiveStatus,
+	})
+	if err != nil {
+		return false, err
+	}
+	return staff.ID != 0 && staff.Enabled, nil
+}
+
+// migrateMerchantStaff 为唯一索引上线前创建的未删除员工补齐 mobile_key，同一手机号重复时只有最早的员工写入成功，其余记录日志待人工处理
+func (s *Service) migrateMerchantStaff(ctx context.Context) {
+	staffs, err := s.dao.ListMerchantStaff(ctx, "status = '"+global.ActiveStatus+"' AND mobile_key IS NULL")
+	if err != nil {
+		log.Error(ctx, "migrateMerchantStaff.ListMerchantStaff() error", zap.Error(err))
+		return
+	}
+	for _, staff := range staffs {
+		if err := s.dao.UpdateMerchantStaff(ctx, map[string]interface{}{"id": staff.ID}, map[string]interface{}{
+			"mobile": staff.Mobile,
+		}); err != nil {
+			log.Error(ctx, "migrateMerchantStaff.UpdateMerchantStaff() error", zap.Uint64("staff_id", staff.ID), zap.Error(err))
+		}
+	}
+}
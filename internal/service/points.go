@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/dao"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/util"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// GetPointsBalance 获取客户积分余额
+func (s *Service) GetPointsBalance(ctx context.Context, customerID uint64) (*model.PointsBalance, wsgin.APICode, error) {
+	customer, err := s.dao.FindCustomer(ctx, map[string]interface{}{"id": customerID})
+	if err != nil {
+		return nil, apicode.ErrDetail, err
+	}
+	return &model.PointsBalance{Balance: customer.Points}, wsgin.APICodeSuccess, nil
+}
+
+// GetPointsEntryList 获取客户积分流水
+func (s *Service) GetPointsEntryList(ctx context.Context, customerID uint64, pageNo, pageSize int) ([]*model.PointsEntry, int, wsgin.APICode, error) {
+	if pageNo == 0 {
+		pageNo = 1
+	}
+	if pageSize == 0 {
+		pageSize = 10
+	}
+	entries, total, err := s.dao.ListPointsEntry(ctx, customerID, pageNo, pageSize)
+	if err != nil {
+		return nil, total, apicode.ErrGetListData, err
+	}
+	return entries, total, wsgin.APICodeSuccess, nil
+}
+
+// RedeemPoints 使用积分兑换免费补签次数或额外福利
+func (s *Service) RedeemPoints(ctx context.Context, customerID uint64, vo *model.PointsRedeemVO) (wsgin.APICode, error) {
+	costKey := config.KeyPointsRedeemSupplement
+	if vo.Item == global.PointsRedeemGift {
+		costKey = config.KeyPointsRedeemGift
+	}
+	cost := viper.GetInt64(costKey)
+	if cost <= 0 {
+		return apicode.ErrRedeemPoints, errors.New("该项目暂不支持积分兑换")
+	}
+	// 防止数量过大时所需积分溢出为正数
+	if vo.Num > uint64(math.MaxInt64/cost) {
+		return wsgin.APICodeInvalidParame, errors.New("兑换数量过大")
+	}
+	txID, err := newPointsTxID(global.PointsReasonRedeem)
+	if err != nil {
+		return apicode.ErrRedeemPoints, err
+	}
+	err = s.dao.RedeemPoints(ctx, &model.PointsTx{
+		TxID:       txID,
+		CustomerID: customerID,
+		Amount:     -cost * int64(vo.Num),
+		Reason:     global.PointsReasonRedeem,
+		Remark:     fmt.Sprintf("%s x%d", vo.Item, vo.Num),
+		OperatorID: customerID,
+	}, vo.Item, vo.Num)
+	if err == dao.ErrPointsNotEnough {
+		return apicode.ErrPointsNotEnough, err
+	}
+	if err != nil {
+		return apicode.ErrRedeemPoints, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// AdjustPoints 后台调整客户积分
+func (s *Service) AdjustPoints(ctx context.Context, vo *model.PointsAdjustVO) (wsgin.APICode, error) {
+	customer, err := s.dao.FindCustomer(ctx, map[string]interface{}{"id": vo.CustomerID})
+	if err != nil {
+		return apicode.ErrAdjustPoints, err
+	}
+	if customer.ID == 0 {
+		return wsgin.APICodeInvalidParame, errors.New("客户不存在")
+	}
+	txID, err := newPointsTxID(global.PointsReasonAdjust)
+	if err != nil {
+		return apicode.ErrAdjustPoints, err
+	}
+	data := &model.PointsTx{
+		TxID:       txID,
+		CustomerID: vo.CustomerID,
+		Amount:     vo.Amount,
+		Reason:     global.PointsReasonAdjust,
+		Remark:     vo.Reason,
+	}
+	if token := wsgin.TokenFromContext(ctx); token != nil {
+		data.OperatorID = token.UID
+	}
+	err = s.dao.PostPoints(ctx, data)
+	if err == dao.ErrPointsNotEnough {
+		return apicode.ErrPointsNotEnough, err
+	}
+	if err != nil {
+		return apicode.ErrAdjustPoints, err
+	}
+	// 调整期间积分可能被其他操作修改，重新读取调整后的余额
+	after, err := s.dao.FindCustomer(ctx, map[string]interface{}{"id": vo.CustomerID})
+	if err != nil {
+		log.Warn(ctx, "AdjustPoints.FindCustomer() error", zap.Error(err))
+		after = &model.Customer{Points: customer.Points + vo.Amount}
+	}
+	s.audit(ctx, global.AuditActionPointsAdjust, global.AuditEntityPoints, vo.CustomerID,
+		&model.PointsBalance{Balance: customer.Points}, &model.PointsBalance{Balance: after.Points})
+	return wsgin.APICodeSuccess, nil
+}
+
+// earnPoints 发放积分奖励，txID 相同的奖励只发放一次，发放失败只记录日志
+func (s *Service) earnPoints(ctx context.Context, customerID uint64, reason, txID string, refID uint64, amount int64) {
+	if amount <= 0 {
+		return
+	}
+	if err := s.dao.PostPoints(ctx, &model.PointsTx{
+		TxID:       txID,
+		CustomerID: customerID,
+		Amount:     amount,
+		Reason:     reason,
+		RefID:      refID,
+	}); err != nil {
+		log.Warn(ctx, "earnPoints.PostPoints() error", zap.String("tx_id", txID), zap.Error(err))
+	}
+}
+
+// awardCheckinPoints 发放签到积分，每个业务日只发放一次
+func (s *Service) awardCheckinPoints(ctx context.Context, customerID uint64, day time.Time) {
+	txID := fmt.Sprintf("%s:%d:%s", global.PointsReasonCheckin, customerID, s.cal.Date(day))
+	s.earnPoints(ctx, customerID, global.PointsReasonCheckin, txID, 0, viper.GetInt64(config.KeyPointsCheckin))
+}
+
+// awardHelpCheckinPoints 发放帮他人补签的积分
+func (s *Service) awardHelpCheckinPoints(ctx context.Context, helpCustomerID, checkinRecordID uint64) {
+	txID := fmt.Sprintf("%s:%d", global.PointsReasonHelpCheckin, checkinRecordID)
+	s.earnPoints(ctx, helpCustomerID, global.PointsReasonHelpCheckin, txID, checkinRecordID, viper.GetInt64(config.KeyPointsHelpCheckin))
+}
+
+// awardCyclePoints 本轮签到全部完成(含补签)时发放完成一轮签到的积分，每轮只发放一次
+func (s *Service) awardCyclePoints(ctx context.Context, customerID uint64) {
+	records, err := s.dao.ListCheckinRecord(ctx, "status <> ? AND customer_id = ?", global.DeleteStatus, customerID)
+	if err != nil {
+		log.Warn(ctx, "awardCyclePoints.ListCheckinRecord() error", zap.Error(err))
+		return
+	}
+	if len(records) == 0 {
+		return
+	}
+	firstID := records[0].ID
+	for _, record := range records {
+		if record.Status != global.ActiveStatus {
+			return
+		}
+		if record.ID < firstID {
+			firstID = record.ID
+		}
+	}
+	txID := fmt.Sprintf("%s:%d", global.PointsReasonCycle, firstID)
+	s.earnPoints(ctx, customerID, global.PointsReasonCycle, txID, firstID, viper.GetInt64(config.KeyPointsCycle))
+}
+
+// newPointsTxID 生成不需要去重的积分交易号
+func newPointsTxID(reason string) (string, error) {
+	uid, err := util.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return reason + ":" + strings.Replace(uid.String(), "-", "", -1), nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/sigtoken"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// sigTokenTypeReferral 邀请码token用途
+const sigTokenTypeReferral = "referral"
+
+// GetReferralCode 获取邀请码，新用户通过带邀请码的授权链接登录后记录为被邀请人
+func (s *Service) GetReferralCode(ctx context.Context, customerID uint64) (*model.ReferralCodeResp, wsgin.APICode, error) {
+	expiresAt := time.Now().AddDate(0, 0, viper.GetInt(config.KeyReferralCodeExpire))
+	code, err := sigtoken.Sign(qrcodeSignKey(), sigtoken.Payload{
+		Type:      sigTokenTypeReferral,
+		Subject:   customerID,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, apicode.ErrReferralCode, err
+	}
+	return &model.ReferralCodeResp{
+		Code:      code,
+		ExpiresAt: expiresAt,
+	}, wsgin.APICodeSuccess, nil
+}
+
+// CreditCheckinRecord 使用邀请奖励的免费补签次数补签最早一天漏签
+func (s *Service) CreditCheckinRecord(ctx context.Context, customerID uint64) (wsgin.APICode, error) {
+	customer, err := s.dao.FindCustomer(ctx, map[string]interface{}{
+		"id":     customerID,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return apicode.ErrSupplementCheckin, err
+	}
+	if customer.SupplementCredits == 0 {
+		return apicode.ErrNoSupplementCredit, errors.New("没有可用的免费补签次数")
+	}
+	eligible, supplemented, err := s.supplementRecords(ctx, customerID, s.cal.DayStart(time.Now()))
+	if err != nil {
+		return apicode.ErrSupplementCheckin, err
+	}
+	if err := s.policy.CheckCredit(supplemented, len(eligible)); err != nil {
+		return supplementCode(err, apicode.ErrSupplementCheckin), err
+	}
+	if err := s.dao.CreditCheckin(ctx, eligible[0].ID, customerID); err != nil {
+		return supplementCode(err, apicode.ErrSupplementCheckin), err
+	}
+	s.awardCyclePoints(ctx, customerID)
+	return wsgin.APICodeSuccess, nil
+}
+
+// referrerFromState 从登录时传入的邀请码获取邀请人ID，邀请码无效或不满足邀请条件时返回0
+// 同一微信用户只能被邀请一次，且不能使用自己的邀请码
+func (s *Service) referrerFromState(ctx context.Context, state, openID string) uint64 {
+	if state == "" || !viper.GetBool(config.KeyReferralEnable) {
+		return 0
+	}
+	payload, err := sigtoken.Verify(qrcodeSignKey(), state, sigTokenTypeReferral, time.Now())
+	if err != nil {
+		log.Info(ctx, "referrerFromState.Verify() error", zap.Error(err))
+		return 0
+	}
+	referrer, err := s.dao.FindCustomer(ctx, map[string]interface{}{
+		"id":     payload.Subject,
+		"status": global.ActiveStatus,
+	})
+	if err != nil || referrer.ID == 0 || referrer.OpenID == openID {
+		return 0
+	}
+	referral, err := s.dao.FindReferral(ctx, map[string]interface{}{"referee_open_id": openID})
+	if err != nil || referral.ID != 0 {
+		return 0
+	}
+	return referrer.ID
+}
+
+// rewardReferrer 被邀请人首次完成一轮签到并领取福利后，给邀请人发放奖励
+func (s *Service) rewardReferrer(ctx context.Context, customer *model.Customer) {
+	if customer.ReferrerID == 0 || !viper.GetBool(config.KeyReferralEnable) {
+		return
+	}
+	referral, err := s.dao.FindReferral(ctx, map[string]interface{}{"referee_id": customer.ID})
+	if err != nil {
+		log.Warn(ctx, "rewardReferrer.FindReferral() error", zap.Error(err))
+		return
+	}
+	if referral.ID == 0 || referral.RewardedAt != nil {
+		return
+	}
+	rewardType := viper.GetString(config.KeyReferralRewardType)
+	if rewardType != global.ReferralRewardGift {
+		rewardType = global.ReferralRewardSupplement
+	}
+	if err := s.dao.RewardReferral(ctx, referral, rewardType, viper.GetUint64(config.KeyReferralRewardNum)); err != nil {
+		log.Warn(ctx, "rewardReferrer.RewardReferral() error", zap.Uint64("referral_id", referral.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/util"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// initRoles 创建内置角色，首次启用角色时将已有的后台用户设为超级管理员
+// 已有用户分配了角色后不再自动分配，未分配角色的用户没有任何权限，需由管理员手动分配
+func (s *Service) initRoles(ctx context.Context) {
+	for name, permissions := range global.DefaultRolePermissions {
+		role, err := s.dao.FindRole(ctx, map[string]interface{}{"name": name})
+		if err != nil {
+			log.Error(ctx, "initRoles.FindRole() error", zap.String("role", name), zap.Error(err))
+			continue
+		}
+		if role.ID != 0 {
+			s.syncRolePermissions(ctx, role, permissions)
+			continue
+		}
+		if err := s.dao.CreateRole(ctx, &model.Role{Name: name}, permissions); err != nil {
+			log.Error(ctx, "initRoles.CreateRole() error", zap.String("role", name), zap.Error(err))
+		}
+	}
+
+	assigned, err := s.dao.FindUser(ctx, "role_id <> 0")
+	if err != nil {
+		log.Error(ctx, "initRoles.FindUser() error", zap.Error(err))
+		return
+	}
+	if assigned.ID != 0 {
+		unassigned, err := s.dao.FindUser(ctx, map[string]interface{}{"role_id": 0})
+		if err == nil && unassigned.ID != 0 {
+			log.Warn(ctx, "initRoles found users without role, assign roles manually", zap.Uint64("user_id", unassigned.ID))
+		}
+		return
+	}
+
+	superAdmin, err := s.dao.FindRole(ctx, map[string]interface{}{"name": global.RoleSuperAdmin})
+	if err != nil || superAdmin.ID == 0 {
+		log.Error(ctx, "initRoles.FindRole() super admin not found", zap.Error(err))
+		return
+	}
+	if err := s.dao.UpdateUser(ctx, map[string]interface{}{"role_id": 0}, map[string]interface{}{
+		"role_id": superAdmin.ID,
+	}); err != nil {
+		log.Error(ctx, "initRoles.UpdateUser() error", zap.Error(err))
+	}
+}
+
+// syncRolePermissions 为已存在的内置角色补齐新增的默认权限
+func (s *Service) syncRolePermissions(ctx context.Context, role *model.Role, permissions []string) {
+	owned, err := s.dao.ListRolePermission(ctx, role.ID)
+	if err != nil {
+		log.Error(ctx, "syncRolePermissions.ListRolePermission() error", zap.String("role", role.Name), zap.Error(err))
+		return
+	}
+	ownedSet := make(map[string]bool, len(owned))
+	for _, p := range owned {
+		ownedSet[p] = true
+	}
+	var missing []string
+	for _, p := range permissions {
+		if !ownedSet[p] {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	if err := s.dao.CreateRolePermission(ctx, role.ID, missing); err != nil {
+		log.Error(ctx, "syncRolePermissions.CreateRolePermission() error", zap.String("role", role.Name), zap.Error(err))
+	}
+}
+
+// HasPermission 后台用户是否拥有某项权限
+func (s *Service) HasPermission(ctx context.Context, uid uint64, permission string) bool {
+	user, err := s.dao.FindUser(ctx, map[string]interface{}{
+		"id":     uid,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		log.Error(ctx, "HasPermission.FindUser() error", zap.Error(err))
+		return false
+	}
+	if user.ID == 0 || user.RoleID == 0 {
+		return false
+	}
+	permissions, err := s.dao.ListRolePermission(ctx, user.RoleID)
+	if err != nil {
+		log.Error(ctx, "HasPermission.ListRolePermission() error", zap.Error(err))
+		return false
+	}
+	for _, p := range permissions {
+		if p == global.PermAll || p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRoleList 获取后台角色列表
+func (s *Service) GetRoleList(ctx context.Context) ([]*model.Role, wsgin.APICode, error) {
+	roles, err := s.dao.ListRole(ctx)
+	if err != nil {
+		return nil, apicode.ErrGetListData, err
+	}
+	return roles, wsgin.APICodeSuccess, nil
+}
+
+// AddUser 新增后台用户
+func (s *Service) AddUser(ctx context.Context, vo *model.UserAddVO) (wsgin.APICode, error) {
+	user, err := s.dao.FindUser(ctx, map[string]interface{}{"name": vo.Name})
+	if err != nil {
+		return apicode.ErrModelCreate, err
+	}
+	if user.ID != 0 {
+		return apicode.ErrUserExists, errors.New("用户名已存在")
+	}
+	if code, err := s.checkRole(ctx, vo.RoleID); err != nil {
+		return code, err
+	}
+	hashed, err := util.HashPassword(vo.Password)
+	if err != nil {
+		return apicode.ErrModelCreate, err
+	}
+	if err := s.dao.CreateUser(ctx, model.User{
+		Name:     vo.Name,
+		Password: hashed,
+		RoleID:   vo.RoleID,
+	}); err != nil {
+		return apicode.ErrModelCreate, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// UpdateUserRole 修改后台用户角色
+func (s *Service) UpdateUserRole(ctx context.Context, userID, roleID uint64) (wsgin.APICode, error) {
+	if code, err := s.checkRole(ctx, roleID); err != nil {
+		return code, err
+	}
+	if err := s.dao.UpdateUser(ctx, map[string]interface{}{"id": userID}, map[string]interface{}{
+		"role_id": roleID,
+	}); err != nil {
+		return apicode.ErrUpdateUserRole, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// checkRole 校验角色是否存在
+func (s *Service) checkRole(ctx context.Context, roleID uint64) (wsgin.APICode, error) {
+	role, err := s.dao.FindRole(ctx, map[string]interface{}{
+		"id":     roleID,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return apicode.ErrRoleNotExists, err
+	}
+	if role.ID == 0 {
+		return apicode.ErrRoleNotExists, errors.New("角色不存在")
+	}
+	return wsgin.APICodeSuccess, nil
+}
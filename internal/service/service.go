@@ -3,22 +3,65 @@ package service
 import (
 	"context"
 
+	"github.com/pkg/errors"
+
 	"welfare-sign/internal/dao"
+	"welfare-sign/internal/pkg/badge"
+	"welfare-sign/internal/pkg/bizday"
+	"welfare-sign/internal/pkg/notify"
+	"welfare-sign/internal/pkg/sms"
+	"welfare-sign/internal/pkg/supplement"
+	"welfare-sign/internal/pkg/wsgin"
 )
 
 // Service service.
 type Service struct {
 	dao dao.Dao
+	sms sms.Sender
+	cal *bizday.Calendar // 业务日历，签到相关的日期均按此计算
+
+	notifier notify.Notifier // 客户通知
+
+	policy supplement.Policy  // 补签规则
+	badges []badge.Definition // 徽章定义
 }
 
 // New new a service and return.
 func New() (s *Service) {
+	sender, err := sms.New()
+	if err != nil {
+		panic(errors.WithMessage(err, "service.New() sms error"))
+	}
+	cal, err := bizday.New()
+	if err != nil {
+		panic(errors.WithMessage(err, "service.New() bizday error"))
+	}
+	badges, err := badge.Load()
+	if err != nil {
+		panic(errors.WithMessage(err, "service.New() badge error"))
+	}
 	s = &Service{
 		dao: dao.New(),
+		sms: sender,
+		cal: cal,
+
+		policy: supplement.New(),
+		badges: badges,
+	}
+	if s.notifier, err = notify.New(sender, s.wxAccessToken, s.saveInboxMessage); err != nil {
+		panic(errors.WithMessage(err, "service.New() notify error"))
 	}
+	s.migrateUserPassword(context.Background())
+	s.migrateMerchantStaff(context.Background())
+	s.initRoles(context.Background())
 	return s
 }
 
+// IdempotencyStore 幂等键存储，供 wsgin 处理 Idempotency-Key
+func (s *Service) IdempotencyStore() wsgin.IdempotencyStore {
+	return s.dao
+}
+
 // Ping ping the resource.
 func (s *Service) Ping(ctx context.Context) (err error) {
 	return s.dao.Ping(ctx)
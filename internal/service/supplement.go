@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/dao"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/supplement"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// supplementRecords 获取客户本轮签到中可补签的漏签记录(按时间正序)及已补签的天数，today 为当前业务日的开始时刻
+func (s *Service) supplementRecords(ctx context.Context, customerID uint64, today time.Time) ([]*model.CheckinRecord, int, error) {
+	records, err := s.dao.ListCheckinRecord(ctx, "status <> ? AND customer_id = ?", global.DeleteStatus, customerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	var (
+		supplemented int
+		missed       []time.Time
+		missedRecord = make(map[int64]*model.CheckinRecord)
+	)
+	for _, record := range records {
+		switch {
+		case record.Status == global.ActiveStatus && (record.SupplementType != "" || record.HelpCheckinCustomerID != 0):
+			supplemented++
+		case record.Status == global.InactiveStatus:
+			missed = append(missed, record.NeedCheckinTime)
+			missedRecord[record.NeedCheckinTime.UnixNano()] = record
+		}
+	}
+	days := s.policy.Eligible(missed, today)
+	eligible := make([]*model.CheckinRecord, 0, len(days))
+	for _, day := range days {
+		eligible = append(eligible, missedRecord[day.UnixNano()])
+	}
+	return eligible, supplemented, nil
+}
+
+// supplementCode 补签规则校验失败时对应的错误码
+func supplementCode(err error, defaultCode wsgin.APICode) wsgin.APICode {
+	switch err {
+	case supplement.ErrNothingToMakeUp:
+		return apicode.ErrNoSupplement
+	case supplement.ErrCycleLimit:
+		return apicode.ErrSupplementLimit
+	case supplement.ErrHelperDailyLimit:
+		return apicode.ErrHelpCheckinDailyLimit
+	case supplement.ErrHelperTargetLimit:
+		return apicode.ErrHasHelpCheckin
+	case supplement.ErrPayDisabled:
+		return apicode.ErrSupplementPayDisabled
+	case dao.ErrCheckinRecordSupplemented:
+		return apicode.ErrNoSupplement
+	}
+	return defaultCode
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/jwt"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// createTokenPair 生成access token和refresh token
+func (s *Service) createTokenPair(ctx context.Context, session *model.TokenSession) (*model.TokenResp, error) {
+	accessToken, err := jwt.CreateTokenWithParams(jwt.TokenParames{
+		UID:       session.UID,
+		Name:      session.Name,
+		Mobile:    session.Mobile,
+		Role:      session.Role,
+		StaffID:   session.StaffID,
+		StaffRole: session.StaffRole,
+	})
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := jwt.CreateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.dao.SaveRefreshToken(ctx, refreshToken, session, jwt.RefreshExpire()); err != nil {
+		return nil, err
+	}
+	return &model.TokenResp{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(jwt.AccessExpire() / time.Second),
+	}, nil
+}
+
+// RefreshToken 使用refresh token换取新的token，旧的refresh token随即失效
+func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (*model.TokenResp, wsgin.APICode, error) {
+	session, err := s.dao.GetRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, apicode.ErrRefreshToken, err
+	}
+	if session == nil {
+		return nil, apicode.ErrRefreshToken, errors.New("refresh token不存在或已过期")
+	}
+	if session.Role == jwt.RoleMerchant {
+		active := false
+		if session.StaffID != 0 {
+			if active, err = s.isStaffActive(ctx, session.UID, session.StaffID); err != nil {
+				return nil, apicode.ErrRefreshToken, err
+			}
+		}
+		if !active {
+			return nil, apicode.ErrRefreshToken, errors.New("员工不存在或已被停用")
+		}
+	}
+	// 以删除结果为准，并发使用同一refresh token时只有一个请求成功
+	deleted, err := s.dao.DelRefreshToken(ctx, refreshToken, session)
+	if err != nil {
+		return nil, apicode.ErrRefreshToken, err
+	}
+	if !deleted {
+		return nil, apicode.ErrRefreshToken, errors.New("refresh token已被使用")
+	}
+	data, err := s.createTokenPair(ctx, session)
+	if err != nil {
+		log.Warn(ctx, "RefreshToken.createTokenPair() error", zap.Error(err))
+		return nil, apicode.ErrCreateToken, err
+	}
+	return data, wsgin.APICodeSuccess, nil
+}
+
+// Logout 退出登录，注销当前access token及传入的refresh token
+func (s *Service) Logout(ctx context.Context, params *jwt.TokenParames, refreshToken string) (wsgin.APICode, error) {
+	expire := time.Until(time.Unix(params.ExpiresAt, 0))
+	if err := s.dao.RevokeToken(ctx, params.Id, expire); err != nil {
+		return apicode.ErrLogout, err
+	}
+	if refreshToken == "" {
+		return wsgin.APICodeSuccess, nil
+	}
+	session, err := s.dao.GetRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return apicode.ErrLogout, err
+	}
+	// 只允许注销属于自己的refresh token
+	if session == nil || session.UID != params.UID || session.Role != params.Role {
+		return wsgin.APICodeSuccess, nil
+	}
+	if _, err := s.dao.DelRefreshToken(ctx, refreshToken, session); err != nil {
+		return apicode.ErrLogout, err
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// revokeAccount 注销账号下所有会话，用于禁用、删除账号
+func (s *Service) revokeAccount(ctx context.Context, role string, uid uint64) {
+	if err := s.dao.RevokeAccount(ctx, role, uid, jwt.AccessExpire()); err != nil {
+		log.Error(ctx, "revokeAccount error", zap.String("role", role), zap.Uint64("uid", uid), zap.Error(err))
+	}
+}
+
+// IsTokenRevoked access token是否已被注销，缓存不可用时视为已注销
+func (s *Service) IsTokenRevoked(ctx context.Context, params *jwt.TokenParames) bool {
+	revoked, err := s.dao.IsTokenRevoked(ctx, params.Id, params.Role, params.UID, params.IssuedAt)
+	if err == nil && !revoked && params.StaffID != 0 {
+		revoked, err = s.dao.IsTokenRevoked(ctx, "", staffAccountRole, params.StaffID, params.IssuedAt)
+	}
+	if err != nil {
+		log.Error(ctx, "IsTokenRevoked error", zap.Error(err))
+		return true
+	}
+	return revoked
+}
@@ -2,41 +2,128 @@ package service
 
 import (
 	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
 
 	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
 	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/config"
 	"welfare-sign/internal/pkg/jwt"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/util"
 	"welfare-sign/internal/pkg/wsgin"
 )
 
-// UserLogin 后台用户登录
-func (s *Service) UserLogin(ctx context.Context, vo *model.UserVO) (string, wsgin.APICode, error) {
+// UserLogin 后台用户登录，分别按用户名和IP、按用户名限制连续失败次数，任一超过上限即锁定
+// 按用户名的上限较高，防止更换IP暴力破解同一用户；每次登录先累加尝试次数再校验密码，并发尝试也无法超过上限
+func (s *Service) UserLogin(ctx context.Context, vo *model.UserVO) (*model.TokenResp, wsgin.APICode, error) {
+	clientIP := wsgin.ClientIPFromContext(ctx)
+	lockTime := time.Duration(viper.GetInt(config.KeyUserLoginLockTime)) * time.Minute
+	ipAttempts, accountAttempts, err := s.dao.IncrUserLoginFail(ctx, vo.Name, clientIP, lockTime)
+	if err != nil {
+		return nil, apicode.ErrLogin, err
+	}
+	if ipAttempts > viper.GetInt64(config.KeyUserLoginMaxAttempts) || accountAttempts > viper.GetInt64(config.KeyUserLoginAccountMaxAttempts) {
+		return nil, apicode.ErrLoginLocked, errors.New("登录失败次数过多，已被临时锁定")
+	}
 	user, err := s.dao.FindUser(ctx, map[string]interface{}{
-		"name":     vo.Name,
-		"password": vo.Password,
+		"name":   vo.Name,
+		"status": global.ActiveStatus,
 	})
 	if err != nil {
-		return "", apicode.ErrLogin, err
+		return nil, apicode.ErrLogin, err
 	}
-	token, err := jwt.CreateToken(user.ID, user.Name, "")
+	if user.ID == 0 || !util.CheckPassword(user.Password, vo.Password) {
+		return nil, apicode.ErrLogin, errors.New("用户名或密码错误")
+	}
+	if err := s.dao.DelUserLoginFail(ctx, vo.Name, clientIP); err != nil {
+		log.Warn(ctx, "UserLogin.DelUserLoginFail() error", zap.Error(err))
+	}
+	token, err := s.createTokenPair(ctx, &model.TokenSession{
+		UID:  user.ID,
+		Name: user.Name,
+		Role: jwt.RoleAdmin,
+	})
 	if err != nil {
-		return "", apicode.ErrCreateToken, err
+		return nil, apicode.ErrCreateToken, err
 	}
 	return token, wsgin.APICodeSuccess, nil
 }
 
+// ChangePassword 后台用户修改密码，修改成功后注销该用户所有会话
+func (s *Service) ChangePassword(ctx context.Context, userID uint64, vo *model.UserPasswordVO) (wsgin.APICode, error) {
+	user, err := s.dao.FindUser(ctx, map[string]interface{}{
+		"id":     userID,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return apicode.ErrChangePassword, err
+	}
+	if user.ID == 0 || !util.CheckPassword(user.Password, vo.OldPassword) {
+		return apicode.ErrChangePassword, errors.New("原密码错误")
+	}
+	hashed, err := util.HashPassword(vo.NewPassword)
+	if err != nil {
+		return apicode.ErrChangePassword, err
+	}
+	ok, err := s.dao.UpdateUserPassword(ctx, user.ID, user.Password, hashed)
+	if err != nil {
+		return apicode.ErrChangePassword, err
+	}
+	if !ok {
+		return apicode.ErrChangePassword, errors.New("密码已被修改，请重试")
+	}
+	s.revokeAccount(ctx, jwt.RoleAdmin, user.ID)
+	return wsgin.APICodeSuccess, nil
+}
+
+// migrateUserPassword 将仍以明文存储的后台用户密码转换为哈希值
+func (s *Service) migrateUserPassword(ctx context.Context) {
+	users, err := s.dao.ListUser(ctx, map[string]interface{}{})
+	if err != nil {
+		log.Error(ctx, "migrateUserPassword.ListUser() error", zap.Error(err))
+		return
+	}
+	for _, user := range users {
+		if util.IsPasswordHashed(user.Password) {
+			continue
+		}
+		hashed, err := util.HashPassword(user.Password)
+		if err != nil {
+			log.Error(ctx, "migrateUserPassword.HashPassword() error", zap.Uint64("user_id", user.ID), zap.Error(err))
+			continue
+		}
+		if _, err := s.dao.UpdateUserPassword(ctx, user.ID, user.Password, hashed); err != nil {
+			log.Error(ctx, "migrateUserPassword.UpdateUserPassword() error", zap.Uint64("user_id", user.ID), zap.Error(err))
+		}
+	}
+}
+
 // GetAllCustomerCheckinRecordList 获取用户有效的签到记录列表
 // TODO: 临时
 func (s *Service) GetAllCustomerCheckinRecordList(ctx context.Context) ([]*model.CheckinRecordListResp, wsgin.APICode, error) {
-	checkinRecordListResps, _ := s.dao.GetTmpCheckinRecordList(ctx)
+	checkinRecordListResps, _ := s.dao.GetTmpCheckinRecordList(ctx, s.cal.AddDays(time.Now(), 1))
 	return checkinRecordListResps, wsgin.APICodeSuccess, nil
 }
 
 // ModifyCustomerCheckinRecord 后台用户更新用户签到状态
 // TODO: 临时
 func (s *Service) ModifyCustomerCheckinRecord(ctx context.Context, checkinRecordID uint64, status string) (wsgin.APICode, error) {
+	before, err := s.dao.FindCheckinRecord(ctx, map[string]interface{}{"id": checkinRecordID})
+	if err != nil {
+		return wsgin.APICodeDefault, err
+	}
 	if err := s.dao.UpdateCustomerCheckinRecord(ctx, checkinRecordID, status); err != nil {
 		return wsgin.APICodeDefault, err
 	}
+	if before.ID != 0 {
+		after := *before
+		after.Status = status
+		s.audit(ctx, global.AuditActionCheckinRecordModify, global.AuditEntityCheckinRecord, checkinRecordID, before, &after)
+	}
 	return wsgin.APICodeSuccess, nil
 }
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/go-redis/redis"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
@@ -15,11 +17,27 @@ import (
 	"welfare-sign/internal/pkg/wsgin"
 )
 
-// SendVerifyCode 发送验证码
-func (s *Service) SendVerifyCode(ctx context.Context, mobile string) (wsgin.APICode, error) {
+// SendVerifyCode 发送验证码，同一手机号有发送间隔限制，手机号和IP有每天发送次数上限
+func (s *Service) SendVerifyCode(ctx context.Context, mobile, clientIP string) (wsgin.APICode, error) {
 	if viper.GetBool(config.KeySMSEnable) {
+		interval := time.Duration(viper.GetInt(config.KeySMSSendInterval)) * time.Second
+		ok, err := s.dao.SetSMSCooldown(ctx, mobile, interval)
+		if err != nil {
+			return apicode.ErrSendSMS, err
+		}
+		if !ok {
+			return apicode.ErrSMSTooFrequent, errors.New("验证码发送过于频繁")
+		}
+		if apiCode, err := s.checkSMSDailyLimit(ctx, mobile, clientIP); err != nil {
+			return apiCode, err
+		}
+
 		code := util.GenerateCode()
-		if err := sms.Send(mobile, viper.GetString(config.KeySMSTemplate), map[string]string{"code": code}); err != nil {
+		if err := s.sms.Send(mobile, sms.TemplateCode(sms.TemplateLoginCode), map[string]string{"code": code}); err != nil {
+			// 发送失败允许立即重试
+			if err := s.dao.DelSMSCooldown(ctx, mobile); err != nil {
+				log.Warn(ctx, "SendVerifyCode.DelSMSCooldown() error", zap.Error(err))
+			}
 			return apicode.ErrSendSMS, err
 		}
 		if err := s.dao.SaveSMSCode(ctx, mobile, code); err != nil {
@@ -29,14 +47,54 @@ func (s *Service) SendVerifyCode(ctx context.Context, mobile string) (wsgin.APIC
 	return wsgin.APICodeSuccess, nil
 }
 
-// ValidateCode 根据传入的手机号，验证码验证是否正确
-func (s *Service) ValidateCode(ctx context.Context, mobile, code string) error {
+// checkSMSDailyLimit 校验手机号及IP当天发送次数
+func (s *Service) checkSMSDailyLimit(ctx context.Context, mobile, clientIP string) (wsgin.APICode, error) {
+	n, err := s.dao.IncrSMSDailyCount(ctx, "mobile:"+mobile)
+	if err != nil {
+		return apicode.ErrSendSMS, err
+	}
+	if n > viper.GetInt64(config.KeySMSMobileDailyMax) {
+		return apicode.ErrSMSTooFrequent, errors.New("该手机号今日验证码发送次数已达上限")
+	}
+	if clientIP == "" {
+		return wsgin.APICodeSuccess, nil
+	}
+	n, err = s.dao.IncrSMSDailyCount(ctx, "ip:"+clientIP)
+	if err != nil {
+		return apicode.ErrSendSMS, err
+	}
+	if n > viper.GetInt64(config.KeySMSIPDailyMax) {
+		return apicode.ErrSMSTooFrequent, errors.New("该IP今日验证码发送次数已达上限")
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// ValidateCode 根据传入的手机号，验证码验证是否正确，验证成功后验证码失效
+// 每次验证先累加验证次数，超过上限时验证码失效，并发的验证请求也无法超过上限
+func (s *Service) ValidateCode(ctx context.Context, mobile, code string) (wsgin.APICode, error) {
+	attempts, err := s.dao.IncrSMSCodeAttempts(ctx, mobile)
+	if err != nil {
+		return apicode.ErrVerifyCode, err
+	}
+	if attempts > viper.GetInt64(config.KeySMSMaxAttempts) {
+		if err := s.dao.DelSMSCode(ctx, mobile); err != nil {
+			log.Warn(ctx, "ValidateCode.DelSMSCode() error", zap.Error(err))
+		}
+		return apicode.ErrSMSTooManyAttempts, errors.New("验证码错误次数过多，验证码已失效")
+	}
+
 	res, err := s.dao.GetSMSCode(ctx, mobile)
+	if err == redis.Nil {
+		return apicode.ErrVerifyCode, errors.New("验证码不存在或已过期")
+	}
 	if err != nil {
-		return err
+		return apicode.ErrVerifyCode, err
 	}
 	if res != code {
-		return errors.New("验证码不正确")
+		return apicode.ErrVerifyCode, errors.New("验证码不正确")
 	}
-	return nil
+	if err := s.dao.DelSMSCode(ctx, mobile); err != nil {
+		log.Warn(ctx, "ValidateCode.DelSMSCode() error", zap.Error(err))
+	}
+	return wsgin.APICodeSuccess, nil
 }
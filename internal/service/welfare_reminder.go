@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/notify"
+	"welfare-sign/internal/pkg/sms"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// RemindExpiringWelfare 提醒客户即将过期的福利，福利在过期前的每个提醒时间点最多成功提醒一次
+// 福利在发放 task.checkin_expired_time 分钟后由 FailureIssueRecord 失效
+func (s *Service) RemindExpiringWelfare(ctx context.Context) (wsgin.APICode, error) {
+	expired := viper.GetInt(config.KeyTaskCheckinExpiredTime)
+	if expired <= 0 {
+		return wsgin.APICodeSuccess, nil
+	}
+	befores := remindBefores(expired)
+	now := time.Now()
+	for i, before := range befores {
+		// 距过期剩余 (next, before] 分钟的福利按 before 提醒，任务中断后不会对同一福利连续提醒多次
+		next := 0
+		if i+1 < len(befores) {
+			next = befores[i+1]
+		}
+		begin := now.Add(-time.Duration(expired-next) * time.Minute)
+		end := now.Add(-time.Duration(expired-before) * time.Minute)
+		issueRecords, err := s.dao.ListExpiringIssueRecords(ctx, begin, end, before)
+		if err != nil {
+			log.Error(ctx, "RemindExpiringWelfare.ListExpiringIssueRecords() error", zap.Error(err))
+			return apicode.ErrTaskRemindWelfare, err
+		}
+		for _, issueRecord := range issueRecords {
+			expiresAt := issueRecord.CreatedAt.Add(time.Duration(expired) * time.Minute)
+			if err := s.remindExpiringWelfare(ctx, issueRecord, before, expiresAt); err != nil {
+				log.Error(ctx, "RemindExpiringWelfare error", zap.Uint64("issue_record_id", issueRecord.ID), zap.Error(err))
+			}
+		}
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// remindExpiringWelfare 发送单条福利过期提醒，先记录提醒再发送，避免并发任务重复提醒
+// 所有渠道都发送失败时删除提醒记录，福利仍在该提醒时间段内时下次任务会重新提醒
+func (s *Service) remindExpiringWelfare(ctx context.Context, issueRecord *model.IssueRecord, before int, expiresAt time.Time) error {
+	created, err := s.dao.CreateWelfareReminder(ctx, &model.WelfareReminder{
+		IssueRecordID: issueRecord.ID,
+		RemindBefore:  before,
+		CustomerID:    issueRecord.CustomerID,
+	})
+	if err != nil || !created {
+		return err
+	}
+	err = s.sendWelfareReminder(ctx, issueRecord, expiresAt)
+	if err == nil || err == notify.ErrSkipped {
+		return nil
+	}
+	if delErr := s.dao.DelWelfareReminder(ctx, issueRecord.ID, before); delErr != nil {
+		log.Error(ctx, "remindExpiringWelfare.DelWelfareReminder() error", zap.Uint64("issue_record_id", issueRecord.ID), zap.Error(delErr))
+	}
+	return err
+}
+
+// sendWelfareReminder 通过通知渠道发送福利过期提醒，客户已失效时返回 notify.ErrSkipped
+func (s *Service) sendWelfareReminder(ctx context.Context, issueRecord *model.IssueRecord, expiresAt time.Time) error {
+	customer, err := s.dao.FindCustomer(ctx, map[string]interface{}{
+		"id":     issueRecord.CustomerID,
+		"status": global.ActiveStatus,
+	})
+	if err != nil {
+		return err
+	}
+	if customer.ID == 0 {
+		return notify.ErrSkipped
+	}
+	merchant, err := s.dao.FindMerchant(ctx, map[string]interface{}{"id": issueRecord.MerchantID})
+	if err != nil {
+		return err
+	}
+
+	num := issueRecord.TotalReceive - issueRecord.Received
+	expireTime := expiresAt.In(s.cal.Location()).Format("2006-01-02 15:04")
+	return s.notifier.Notify(ctx, &notify.Message{
+		CustomerID: customer.ID,
+		Mobile:     customer.Mobile,
+		OpenID:     customer.OpenID,
+		Template:   sms.TemplateWelfareExpiring,
+		Params: map[string]string{
+			"merchant": merchant.StoreName,
+			"num":      strconv.FormatUint(num, 10),
+			"time":     expireTime,
+		},
+		Title:   "福利即将过期",
+		Content: fmt.Sprintf("您在%s的%d份福利将于%s过期，请尽快到店核销", merchant.StoreName, num, expireTime),
+	})
+}
+
+// notifyWelfareClaimed 通知客户福利领取成功，发送失败只记录日志
+func (s *Service) notifyWelfareClaimed(ctx context.Context, customer *model.Customer, merchant *model.Merchant, num uint64) {
+	err := s.notifier.Notify(ctx, &notify.Message{
+		CustomerID: customer.ID,
+		Mobile:     customer.Mobile,
+		OpenID:     customer.OpenID,
+		Template:   sms.TemplateWelfareClaimed,
+		Params: map[string]string{
+			"merchant": merchant.StoreName,
+			"num":      strconv.FormatUint(num, 10),
+		},
+		Title:   "福利领取成功",
+		Content: fmt.Sprintf("您已成功领取%s的%d份福利，请尽快到店核销", merchant.StoreName, num),
+	})
+	if err != nil && err != notify.ErrSkipped {
+		log.Warn(ctx, "notifyWelfareClaimed error", zap.Uint64("customer_id", customer.ID), zap.Error(err))
+	}
+}
+
+// remindBefores 有效的提醒时间点，单位分钟，按从早到晚排序
+func remindBefores(expired int) []int {
+	var befores []int
+	if err := viper.UnmarshalKey(config.KeyTaskWelfareRemindBefore, &befores); err != nil {
+		return nil
+	}
+	seen := make(map[int]bool, len(befores))
+	valid := befores[:0]
+	for _, before := range befores {
+		if before <= 0 || before >= expired || seen[before] {
+			continue
+		}
+		seen[before] = true
+		valid = append(valid, before)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(valid)))
+	return valid
+}
+
+// saveInboxMessage 保存站内信，供站内信通知渠道使用
+func (s *Service) saveInboxMessage(ctx context.Context, msg *notify.Message) error {
+	return s.dao.CreateInboxMessage(ctx, &model.InboxMessage{
+		CustomerID: msg.CustomerID,
+		Title:      msg.Title,
+		Content:    msg.Content,
+		Template:   msg.Template,
+	})
+}
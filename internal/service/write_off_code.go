@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/sigtoken"
+	"welfare-sign/internal/pkg/util"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// sigTokenTypeWriteOff 核销二维码token用途
+const sigTokenTypeWriteOff = "writeoff"
+
+// qrcodeSignKey 核销二维码签名密钥，未配置时使用jwt签名密钥
+func qrcodeSignKey() []byte {
+	if key := viper.GetString(config.KeyQRCodeSign); key != "" {
+		return []byte(key)
+	}
+	return []byte(viper.GetString(config.KeyJWTSign))
+}
+
+// createWriteOffCode 生成绑定客户的一次性核销码
+func (s *Service) createWriteOffCode(ctx context.Context, customerID uint64) (string, error) {
+	uid, err := util.NewV4()
+	if err != nil {
+		return "", err
+	}
+	nonce := strings.Replace(uid.String(), "-", "", -1)
+	expire := time.Duration(viper.GetInt(config.KeyQRCodeExpire)) * time.Second
+	if err := s.dao.SaveQRCodeNonce(ctx, nonce, customerID, expire); err != nil {
+		return "", err
+	}
+	return sigtoken.Sign(qrcodeSignKey(), sigtoken.Payload{
+		Type:      sigTokenTypeWriteOff,
+		Subject:   customerID,
+		Nonce:     nonce,
+		ExpiresAt: time.Now().Add(expire).Unix(),
+	})
+}
+
+// verifyWriteOffCode 校验核销码，返回客户ID及需要在核销时使用掉的随机数
+// 开启 qrcode.legacy_customer_id 时，未传核销码可直接使用客户ID
+func (s *Service) verifyWriteOffCode(ctx context.Context, code string, customerID uint64) (uint64, string, wsgin.APICode, error) {
+	if code == "" {
+		if customerID != 0 && viper.GetBool(config.KeyQRCodeLegacyCustomerID) {
+			return customerID, "", wsgin.APICodeSuccess, nil
+		}
+		return 0, "", apicode.ErrWriteOffCode, errors.New("缺少核销码")
+	}
+	payload, err := sigtoken.Verify(qrcodeSignKey(), code, sigTokenTypeWriteOff, time.Now())
+	if err != nil {
+		return 0, "", apicode.ErrWriteOffCode, err
+	}
+	nonceCustomerID, err := s.dao.GetQRCodeNonce(ctx, payload.Nonce)
+	if err != nil {
+		return 0, "", apicode.ErrWriteOffCode, err
+	}
+	if nonceCustomerID != payload.Subject {
+		return 0, "", apicode.ErrWriteOffCode, errors.New("核销码已被使用")
+	}
+	return payload.Subject, payload.Nonce, wsgin.APICodeSuccess, nil
+}
+
+// consumeWriteOffCode 使用掉核销码，同一核销码只能成功使用一次
+func (s *Service) consumeWriteOffCode(ctx context.Context, nonce string) (wsgin.APICode, error) {
+	if nonce == "" {
+		return wsgin.APICodeSuccess, nil
+	}
+	ok, err := s.dao.DelQRCodeNonce(ctx, nonce)
+	if err != nil {
+		return apicode.ErrWriteOffCode, err
+	}
+	if !ok {
+		return apicode.ErrWriteOffCode, errors.New("核销码已被使用")
+	}
+	return wsgin.APICodeSuccess, nil
+}
+
+// restoreWriteOffCode 核销失败时恢复已使用掉的核销码，核销码未过期时客户无需刷新二维码即可重试
+func (s *Service) restoreWriteOffCode(ctx context.Context, code string) {
+	if code == "" {
+		return
+	}
+	payload, err := sigtoken.Verify(qrcodeSignKey(), code, sigTokenTypeWriteOff, time.Now())
+	if err != nil {
+		return
+	}
+	expire := time.Until(time.Unix(payload.ExpiresAt, 0))
+	if expire <= 0 {
+		return
+	}
+	if err := s.dao.SaveQRCodeNonce(ctx, payload.Nonce, payload.Subject, expire); err != nil {
+		log.Warn(ctx, "restoreWriteOffCode.SaveQRCodeNonce() error", zap.Error(err))
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"welfare-sign/internal/apicode"
+	"welfare-sign/internal/dao"
+	"welfare-sign/internal/global"
+	"welfare-sign/internal/model"
+	"welfare-sign/internal/pkg/config"
+	"welfare-sign/internal/pkg/log"
+	"welfare-sign/internal/pkg/wsgin"
+)
+
+// maxWriteOffQueryDays 核销记录单次最多可查询的天数
+const maxWriteOffQueryDays = 92
+
+// GetWriteOffLogList 获取商户核销记录
+func (s *Service) GetWriteOffLogList(ctx context.Context, vo *model.WriteOffLogListVO) ([]*model.WriteOffLogItem, int, wsgin.APICode, error) {
+	begin, end, err := s.writeOffDateRange(vo.BeginDate, vo.EndDate)
+	if err != nil {
+		return nil, 0, apicode.ErrWriteOffDate, err
+	}
+	if vo.PageNo == 0 {
+		vo.PageNo = 1
+	}
+	if vo.PageSize == 0 {
+		vo.PageSize = 10
+	}
+	logs, total, err := s.dao.ListWriteOffLog(ctx, vo.MerchantID, begin, end, vo.PageNo, vo.PageSize)
+	if err != nil {
+		return nil, total, apicode.ErrGetListData, err
+	}
+	return logs, total, wsgin.APICodeSuccess, nil
+}
+
+// GetWriteOffSummary 按天汇总商户核销记录，已撤销的核销不计入，没有核销的日期不返回
+func (s *Service) GetWriteOffSummary(ctx context.Context, vo *model.WriteOffLogListVO) ([]*model.WriteOffSummary, wsgin.APICode, error) {
+	begin, end, err := s.writeOffDateRange(vo.BeginDate, vo.EndDate)
+	if err != nil {
+		return nil, apicode.ErrWriteOffDate, err
+	}
+	days := s.dayBoundaries(begin, end)
+	summaries, err := s.dao.SummaryWriteOffLog(ctx, vo.MerchantID, days)
+	if err != nil {
+		return nil, apicode.ErrGetListData, err
+	}
+	for _, summary := range summaries {
+		summary.Date = s.cal.Date(days[summary.Day])
+	}
+	return summaries, wsgin.APICodeSuccess, nil
+}
+
+// ExportWriteOffLog 导出商户核销记录为CSV
+func (s *Service) ExportWriteOffLog(ctx context.Context, vo *model.WriteOffLogListVO) ([]byte, wsgin.APICode, error) {
+	begin, end, err := s.writeOffDateRange(vo.BeginDate, vo.EndDate)
+	if err != nil {
+		return nil, apicode.ErrWriteOffDate, err
+	}
+	logs, _, err := s.dao.ListWriteOffLog(ctx, vo.MerchantID, begin, end, 0, 0)
+	if err != nil {
+		return nil, apicode.ErrExportWriteOffLog, err
+	}
+
+	var buf bytes.Buffer
+	// 写入BOM，避免Excel打开时中文乱码
+	buf.WriteString("\xEF\xBB\xBF")
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"核销时间", "客户ID", "客户昵称", "福利记录ID", "礼品", "核销数量", "操作员工", "客户端IP", "撤销时间", "撤销原因"})
+	for _, item := range logs {
+		reversedAt := ""
+		if item.ReversedAt != nil {
+			reversedAt = item.ReversedAt.In(s.cal.Location()).Format("2006-01-02 15:04:05")
+		}
+		w.Write([]string{
+			item.CreatedAt.In(s.cal.Location()).Format("2006-01-02 15:04:05"),
+			strconv.FormatUint(item.CustomerID, 10),
+			csvSafe(item.Nickname),
+			strconv.FormatUint(item.IssueRecordID, 10),
+			csvSafe(item.GiftItemName),
+			strconv.FormatUint(item.Num, 10),
+			csvSafe(item.StaffName),
+			item.ClientIP,
+			reversedAt,
+			csvSafe(item.ReverseReason),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, apicode.ErrExportWriteOffLog, err
+	}
+	return buf.Bytes(), wsgin.APICodeSuccess, nil
+}
+
+// ReverseWriteOff 撤销核销，只能撤销本商户在可撤销时间内且福利未过期的核销记录
+func (s *Service) ReverseWriteOff(ctx context.Context, vo *model.WriteOffReverseVO) (*model.WriteOffLog, wsgin.APICode, error) {
+	window := time.Duration(viper.GetInt(config.KeyWriteOffReverseWindow)) * time.Minute
+	if window <= 0 {
+		return nil, apicode.ErrReverseWriteOff, errors.New("未开放撤销核销")
+	}
+	writeOffLog, err := s.dao.FindWriteOffLog(ctx, map[string]interface{}{"id": vo.ID})
+	if err != nil {
+		return nil, apicode.ErrReverseWriteOff, err
+	}
+	if writeOffLog.ID == 0 || writeOffLog.MerchantID != vo.MerchantID {
+		return nil, apicode.ErrWriteOffLogNotExists, errors.New("核销记录不存在")
+	}
+	if writeOffLog.ReversedAt != nil {
+		return nil, apicode.ErrWriteOffReversed, errors.New("核销记录已撤销")
+	}
+	now := time.Now()
+	windowStart := now.Add(-window)
+	if writeOffLog.CreatedAt.Before(windowStart) {
+		return nil, apicode.ErrReverseWindowPassed, errors.Errorf("只能撤销%d分钟内的核销", int(window/time.Minute))
+	}
+
+	issueRecord, err := s.dao.FindIssueRecord(ctx, map[string]interface{}{"id": writeOffLog.IssueRecordID})
+	if err != nil {
+		return nil, apicode.ErrReverseWriteOff, err
+	}
+	// 福利发放后超过失效时间即视为过期，即使失效任务尚未执行
+	var issuedAfter time.Time
+	if expired := viper.GetInt64(config.KeyTaskCheckinExpiredTime); expired > 0 {
+		issuedAfter = now.Add(-time.Duration(expired) * time.Minute)
+	}
+	if issueRecord.ID == 0 || issueRecord.Status != global.ActiveStatus || !issueRecord.CreatedAt.After(issuedAfter) {
+		return nil, apicode.ErrWelfareExpired, errors.New("福利已过期")
+	}
+
+	writeOffLog.ReversedBy = vo.StaffID
+	writeOffLog.ReverseReason = vo.Reason
+	if err := s.dao.ReverseWriteOff(ctx, writeOffLog, windowStart, issuedAfter); err != nil {
+		switch err {
+		case dao.ErrWriteOffReversed:
+			return nil, apicode.ErrWriteOffReversed, err
+		case dao.ErrWriteOffNotReversed:
+			return nil, apicode.ErrWelfareExpired, err
+		}
+		log.Warn(ctx, "ReverseWriteOff.ReverseWriteOff() error", zap.Error(err))
+		return nil, apicode.ErrReverseWriteOff, err
+	}
+	s.hideReversedReview(ctx, writeOffLog.ID)
+	return writeOffLog, wsgin.APICodeSuccess, nil
+}
+
+// dayBoundaries [begin, end) 内各业务日的开始时刻，最后一个元素为 end
+func (s *Service) dayBoundaries(begin, end time.Time) []time.Time {
+	var days []time.Time
+	for day := begin; day.Before(end); day = s.cal.AddDays(day, 1) {
+		days = append(days, day)
+	}
+	return append(days, end)
+}
+
+// csvSafe 以 =、+、-、@、制表符或回车开头的单元格前加单引号，防止在表格软件中被当作公式执行
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// writeOffDateRange 解析查询日期，未传日期时默认查询当天
+func (s *Service) writeOffDateRange(beginDate, endDate string) (begin, end time.Time, err error) {
+	today := s.cal.Date(time.Now())
+	if beginDate == "" {
+		beginDate = today
+	}
+	if endDate == "" {
+		endDate = today
+	}
+	begin, end, err = s.cal.DateRange(beginDate, endDate)
+	if err != nil {
+		return
+	}
+	if end.After(s.cal.AddDays(begin, maxWriteOffQueryDays)) {
+		err = errors.Errorf("最多可查询%d天的核销记录", maxWriteOffQueryDays)
+	}
+	return
+}
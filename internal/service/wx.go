@@ -27,9 +27,7 @@ import (
 // GetWXConfig 获取微信配置
 func (s *Service) GetWXConfig(ctx context.Context, url string) (*model.WXConfigResp, wsgin.APICode, error) {
 	var c model.WXConfigResp
-	appID := viper.GetString(config.KeyWxAppID)
-	appSecret := viper.GetString(config.KeyWxAppSecret)
-	c.Appid = appID
+	c.Appid = viper.GetString(config.KeyWxAppID)
 	c.Timestamp = time.Now().Unix()
 	c.Noncestr = uuid.NewV4().String()
 
@@ -40,32 +38,11 @@ func (s *Service) GetWXConfig(ctx context.Context, url string) (*model.WXConfigR
 		return nil, apicode.ErrGetWXConfig, err
 	}
 	if ticket == "" {
-		accessToken, err := s.dao.GetWXAccessToken()
+		accessToken, err := s.wxAccessToken(ctx)
 		if err != nil {
-			log.Warn(ctx, "GetWXConfig.GetWXAccessToken() error", zap.Error(err))
+			log.Warn(ctx, "GetWXConfig.wxAccessToken() error", zap.Error(err))
 			return nil, apicode.ErrGetWXConfig, err
 		}
-		if accessToken == "" {
-			// get access_token
-			resp, err := http.Get(fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appId=%s&secret=%s", appID, appSecret))
-			if err != nil {
-				return nil, apicode.ErrGetWXConfig, err
-			}
-			defer resp.Body.Close()
-			bytes, err := ioutil.ReadAll(resp.Body)
-			if err != nil {
-				return nil, apicode.ErrGetWXConfig, err
-			}
-			accessToken = gjson.GetBytes(bytes, "access_token").String()
-			if accessToken == "" {
-				return nil, wsgin.APICodeSuccess, errors.New("获取access_token失败")
-			}
-			accessTokenExpire := time.Second * time.Duration(gjson.GetBytes(bytes, "expires_in").Int()-60)
-			if err := s.dao.StoreWXAccessToken(accessToken, accessTokenExpire); err != nil {
-				log.Warn(ctx, "GetWXConfig.StoreWXAccessToken() error", zap.Error(err))
-				return nil, apicode.ErrGetWXConfig, err
-			}
-		}
 
 		resp, err := http.Get(fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/ticket/getticket?access_token=%s&type=jsapi", accessToken))
 		if err != nil {
@@ -96,6 +73,33 @@ func (s *Service) GetWXConfig(ctx context.Context, url string) (*model.WXConfigR
 	return &c, wsgin.APICodeSuccess, nil
 }
 
+// wxAccessToken 获取公众号access_token，缓存中没有时重新获取
+func (s *Service) wxAccessToken(ctx context.Context) (string, error) {
+	accessToken, err := s.dao.GetWXAccessToken()
+	if err != nil || accessToken != "" {
+		return accessToken, err
+	}
+	resp, err := http.Get(fmt.Sprintf("https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appId=%s&secret=%s",
+		viper.GetString(config.KeyWxAppID), viper.GetString(config.KeyWxAppSecret)))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	accessToken = gjson.GetBytes(bytes, "access_token").String()
+	if accessToken == "" {
+		return "", errors.New("获取access_token失败")
+	}
+	accessTokenExpire := time.Second * time.Duration(gjson.GetBytes(bytes, "expires_in").Int()-60)
+	if err := s.dao.StoreWXAccessToken(accessToken, accessTokenExpire); err != nil {
+		return "", err
+	}
+	return accessToken, nil
+}
+
 // WXPay 微信支付
 func (s *Service) WXPay(ctx context.Context, customerID uint64) (string, wsgin.APICode, error) {
 	customer, _ := s.dao.FindCustomer(ctx, map[string]interface{}{
@@ -106,13 +110,17 @@ func (s *Service) WXPay(ctx context.Context, customerID uint64) (string, wsgin.A
 		return "", apicode.ErrWXPay, errors.New("未查到用户信息")
 	}
 
-	uncheckeds, err := s.dao.GetAllUnchecked(ctx, customer.ID)
-	if err != nil || len(uncheckeds) == 0 {
-		log.Warn(ctx, "WXPay.GetAllUnchecked()", zap.String("pay: ", "当前用户没有需要补签的记录"))
-		return "", apicode.ErrWXPay, errors.New("当前用户没有需要补签的记录")
+	eligible, supplemented, err := s.supplementRecords(ctx, customer.ID, s.cal.DayStart(time.Now()))
+	if err != nil {
+		return "", apicode.ErrWXPay, err
+	}
+	days, err := s.policy.PayDays(supplemented, len(eligible))
+	if err != nil {
+		log.Info(ctx, "WXPay.PayDays()", zap.Error(err))
+		return "", supplementCode(err, apicode.ErrWXPay), err
 	}
 
-	req := prepareWxpayRequest(ctx, customer.OpenID, len(uncheckeds))
+	req := prepareWxpayRequest(ctx, customer.OpenID, days)
 	ret, err := wxpay.UnifiedOrder(req)
 	if err != nil {
 		return "", apicode.ErrWXPay, errors.WithMessage(err, "当前订单无法支付，请稍候再试")
@@ -120,7 +128,7 @@ func (s *Service) WXPay(ctx context.Context, customerID uint64) (string, wsgin.A
 	//微信小程序支付prepay_id
 	prepayId := ret.GetValue("prepay_id")
 
-	req = miniWxpaySign(prepayId, len(uncheckeds))
+	req = miniWxpaySign(prepayId, days)
 	return req.ToJson(), wsgin.APICodeSuccess, nil
 }
 
@@ -206,18 +214,23 @@ func (s *Service) payOrderComplete(ctx context.Context, orderId string, payFee u
 		return apicode.ErrWXPayNotify, errors.New("支付金额不正确")
 	}
 
-	uncheckeds, err := s.dao.GetAllUnchecked(ctx, customer.ID)
+	// 按实际支付的天数补签，不超过补签规则允许的天数
+	eligible, supplemented, err := s.supplementRecords(ctx, customer.ID, s.cal.DayStart(time.Now()))
 	if err != nil {
 		return apicode.ErrWXPayNotify, err
 	}
-	if len(uncheckeds) == 0 {
-		log.Warn(ctx, "payOrderComplete.GetAllUnchecked()", zap.String("notify: ", "当前用户没有需要补签的记录"))
+	days := int(payFee / uint64(viper.GetFloat64(config.KeyWXPayAmount)*100))
+	if remaining := s.policy.Remaining(supplemented, len(eligible)); days > remaining {
+		days = remaining
+	}
+	if days == 0 {
+		log.Warn(ctx, "payOrderComplete.supplementRecords()", zap.String("notify: ", "当前用户没有需要补签的记录"))
 		return wsgin.APICodeSuccess, nil
 	}
 
-	uncheckedIds := make([]uint64, 0, len(uncheckeds))
-	for i := 0; i < len(uncheckeds); i++ {
-		uncheckedIds = append(uncheckedIds, uncheckeds[i].ID)
+	uncheckedIds := make([]uint64, 0, days)
+	for i := 0; i < days; i++ {
+		uncheckedIds = append(uncheckedIds, eligible[i].ID)
 	}
 	if err := s.dao.PayCheckin(ctx, uncheckedIds, customer.ID, &model.WXPayRecord{
 		OrderID:         orderId,
@@ -228,6 +241,7 @@ func (s *Service) payOrderComplete(ctx context.Context, orderId string, payFee u
 	}); err != nil {
 		return apicode.ErrWXPayNotify, err
 	}
+	s.awardCyclePoints(ctx, customer.ID)
 
 	return wsgin.APICodeSuccess, nil
 }
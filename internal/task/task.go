@@ -14,6 +14,7 @@ import (
 // Run 定时任务执行
 func Run(svc *service.Service) {
 	t := task.Default()
+	t.AddFunc(viper.GetString(config.KeyTaskWelfareRemindInterval), "启动福利过期提醒的任务", svc.RemindExpiringWelfare)
 	t.AddFunc(viper.GetString(config.KeyTaskCheckinExpiredTimeStartInterval), "启动清除失效的任务", svc.FailureIssueRecord)
 	log.Info(context.Background(), "task running")
 	t.Run()